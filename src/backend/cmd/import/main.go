@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	_ "github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"os"
+	"src/configs/backend"
+	"src/db/roomRepo"
+	"src/db/studentRepo"
+	"src/db/thingRepo"
+	"src/db/tokenRepo"
+	"src/db/userRepo"
+	"src/delivery/http/models"
+	"src/logic/controllers/roomController"
+	"src/logic/controllers/studentController"
+	"src/logic/controllers/thingController"
+	"src/logic/controllers/tokenController"
+	"src/logic/controllers/userController"
+	"src/logic/managers/studentManager"
+	utils "src/utils/connection"
+	"src/utils/hashUtils"
+	"src/utils/tableUtils"
+)
+
+var configPath = os.Getenv("CONFIG_FILE")
+
+// Imports students from a CSV or XLSX file, the same as POST /api/v1/students/import:
+//
+//	CONFIG_FILE=config.toml import -file students.xlsx -dry-run
+//	CONFIG_FILE=config.toml import -file students.xlsx -out passwords.csv
+func main() {
+	filePath := flag.String("file", "", "CSV or XLSX file with students")
+	dryRun := flag.Bool("dry-run", false, "only check the file")
+	outPath := flag.String("out", "students.csv", "file for logins and initial passwords")
+	flag.Parse()
+
+	config := configs.CreateConfigForServer()
+	_, err := toml.DecodeFile(configPath, &config)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	format := tableUtils.FormatFromFileName(*filePath)
+	if format == "" {
+		logrus.Fatal("file must be .csv or .xlsx")
+	}
+	data, err := os.ReadFile(*filePath)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	table, err := tableUtils.ReadTable(data, format)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	result, err := createStudentManager(config).ImportStudents(table, *dryRun)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	for _, rowError := range result.Errors {
+		for _, field := range rowError.Fields {
+			fmt.Printf("line %d: %s: %s\n", rowError.Line, field.Field, field.Reason)
+		}
+	}
+	fmt.Printf("rows: %d, valid: %d, skipped: %d\n", result.Total, len(result.Students), len(result.Errors))
+	if *dryRun {
+		return
+	}
+
+	out, err := os.OpenFile(*outPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err == nil {
+		err = tableUtils.WriteCSV(out, models.CreateImportCredentialsTable(result))
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	fmt.Printf("logins and passwords are written to %s\n", *outPath)
+}
+
+func createStudentManager(config *configs.ServerConfig) *studentManager.StudentManager {
+	db := utils.NewPgSQLConnection(config.ConnParams)
+
+	roomRepository := roomRepo.PgRoomRepo{Conn: db}
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	thingRepository := thingRepo.PgThingRepo{Conn: db}
+	userRepository := userRepo.PgUserRepo{Conn: db}
+	tokenRepository := tokenRepo.PgTokenRepo{Conn: db}
+
+	return studentManager.CreateNewStudentManager(
+		roomController.RoomController{Repo: &roomRepository},
+		studentController.StudentController{Repo: &studentRepository},
+		userController.UserController{Repo: &userRepository,
+			Hasher: hashUtils.NewBcryptHasher(config.PasswordHashCost)},
+		thingController.ThingController{Repo: &thingRepository},
+		tokenController.TokenController{Repo: &tokenRepository},
+		nil)
+}
@@ -1,5 +1,7 @@
 package configs
 
+import "time"
+
 type PgSQLConnectionParams struct {
 	Host     string
 	Port     string
@@ -8,9 +10,81 @@ type PgSQLConnectionParams struct {
 	Password string
 }
 
+// JWTKeyParams describes one signing key. HS256 keys use Secret, RS256 and EdDSA keys are read from PEM files.
+// PrivateKeyEnv names an environment variable with the PEM private key, it is used instead of PrivateKeyFile,
+// so private keys come from mounted secrets or the environment and are never stored with the configs.
+// A key without a private part is only used to verify tokens, e.g. the previous key during rotation.
+type JWTKeyParams struct {
+	ID             string `toml:"id"`
+	Algorithm      string `toml:"algorithm"`
+	Secret         string `toml:"secret"`
+	PrivateKeyEnv  string `toml:"private_key_env"`
+	PrivateKeyFile string `toml:"private_key_file"`
+	PublicKeyFile  string `toml:"public_key_file"`
+}
+
+type JWTParams struct {
+	ActiveKey       string         `toml:"active_key"`
+	AccessTokenTTL  time.Duration  `toml:"access_token_ttl"`
+	RefreshTokenTTL time.Duration  `toml:"refresh_token_ttl"`
+	Keys            []JWTKeyParams `toml:"keys"`
+}
+
+// LoginThrottleParams configures the delay after failed logins. Store is "postgres" or "memory",
+// the memory store only works with a single replica. Zero values are replaced with defaults.
+type LoginThrottleParams struct {
+	Store          string        `toml:"store"`
+	FreeAttempts   int           `toml:"free_attempts"`
+	IPFreeAttempts int           `toml:"ip_free_attempts"`
+	BaseDelay      time.Duration `toml:"base_delay"`
+	MaxDelay       time.Duration `toml:"max_delay"`
+	ResetAfter     time.Duration `toml:"reset_after"`
+}
+
+// LDAPParams enables login with university accounts by LDAP bind. The user entry is found with
+// UserFilter, where %s is replaced with the escaped login, then the server binds as this entry.
+// RoleGroups maps group DNs from GroupAttribute to roles of new local users.
+type LDAPParams struct {
+	URL            string         `toml:"url"`
+	BindDN         string         `toml:"bind_dn"`
+	BindPassword   string         `toml:"bind_password"`
+	BaseDN         string         `toml:"base_dn"`
+	UserFilter     string         `toml:"user_filter"`
+	LoginAttribute string         `toml:"login_attribute"`
+	GroupAttribute string         `toml:"group_attribute"`
+	RoleGroups     map[string]int `toml:"role_groups"`
+}
+
+// OIDCParams enables login with university SSO by OIDC authorization code flow.
+// LoginClaim is matched with logins of local users, RoleGroups maps values of GroupsClaim to roles.
+type OIDCParams struct {
+	Name         string         `toml:"name"`
+	Issuer       string         `toml:"issuer"`
+	ClientID     string         `toml:"client_id"`
+	ClientSecret string         `toml:"client_secret"`
+	RedirectURL  string         `toml:"redirect_url"`
+	Scopes       []string       `toml:"scopes"`
+	LoginClaim   string         `toml:"login_claim"`
+	GroupsClaim  string         `toml:"groups_claim"`
+	RoleGroups   map[string]int `toml:"role_groups"`
+}
+
+// AuthProvidersParams lists external providers, a provider without URL or issuer is disabled.
+type AuthProvidersParams struct {
+	LDAP LDAPParams `toml:"ldap"`
+	OIDC OIDCParams `toml:"oidc"`
+}
+
+// ServerConfig.TrustedProxies lists addresses or CIDR networks of proxies whose X-Real-IP header is used
+// as the client address, requests from other addresses are identified by the connection address.
 type ServerConfig struct {
-	PortToStart string                `toml:"start_port"`
-	ConnParams  PgSQLConnectionParams `toml:"server"`
+	PortToStart      string                `toml:"start_port"`
+	PasswordHashCost int                   `toml:"password_hash_cost"`
+	TrustedProxies   []string              `toml:"trusted_proxies"`
+	ConnParams       PgSQLConnectionParams `toml:"server"`
+	JWT              JWTParams             `toml:"jwt"`
+	LoginThrottle    LoginThrottleParams   `toml:"login_throttle"`
+	AuthProviders    AuthProvidersParams   `toml:"auth_providers"`
 }
 
 func CreateConfigForServer() *ServerConfig {
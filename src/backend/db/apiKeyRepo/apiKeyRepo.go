@@ -0,0 +1,12 @@
+package apiKeyRepo
+
+import "src/objects"
+
+type APIKeyRepo interface {
+	AddAPIKey(name, keyHash string, role objects.Levels, readOnly bool, createdBy int) (int, error)
+	GetAPIKeyByHash(keyHash string) (objects.APIKey, error)
+	GetAPIKey(id int) (objects.APIKey, error)
+	GetAPIKeys(page, size int) ([]objects.APIKey, error)
+	RevokeAPIKey(id int) error
+	TouchAPIKey(id int) error
+}
@@ -0,0 +1,93 @@
+package apiKeyRepo
+
+import (
+	"database/sql"
+	pgsql "src/db/sql"
+	"src/objects"
+	"time"
+)
+
+type PgAPIKeyRepo struct {
+	Conn *sql.DB
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func (pg *PgAPIKeyRepo) AddAPIKey(name, keyHash string, role objects.Levels, readOnly bool,
+	createdBy int) (int, error) {
+	var id = objects.None
+	sqlString := pgsql.PostgreSQLAddAPIKey{}.GetString()
+	row := pg.Conn.QueryRow(sqlString, name, keyHash, role, readOnly, createdBy)
+	err := row.Scan(&id)
+	return id, err
+}
+
+func (pg *PgAPIKeyRepo) GetAPIKeyByHash(keyHash string) (objects.APIKey, error) {
+	sqlString := pgsql.PostgreSQLGetAPIKeyByHash{}.GetString()
+	return scanAPIKey(pg.Conn.QueryRow(sqlString, keyHash))
+}
+
+func (pg *PgAPIKeyRepo) GetAPIKey(id int) (objects.APIKey, error) {
+	sqlString := pgsql.PostgreSQLGetAPIKey{}.GetString()
+	return scanAPIKey(pg.Conn.QueryRow(sqlString, id))
+}
+
+func (pg *PgAPIKeyRepo) GetAPIKeys(page, size int) ([]objects.APIKey, error) {
+	var (
+		resultKeys = make([]objects.APIKey, objects.Empty)
+		err        error
+		sizeParam  any
+	)
+	sqlString := pgsql.PostgreSQLGetAPIKeys{}.GetString()
+	if size != objects.Null {
+		sizeParam = size
+	}
+	rows, execError := pg.Conn.Query(sqlString, sizeParam, page*size)
+	if execError == nil {
+		for rows.Next() {
+			tmpKey, scanErr := scanAPIKey(rows)
+			if scanErr == nil {
+				resultKeys = append(resultKeys, tmpKey)
+			}
+		}
+	} else {
+		err = execError
+	}
+	return resultKeys, err
+}
+
+func (pg *PgAPIKeyRepo) RevokeAPIKey(id int) error {
+	sqlString := pgsql.PostgreSQLRevokeAPIKey{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, id)
+	return err
+}
+
+func (pg *PgAPIKeyRepo) TouchAPIKey(id int) error {
+	sqlString := pgsql.PostgreSQLTouchAPIKey{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, id)
+	return err
+}
+
+func scanAPIKey(row scanner) (objects.APIKey, error) {
+	var (
+		id, createdBy int
+		name, keyHash string
+		role          objects.Levels
+		readOnly      bool
+		revoked       bool
+		createdAt     time.Time
+		lastUsedAt    sql.NullTime
+		result        = objects.NewEmptyAPIKey()
+	)
+	err := row.Scan(&id, &name, &keyHash, &role, &readOnly, &createdBy, &createdAt, &lastUsedAt, &revoked)
+	if err == nil {
+		result = objects.NewAPIKeyWithParams(id, name, keyHash, role, readOnly, createdBy, createdAt)
+		result.SetRevoked(revoked)
+		if lastUsedAt.Valid {
+			result.SetLastUsedAt(lastUsedAt.Time)
+		}
+	}
+	return result, err
+}
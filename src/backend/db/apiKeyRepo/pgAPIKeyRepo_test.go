@@ -0,0 +1,141 @@
+package apiKeyRepo
+
+import (
+	"database/sql"
+	"github.com/bloomberg/go-testgroup"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	"src/utils/hashUtils"
+	"testing"
+	"time"
+)
+
+var (
+	InsertID     int64 = 5
+	RowsAffected int64 = 1
+)
+
+type TestPgAPIKeyRepo struct{}
+
+func Test_PgAPIKeyRepo(t *testing.T) {
+	testgroup.RunSerially(t, &TestPgAPIKeyRepo{})
+}
+
+func (*TestPgAPIKeyRepo) TestPgAPIKeyRepo_AddAPIKey(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.APIKeyRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	keyHash := hashUtils.HashToken(mother.DefaultAPIKey)
+	rows := sqlmock.NewRows([]string{"id"}).AddRow(InsertID)
+	mock.ExpectQuery("INSERT INTO").WithArgs(mother.DefaultAPIKeyName, keyHash, objects.SupplyRole, true, 1).
+		WillReturnError(nil).WillReturnRows(rows)
+	repo := PgAPIKeyRepo{Conn: db}
+
+	// Act
+	id, execErr := repo.AddAPIKey(mother.DefaultAPIKeyName, keyHash, objects.SupplyRole, true, 1)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, id, int(InsertID))
+}
+
+func (*TestPgAPIKeyRepo) TestPgAPIKeyRepo_GetAPIKeyByHash(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.APIKeyRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	key := objectMother.CreateAPIKey(objects.ComendRole, false, time.Now(), false)
+	rows := objectMother.CreateRows([]objects.APIKey{key})
+	mock.ExpectQuery("SELECT").WithArgs(key.GetKeyHash()).WillReturnError(nil).WillReturnRows(rows)
+	repo := PgAPIKeyRepo{Conn: db}
+
+	// Act
+	result, execErr := repo.GetAPIKeyByHash(key.GetKeyHash())
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result, key)
+}
+
+func (*TestPgAPIKeyRepo) TestPgAPIKeyRepo_GetAPIKeyNeverUsed(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.APIKeyRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	key := objectMother.CreateAPIKey(objects.SupplyRole, true, time.Time{}, false)
+	rows := objectMother.CreateRows([]objects.APIKey{key})
+	mock.ExpectQuery("SELECT").WithArgs(key.GetID()).WillReturnError(nil).WillReturnRows(rows)
+	repo := PgAPIKeyRepo{Conn: db}
+
+	// Act
+	result, execErr := repo.GetAPIKey(key.GetID())
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result.GetLastUsedAt().IsZero(), true)
+}
+
+func (*TestPgAPIKeyRepo) TestPgAPIKeyRepo_GetAPIKeyNotFound(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.APIKeyRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectQuery("SELECT").WithArgs(int(InsertID)).WillReturnError(sql.ErrNoRows)
+	repo := PgAPIKeyRepo{Conn: db}
+
+	// Act
+	result, execErr := repo.GetAPIKey(int(InsertID))
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrNoRows)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result.GetID(), objects.None)
+}
+
+func (*TestPgAPIKeyRepo) TestPgAPIKeyRepo_GetAPIKeys(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.APIKeyRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	keys := []objects.APIKey{objectMother.CreateAPIKey(objects.ComendRole, false, time.Now(), true)}
+	rows := objectMother.CreateRows(keys)
+	mock.ExpectQuery("SELECT").WithArgs(nil, objects.Null).WillReturnError(nil).WillReturnRows(rows)
+	repo := PgAPIKeyRepo{Conn: db}
+
+	// Act
+	result, execErr := repo.GetAPIKeys(objects.Null, objects.Null)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result, keys)
+}
+
+func (*TestPgAPIKeyRepo) TestPgAPIKeyRepo_RevokeAPIKey(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.APIKeyRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectExec("UPDATE").WithArgs(int(InsertID)).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	repo := PgAPIKeyRepo{Conn: db}
+
+	// Act
+	execErr := repo.RevokeAPIKey(int(InsertID))
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
@@ -0,0 +1,20 @@
+package attemptRepo
+
+import "src/objects"
+
+const (
+	MemoryStore   = "memory"
+	PostgresStore = "postgres"
+)
+
+// AttemptRepo keeps failed login counters. Failures older than ResetAfter are forgotten
+// on the next attempt, so the counter starts from one again.
+//
+// TakeAttempt checks the lock and counts the attempt atomically: it returns false and the current
+// counter if the key is locked, the counter is not changed then. CancelAttempt returns a taken attempt.
+type AttemptRepo interface {
+	GetAttempts(key string) (objects.LoginAttempts, error)
+	TakeAttempt(key string, limits objects.AttemptLimits) (objects.LoginAttempts, bool, error)
+	CancelAttempt(key string) error
+	ResetAttempts(key string) error
+}
@@ -0,0 +1,78 @@
+package attemptRepo
+
+import (
+	"src/objects"
+	"sync"
+	"time"
+)
+
+// maxMemoryEntries bounds the map, stale counters are dropped when it is reached.
+const maxMemoryEntries = 10000
+
+// MemoryAttemptRepo keeps the counters of a single replica, e.g. for local runs without a shared database.
+type MemoryAttemptRepo struct {
+	mutex    sync.Mutex
+	attempts map[string]objects.LoginAttempts
+}
+
+func NewMemoryAttemptRepo() *MemoryAttemptRepo {
+	return &MemoryAttemptRepo{attempts: make(map[string]objects.LoginAttempts)}
+}
+
+func (m *MemoryAttemptRepo) GetAttempts(key string) (objects.LoginAttempts, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	result, isFound := m.attempts[key]
+	if !isFound {
+		result = objects.NewEmptyLoginAttempts(key)
+	}
+	return result, nil
+}
+
+func (m *MemoryAttemptRepo) TakeAttempt(key string, limits objects.AttemptLimits) (objects.LoginAttempts, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	if len(m.attempts) >= maxMemoryEntries {
+		m.removeStale(now, limits.ResetAfter)
+	}
+
+	failures := 1
+	if current, isFound := m.attempts[key]; isFound && !current.IsExpired(limits, now) {
+		if current.LockedUntil(limits).After(now) {
+			return current, false, nil
+		}
+		failures = current.GetFailures() + 1
+	}
+	result := objects.NewLoginAttemptsWithParams(key, failures, now)
+	m.attempts[key] = result
+	return result, true, nil
+}
+
+func (m *MemoryAttemptRepo) CancelAttempt(key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if current, isFound := m.attempts[key]; isFound && current.GetFailures() > objects.Null {
+		m.attempts[key] = objects.NewLoginAttemptsWithParams(key, current.GetFailures()-1, current.GetLastFailure())
+	}
+	return nil
+}
+
+func (m *MemoryAttemptRepo) ResetAttempts(key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.attempts, key)
+	return nil
+}
+
+func (m *MemoryAttemptRepo) removeStale(now time.Time, resetAfter time.Duration) {
+	for key, attempts := range m.attempts {
+		if now.Sub(attempts.GetLastFailure()) > resetAfter {
+			delete(m.attempts, key)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package attemptRepo
+
+import (
+	"github.com/bloomberg/go-testgroup"
+	"src/tests"
+	"src/tests/mother"
+	"testing"
+	"time"
+)
+
+type TestMemoryAttemptRepo struct{}
+
+func Test_MemoryAttemptRepo(t *testing.T) {
+	testgroup.RunSerially(t, &TestMemoryAttemptRepo{})
+}
+
+func (*TestMemoryAttemptRepo) TestMemoryAttemptRepo_TakeAttempt(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	repo := NewMemoryAttemptRepo()
+	_, _, _ = repo.TakeAttempt(mother.DefaultLoginKey, mother.DefaultAttemptLimits)
+
+	// Act
+	result, isTaken, execErr := repo.TakeAttempt(mother.DefaultLoginKey, mother.DefaultAttemptLimits)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertResult(t, result.GetFailures(), 2)
+	tests.AssertResult(t, isTaken, true)
+}
+
+func (*TestMemoryAttemptRepo) TestMemoryAttemptRepo_TakeAttemptLocked(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	repo := NewMemoryAttemptRepo()
+	for i := 0; i <= mother.DefaultAttemptLimits.FreeAttempts; i++ {
+		_, _, _ = repo.TakeAttempt(mother.DefaultLoginKey, mother.DefaultAttemptLimits)
+	}
+
+	// Act
+	result, isTaken, execErr := repo.TakeAttempt(mother.DefaultLoginKey, mother.DefaultAttemptLimits)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertResult(t, result.GetFailures(), mother.DefaultAttemptLimits.FreeAttempts+1)
+	tests.AssertResult(t, isTaken, false)
+}
+
+func (*TestMemoryAttemptRepo) TestMemoryAttemptRepo_TakeAttemptAfterReset(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	repo := NewMemoryAttemptRepo()
+	_, _, _ = repo.TakeAttempt(mother.DefaultLoginKey, mother.DefaultAttemptLimits)
+	_ = repo.ResetAttempts(mother.DefaultLoginKey)
+
+	// Act
+	result, _, execErr := repo.TakeAttempt(mother.DefaultLoginKey, mother.DefaultAttemptLimits)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertResult(t, result.GetFailures(), 1)
+}
+
+func (*TestMemoryAttemptRepo) TestMemoryAttemptRepo_CancelAttempt(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	repo := NewMemoryAttemptRepo()
+	_, _, _ = repo.TakeAttempt(mother.DefaultLoginKey, mother.DefaultAttemptLimits)
+	_, _, _ = repo.TakeAttempt(mother.DefaultLoginKey, mother.DefaultAttemptLimits)
+
+	// Act
+	execErr := repo.CancelAttempt(mother.DefaultLoginKey)
+	result, _ := repo.GetAttempts(mother.DefaultLoginKey)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertResult(t, result.GetFailures(), 1)
+}
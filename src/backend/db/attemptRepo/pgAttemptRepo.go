@@ -0,0 +1,60 @@
+package attemptRepo
+
+import (
+	"database/sql"
+	pgsql "src/db/sql"
+	"src/objects"
+	"time"
+)
+
+// PgAttemptRepo shares the counters between all app replicas.
+type PgAttemptRepo struct {
+	Conn *sql.DB
+}
+
+func (pg *PgAttemptRepo) GetAttempts(key string) (objects.LoginAttempts, error) {
+	sqlString := pgsql.PostgreSQLGetLoginAttempts{}.GetString()
+	row := pg.Conn.QueryRow(sqlString, key)
+	result, err := scanLoginAttempts(row)
+	if err == sql.ErrNoRows {
+		result, err = objects.NewEmptyLoginAttempts(key), nil
+	}
+	return result, err
+}
+
+func (pg *PgAttemptRepo) TakeAttempt(key string, limits objects.AttemptLimits) (objects.LoginAttempts, bool, error) {
+	sqlString := pgsql.PostgreSQLTakeLoginAttempt{}.GetString()
+	row := pg.Conn.QueryRow(sqlString, key, int(limits.ResetAfter.Seconds()), limits.FreeAttempts,
+		limits.BaseDelay.Seconds(), limits.MaxDelay.Seconds())
+	result, err := scanLoginAttempts(row)
+	if err == sql.ErrNoRows {
+		result, err = pg.GetAttempts(key)
+		return result, false, err
+	}
+	return result, err == nil, err
+}
+
+func (pg *PgAttemptRepo) CancelAttempt(key string) error {
+	sqlString := pgsql.PostgreSQLCancelLoginAttempt{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, key)
+	return err
+}
+
+func (pg *PgAttemptRepo) ResetAttempts(key string) error {
+	sqlString := pgsql.PostgreSQLResetLoginAttempts{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, key)
+	return err
+}
+
+func scanLoginAttempts(row *sql.Row) (objects.LoginAttempts, error) {
+	var (
+		key         string
+		failures    int
+		lastFailure time.Time
+	)
+	err := row.Scan(&key, &failures, &lastFailure)
+	if err != nil {
+		return objects.NewEmptyLoginAttempts(key), err
+	}
+	return objects.NewLoginAttemptsWithParams(key, failures, lastFailure), nil
+}
@@ -0,0 +1,143 @@
+package attemptRepo
+
+import (
+	"database/sql"
+	"github.com/bloomberg/go-testgroup"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	"testing"
+	"time"
+)
+
+var (
+	InsertID     int64 = 5
+	RowsAffected int64 = 1
+)
+
+type TestPgAttemptRepo struct{}
+
+func Test_PgAttemptRepo(t *testing.T) {
+	testgroup.RunSerially(t, &TestPgAttemptRepo{})
+}
+
+func (*TestPgAttemptRepo) TestPgAttemptRepo_GetAttempts(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.AttemptRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	attempts := objects.NewLoginAttemptsWithParams(mother.DefaultLoginKey, 3, time.Now())
+	rows := objectMother.CreateRows([]objects.LoginAttempts{attempts})
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLoginKey).WillReturnError(nil).WillReturnRows(rows)
+	repo := PgAttemptRepo{Conn: db}
+
+	// Act
+	result, execErr := repo.GetAttempts(mother.DefaultLoginKey)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result, attempts)
+}
+
+func (*TestPgAttemptRepo) TestPgAttemptRepo_GetAttemptsEmpty(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.AttemptRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLoginKey).WillReturnError(sql.ErrNoRows)
+	repo := PgAttemptRepo{Conn: db}
+
+	// Act
+	result, execErr := repo.GetAttempts(mother.DefaultLoginKey)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result.GetFailures(), objects.Null)
+}
+
+func (*TestPgAttemptRepo) TestPgAttemptRepo_TakeAttempt(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.AttemptRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	attempts := objects.NewLoginAttemptsWithParams(mother.DefaultLoginKey, 1, time.Now())
+	rows := objectMother.CreateRows([]objects.LoginAttempts{attempts})
+	limits := mother.DefaultAttemptLimits
+	mock.ExpectQuery("INSERT INTO").WithArgs(mother.DefaultLoginKey, int(limits.ResetAfter.Seconds()),
+		limits.FreeAttempts, limits.BaseDelay.Seconds(), limits.MaxDelay.Seconds()).
+		WillReturnError(nil).WillReturnRows(rows)
+	repo := PgAttemptRepo{Conn: db}
+
+	// Act
+	result, isTaken, execErr := repo.TakeAttempt(mother.DefaultLoginKey, limits)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result, attempts)
+	tests.AssertResult(t, isTaken, true)
+}
+
+func (*TestPgAttemptRepo) TestPgAttemptRepo_TakeAttemptLocked(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.AttemptRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	attempts := objects.NewLoginAttemptsWithParams(mother.DefaultLoginKey, 5, time.Now())
+	rows := objectMother.CreateRows([]objects.LoginAttempts{attempts})
+	mock.ExpectQuery("INSERT INTO").WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLoginKey).WillReturnError(nil).WillReturnRows(rows)
+	repo := PgAttemptRepo{Conn: db}
+
+	// Act
+	result, isTaken, execErr := repo.TakeAttempt(mother.DefaultLoginKey, mother.DefaultAttemptLimits)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result, attempts)
+	tests.AssertResult(t, isTaken, false)
+}
+
+func (*TestPgAttemptRepo) TestPgAttemptRepo_CancelAttempt(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.AttemptRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectExec("UPDATE").WithArgs(mother.DefaultLoginKey).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	repo := PgAttemptRepo{Conn: db}
+
+	// Act
+	execErr := repo.CancelAttempt(mother.DefaultLoginKey)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestPgAttemptRepo) TestPgAttemptRepo_ResetAttempts(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.AttemptRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectExec("DELETE").WithArgs(mother.DefaultLoginKey).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	repo := PgAttemptRepo{Conn: db}
+
+	// Act
+	execErr := repo.ResetAttempts(mother.DefaultLoginKey)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
@@ -0,0 +1,10 @@
+package buildingRepo
+
+import "src/objects"
+
+type BuildingRepo interface {
+	AddBuilding(building objects.BuildingDTO) error
+	GetBuildings(page, size int) ([]objects.Building, error)
+	GetBuilding(id int) (objects.Building, error)
+	BuildingNameExists(name string) (bool, error)
+}
@@ -0,0 +1,63 @@
+package buildingRepo
+
+import (
+	"database/sql"
+	"src/db/sql"
+	"src/objects"
+)
+
+type PgBuildingRepo struct {
+	Conn *sql.DB
+}
+
+func (pg *PgBuildingRepo) AddBuilding(building objects.BuildingDTO) error {
+	sqlString := pgsql.PostgreSQLAddBuilding{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, building.GetName(), building.GetAddress(), building.GetFloors())
+	return err
+}
+
+func (pg *PgBuildingRepo) GetBuildings(page, size int) ([]objects.Building, error) {
+	var (
+		resultBuildings = make([]objects.Building, objects.Empty)
+		id, floors      int
+		name, address   string
+		err             error
+		sizeParam       any
+	)
+	sqlString := pgsql.PostgreSQLGetBuildings{}.GetString()
+	if size != objects.Null {
+		sizeParam = size
+	}
+	rows, execError := pg.Conn.Query(sqlString, sizeParam, page*size)
+	if execError == nil {
+		for rows.Next() {
+			scanErr := rows.Scan(&id, &name, &address, &floors)
+			if scanErr == nil {
+				resultBuildings = append(resultBuildings, objects.NewBuildingWithParams(id, name, address, floors))
+			}
+		}
+	} else {
+		err = execError
+	}
+	return resultBuildings, err
+}
+
+func (pg *PgBuildingRepo) GetBuilding(id int) (objects.Building, error) {
+	var (
+		buildingID, floors int
+		name, address      string
+	)
+	sqlString := pgsql.PostgreSQLGetBuilding{}.GetString()
+	err := pg.Conn.QueryRow(sqlString, id).Scan(&buildingID, &name, &address, &floors)
+	if err != nil {
+		return objects.NewEmptyBuilding(), err
+	}
+	return objects.NewBuildingWithParams(buildingID, name, address, floors), nil
+}
+
+func (pg *PgBuildingRepo) BuildingNameExists(name string) (bool, error) {
+	var exist bool
+	sqlString := pgsql.PostgreSQLBuildingNameExists{}.GetString()
+	err := pg.Conn.QueryRow(sqlString, name).Scan(&exist)
+	return exist, err
+}
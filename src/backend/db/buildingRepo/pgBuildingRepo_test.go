@@ -0,0 +1,125 @@
+package buildingRepo
+
+import (
+	"database/sql"
+	"github.com/bloomberg/go-testgroup"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	"testing"
+	"time"
+)
+
+const (
+	InsertID     = 5
+	RowsAffected = 1
+)
+
+type TestPgBuildingRepo struct{}
+
+func Test_PgBuildingRepo(t *testing.T) {
+	testgroup.RunSerially(t, &TestPgBuildingRepo{})
+}
+
+func (*TestPgBuildingRepo) TestPgBuildingRepo_AddBuilding(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.BuildingRepoObjectMother{}
+	buildingDTO := objectMother.CreateBuildingDTO()
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectExec("INSERT INTO").
+		WithArgs(buildingDTO.GetName(), buildingDTO.GetAddress(), buildingDTO.GetFloors()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	repo := PgBuildingRepo{Conn: db}
+
+	// Act
+	execErr := repo.AddBuilding(buildingDTO)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestPgBuildingRepo) TestPgBuildingRepo_GetBuildings(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.BuildingRepoObjectMother{}
+	N := 3
+	db, mock := objectMother.CreateRepo()
+	realBuildings := objectMother.CreateDefaultBuildings(N)
+	rows := objectMother.CreateRows(realBuildings)
+	mock.ExpectQuery("SELECT").WithArgs(nil, objects.Null).WillReturnRows(rows).WillReturnError(nil)
+	repo := PgBuildingRepo{Conn: db}
+
+	// Act
+	resultBuildings, execErr := repo.GetBuildings(objects.Null, objects.Null)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, resultBuildings, realBuildings)
+}
+
+// TestPgBuildingRepo_GetBuildingPositive проверяет, что если корпус есть, он успешно вернётся.
+func (*TestPgBuildingRepo) TestPgBuildingRepo_GetBuildingPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.BuildingRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	id := 1
+	realBuildings := objectMother.CreateDefaultBuildings(1)
+	rows := objectMother.CreateRows(realBuildings)
+	mock.ExpectQuery("SELECT").WithArgs(id).WillReturnError(nil).WillReturnRows(rows)
+	repo := PgBuildingRepo{Conn: db}
+
+	// Act
+	building, execErr := repo.GetBuilding(id)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, building, realBuildings[0])
+}
+
+// TestPgBuildingRepo_GetBuildingNegative проверяет, что если корпуса нет, то вернётся ошибка.
+func (*TestPgBuildingRepo) TestPgBuildingRepo_GetBuildingNegative(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.BuildingRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectQuery("SELECT").WithArgs(InsertID).WillReturnError(sql.ErrNoRows)
+	repo := PgBuildingRepo{Conn: db}
+
+	// Act
+	_, execErr := repo.GetBuilding(InsertID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrNoRows)
+	tests.AssertMocks(t, mock)
+}
+
+// TestPgBuildingRepo_BuildingNameExists проверяет, что корпус ищется по названию.
+func (*TestPgBuildingRepo) TestPgBuildingRepo_BuildingNameExists(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.BuildingRepoObjectMother{}
+	name := mother.DefaultBuildingName + "1"
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectQuery("SELECT EXISTS").WithArgs(name).WillReturnError(nil).
+		WillReturnRows(objectMother.CreateExistsRows(true))
+	repo := PgBuildingRepo{Conn: db}
+
+	// Act
+	exist, execErr := repo.BuildingNameExists(name)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, exist, true)
+}
@@ -4,7 +4,6 @@ import (
 	"database/sql"
 	"src/db/sql"
 	"src/objects"
-	"strconv"
 )
 
 type PgRoomRepo struct {
@@ -13,30 +12,41 @@ type PgRoomRepo struct {
 
 func (pg *PgRoomRepo) AddRoom(room objects.RoomDTO) error {
 	sqlString := pgsql.PostgreSQLAddRoom{}.GetString()
-	_, err := pg.Conn.Exec(sqlString, room.GetRoomType(), room.GetRoomNumber())
+	_, err := pg.Conn.Exec(sqlString, room.GetRoomType(), room.GetRoomNumber(), room.GetCapacity(), room.GetGender(),
+		room.GetBuildingID(), room.GetFloor(), room.GetBlock())
 	return err
 }
 
-func (pg *PgRoomRepo) GetRooms(page, size int) ([]objects.Room, error) {
+func (pg *PgRoomRepo) UpdateRoom(id int, room objects.RoomDTO) error {
+	sqlString := pgsql.PostgreSQLUpdateRoom{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, id, room.GetRoomType(), room.GetRoomNumber(), room.GetCapacity(),
+		room.GetGender(), room.GetBuildingID(), room.GetFloor(), room.GetBlock())
+	return err
+}
+
+func (pg *PgRoomRepo) GetRooms(filter objects.RoomFilter, page, size int) ([]objects.Room, error) {
 	var (
-		resultRooms    = make([]objects.Room, objects.Empty)
-		id, roomNumber int
-		roomType       string
-		err            error
-		sizeParam      string
+		resultRooms                                           = make([]objects.Room, objects.Empty)
+		id, roomNumber, capacity, occupied, buildingID, floor int
+		roomType, block                                       string
+		gender                                                objects.Gender
+		err                                                   error
+		sizeParam                                             any
 	)
 	sqlString := pgsql.PostgreSQLGetRooms{}.GetString()
 	if size != objects.Null {
-		sizeParam = strconv.Itoa(size)
-	} else {
-		sizeParam = "ALL"
+		sizeParam = size
 	}
-	rows, execError := pg.Conn.Query(sqlString, sizeParam, page*size)
+	rows, execError := pg.Conn.Query(sqlString, sizeParam, page*size, filterParam(filter.GetBuildingID()),
+		filterParam(filter.GetFloor()))
 	if execError == nil {
 		for rows.Next() {
-			scanErr := rows.Scan(&id, &roomType, &roomNumber)
+			scanErr := rows.Scan(&id, &roomType, &roomNumber, &capacity, &gender, &buildingID, &floor, &block,
+				&occupied)
 			if scanErr == nil {
-				tmpRoom := objects.NewRoomWithParams(id, roomType, roomNumber)
+				tmpRoom := objects.NewRoomWithParams(id, roomType, roomNumber, capacity, gender)
+				tmpRoom.SetLocation(buildingID, floor, block)
+				tmpRoom.SetOccupied(occupied)
 				resultRooms = append(resultRooms, tmpRoom)
 			}
 		}
@@ -48,18 +58,22 @@ func (pg *PgRoomRepo) GetRooms(page, size int) ([]objects.Room, error) {
 
 func (pg *PgRoomRepo) GetRoom(id int) (objects.Room, error) {
 	var (
-		resultRoom         = objects.NewEmptyRoom()
-		roomID, roomNumber int
-		roomType           string
-		err                error
+		resultRoom                                                = objects.NewEmptyRoom()
+		roomID, roomNumber, capacity, occupied, buildingID, floor int
+		roomType, block                                           string
+		gender                                                    objects.Gender
+		err                                                       error
 	)
 	sqlString := pgsql.PostgreSQLGetRoom{}.GetString()
 	rows, execError := pg.Conn.Query(sqlString, id)
 	if execError == nil {
 		for rows.Next() {
-			scanErr := rows.Scan(&roomID, &roomType, &roomNumber)
+			scanErr := rows.Scan(&roomID, &roomType, &roomNumber, &capacity, &gender, &buildingID, &floor, &block,
+				&occupied)
 			if scanErr == nil {
-				resultRoom = objects.NewRoomWithParams(id, roomType, roomNumber)
+				resultRoom = objects.NewRoomWithParams(id, roomType, roomNumber, capacity, gender)
+				resultRoom.SetLocation(buildingID, floor, block)
+				resultRoom.SetOccupied(occupied)
 			}
 		}
 	} else {
@@ -94,8 +108,32 @@ func (pg *PgRoomRepo) GetRoomThings(id int) ([]objects.Thing, error) {
 	return resultThings, err
 }
 
+// GetRoomHistoryCount returns how many student and thing transfer records refer to the room.
+func (pg *PgRoomRepo) GetRoomHistoryCount(id int) (int, error) {
+	var count int
+	sqlString := pgsql.PostgreSQLGetRoomHistoryCount{}.GetString()
+	err := pg.Conn.QueryRow(sqlString, id).Scan(&count)
+	return count, err
+}
+
+// RoomNumberExists checks whether the building has a room with the number other than exceptID.
+func (pg *PgRoomRepo) RoomNumberExists(buildingID, roomNumber, exceptID int) (bool, error) {
+	var exist bool
+	sqlString := pgsql.PostgreSQLRoomNumberExists{}.GetString()
+	err := pg.Conn.QueryRow(sqlString, buildingID, roomNumber, exceptID).Scan(&exist)
+	return exist, err
+}
+
 func (pg *PgRoomRepo) DeleteRoom(id int) error {
 	sqlString := pgsql.PostgreSQLDeleteRoom{}.GetString()
 	_, err := pg.Conn.Exec(sqlString, id)
 	return err
 }
+
+// filterParam passes filters which are not set (None) as NULL, the query skips them.
+func filterParam(value int) any {
+	if value == objects.None {
+		return nil
+	}
+	return value
+}
@@ -4,8 +4,11 @@ import "src/objects"
 
 type RoomRepo interface {
 	AddRoom(room objects.RoomDTO) error
-	GetRooms(page, size int) ([]objects.Room, error)
+	GetRooms(filter objects.RoomFilter, page, size int) ([]objects.Room, error)
 	GetRoom(id int) (objects.Room, error)
 	GetRoomThings(id int) ([]objects.Thing, error)
+	UpdateRoom(id int, room objects.RoomDTO) error
+	GetRoomHistoryCount(id int) (int, error)
+	RoomNumberExists(buildingID, roomNumber, exceptID int) (bool, error)
 	DeleteRoom(id int) error
 }
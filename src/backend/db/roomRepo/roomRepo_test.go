@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"github.com/bloomberg/go-testgroup"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/objects"
 	"src/tests"
 	"src/tests/mother"
 	"testing"
@@ -35,7 +36,7 @@ func (*TestPgRoomRepo) TestPgRoomRepo_GetRooms(t *testgroup.T) {
 	repo := PgRoomRepo{Conn: db}
 
 	// Act
-	resultRooms, execErr := repo.GetRooms()
+	resultRooms, execErr := repo.GetRooms(objects.NewEmptyRoomFilter(), objects.Null, objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
@@ -44,6 +45,30 @@ func (*TestPgRoomRepo) TestPgRoomRepo_GetRooms(t *testgroup.T) {
 
 }
 
+// TestPgRoomRepo_GetRoomsFilter проверяет, что незаданный этаж передаётся в запрос как NULL.
+func (*TestPgRoomRepo) TestPgRoomRepo_GetRoomsFilter(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.RoomRepoObjectMother{}
+	N := 3
+	db, mock := objectMother.CreateRepo()
+	realRooms := objectMother.CreateDefaultRooms(N)
+	rows := objectMother.CreateRows(realRooms)
+	mock.ExpectQuery("SELECT").WithArgs(nil, objects.Null, mother.DefaultBuildingID, nil).
+		WillReturnRows(rows).WillReturnError(nil)
+	repo := PgRoomRepo{Conn: db}
+
+	// Act
+	resultRooms, execErr := repo.GetRooms(objects.NewRoomFilter(mother.DefaultBuildingID, objects.None),
+		objects.Null, objects.Null)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, resultRooms, realRooms)
+}
+
 func (*TestPgRoomRepo) TestPgRoomRepo_AddRoom(t *testgroup.T) {
 	defer tests.TimeTrack(time.Now())
 	// Arrange
@@ -51,7 +76,9 @@ func (*TestPgRoomRepo) TestPgRoomRepo_AddRoom(t *testgroup.T) {
 	objectMother := mother.RoomRepoObjectMother{}
 	roomDTO := objectMother.CreateDTORoom()
 	db, mock := objectMother.CreateRepo()
-	mock.ExpectExec("INSERT INTO").WithArgs(roomDTO.GetRoomType(), roomDTO.GetRoomNumber()).
+	mock.ExpectExec("INSERT INTO").
+		WithArgs(roomDTO.GetRoomType(), roomDTO.GetRoomNumber(), roomDTO.GetCapacity(), roomDTO.GetGender(),
+			roomDTO.GetBuildingID(), roomDTO.GetFloor(), roomDTO.GetBlock()).
 		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
 	repo := PgRoomRepo{Conn: db}
 
@@ -123,6 +150,71 @@ func (*TestPgRoomRepo) TestPgRoomRepo_DeleteRoom(t *testgroup.T) {
 	tests.AssertMocks(t, mock)
 }
 
+func (*TestPgRoomRepo) TestPgRoomRepo_UpdateRoom(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.RoomRepoObjectMother{}
+	roomDTO := objectMother.CreateDTORoom()
+	ID := 1
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectExec("UPDATE").
+		WithArgs(ID, roomDTO.GetRoomType(), roomDTO.GetRoomNumber(), roomDTO.GetCapacity(), roomDTO.GetGender(),
+			roomDTO.GetBuildingID(), roomDTO.GetFloor(), roomDTO.GetBlock()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(int64(ID), RowsAffected))
+	repo := PgRoomRepo{Conn: db}
+
+	// Act
+	execErr := repo.UpdateRoom(ID, roomDTO)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestPgRoomRepo) TestPgRoomRepo_GetRoomHistoryCount(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.RoomRepoObjectMother{}
+	ID := 1
+	realCount := 4
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(realCount))
+	repo := PgRoomRepo{Conn: db}
+
+	// Act
+	count, execErr := repo.GetRoomHistoryCount(ID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, count, realCount)
+}
+
+// TestPgRoomRepo_RoomNumberExists проверяет, что номер ищется только среди других комнат корпуса.
+func (*TestPgRoomRepo) TestPgRoomRepo_RoomNumberExists(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.RoomRepoObjectMother{}
+	ID := 1
+	roomNumber := 2
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectQuery("SELECT EXISTS").WithArgs(mother.DefaultBuildingID, roomNumber, ID).WillReturnError(nil).
+		WillReturnRows(objectMother.CreateExistsRows(true))
+	repo := PgRoomRepo{Conn: db}
+
+	// Act
+	exist, execErr := repo.RoomNumberExists(mother.DefaultBuildingID, roomNumber, ID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, exist, true)
+}
+
 func (*TestPgRoomRepo) TestPgRoomRepo_GetRoomThings(t *testgroup.T) {
 	defer tests.TimeTrack(time.Now())
 	// Arrange
@@ -0,0 +1,211 @@
+package settlementRepo
+
+import (
+	"database/sql"
+	"src/db/sql"
+	"src/db/studentRepo"
+	"src/objects"
+	"time"
+)
+
+type PgSettlementRepo struct {
+	Conn *sql.DB
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// AddRequest writes the request together with its first pending record in the status history.
+func (pg *PgSettlementRepo) AddRequest(studentID int, preferences objects.SettlementPreferences,
+	priority, createdBy int) (int, error) {
+	var id = objects.None
+	tx, err := pg.Conn.Begin()
+	if err != nil {
+		return id, err
+	}
+
+	sqlString := pgsql.PostgreSQLAddSettlementRequest{}.GetString()
+	err = tx.QueryRow(sqlString, studentID, optionalParam(preferences.GetBuildingID()),
+		optionalParam(preferences.GetFloor()), optionalParam(preferences.GetRoomID()), preferences.GetComment(),
+		priority).Scan(&id)
+	if err == nil {
+		sqlString = pgsql.PostgreSQLAddSettlementStatusChange{}.GetString()
+		_, err = tx.Exec(sqlString, id, objects.SettlementPending, optionalParam(createdBy),
+			preferences.GetComment())
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return objects.None, err
+	}
+	return id, tx.Commit()
+}
+
+func (pg *PgSettlementRepo) GetRequest(id int) (objects.SettlementRequest, error) {
+	sqlString := pgsql.PostgreSQLGetSettlementRequest{}.GetString()
+	return scanSettlementRequest(pg.Conn.QueryRow(sqlString, id))
+}
+
+// GetRequests returns requests with the status, the most prioritized first.
+func (pg *PgSettlementRepo) GetRequests(status objects.SettlementStatus,
+	page, size int) ([]objects.SettlementRequest, error) {
+	var sizeParam any
+	if size != objects.Null {
+		sizeParam = size
+	}
+	sqlString := pgsql.PostgreSQLGetSettlementRequests{}.GetString()
+	rows, err := pg.Conn.Query(sqlString, sizeParam, page*size, status)
+	if err != nil {
+		return make([]objects.SettlementRequest, objects.Empty), err
+	}
+	return scanSettlementRequests(rows)
+}
+
+func (pg *PgSettlementRepo) GetStudentRequests(studentID int) ([]objects.SettlementRequest, error) {
+	sqlString := pgsql.PostgreSQLGetStudentSettlementRequests{}.GetString()
+	rows, err := pg.Conn.Query(sqlString, studentID)
+	if err != nil {
+		return make([]objects.SettlementRequest, objects.Empty), err
+	}
+	return scanSettlementRequests(rows)
+}
+
+// ChangeRequestStatus closes a pending request and records the transition.
+// sql.ErrNoRows means the request is not pending anymore.
+func (pg *PgSettlementRepo) ChangeRequestStatus(id int, status objects.SettlementStatus, changedBy int,
+	comment string) error {
+	tx, err := pg.Conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = changeRequestStatusTx(tx, id, status, changedBy, comment)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// ApproveRequest settles the student of a pending request into the room and closes the request
+// in one transaction. The request is locked first, so it can't be closed meanwhile;
+// sql.ErrNoRows means the request is not pending anymore. See studentRepo.SettleStudentTx for check.
+func (pg *PgSettlementRepo) ApproveRequest(id, roomID, changedBy int, comment string,
+	check studentRepo.RoomCheck) error {
+	tx, err := pg.Conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	var studentID int
+	sqlString := pgsql.PostgreSQLLockSettlementRequest{}.GetString()
+	err = tx.QueryRow(sqlString, id).Scan(&studentID)
+	if err == nil {
+		err = studentRepo.SettleStudentTx(tx, studentID, roomID, changedBy, check)
+	}
+	if err == nil {
+		err = changeRequestStatusTx(tx, id, objects.SettlementApproved, changedBy, comment)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func changeRequestStatusTx(tx *sql.Tx, id int, status objects.SettlementStatus, changedBy int,
+	comment string) error {
+	sqlString := pgsql.PostgreSQLChangeSettlementStatus{}.GetString()
+	result, err := tx.Exec(sqlString, id, status)
+	if err == nil {
+		var affected int64
+		if affected, err = result.RowsAffected(); err == nil && affected == objects.Null {
+			err = sql.ErrNoRows
+		}
+	}
+	if err == nil {
+		sqlString = pgsql.PostgreSQLAddSettlementStatusChange{}.GetString()
+		_, err = tx.Exec(sqlString, id, status, optionalParam(changedBy), comment)
+	}
+	return err
+}
+
+func (pg *PgSettlementRepo) ChangeRequestPriority(id, priority int) error {
+	sqlString := pgsql.PostgreSQLChangeSettlementPriority{}.GetString()
+	result, err := pg.Conn.Exec(sqlString, id, priority)
+	if err == nil {
+		var affected int64
+		if affected, err = result.RowsAffected(); err == nil && affected == objects.Null {
+			err = sql.ErrNoRows
+		}
+	}
+	return err
+}
+
+func (pg *PgSettlementRepo) GetRequestHistory(id int) ([]objects.SettlementStatusChange, error) {
+	var (
+		resultChanges      = make([]objects.SettlementStatusChange, objects.Empty)
+		status             objects.SettlementStatus
+		changedAt          time.Time
+		changedBy, comment string
+		err                error
+	)
+	sqlString := pgsql.PostgreSQLGetSettlementHistory{}.GetString()
+	rows, execError := pg.Conn.Query(sqlString, id)
+	if execError == nil {
+		for rows.Next() {
+			scanErr := rows.Scan(&status, &changedAt, &changedBy, &comment)
+			if scanErr == nil {
+				resultChanges = append(resultChanges,
+					objects.NewSettlementStatusChange(status, changedAt, changedBy, comment))
+			} else {
+				err = scanErr
+				break
+			}
+		}
+	} else {
+		err = execError
+	}
+	return resultChanges, err
+}
+
+func scanSettlementRequests(rows *sql.Rows) ([]objects.SettlementRequest, error) {
+	var (
+		resultRequests = make([]objects.SettlementRequest, objects.Empty)
+		err            error
+	)
+	for rows.Next() {
+		request, scanErr := scanSettlementRequest(rows)
+		if scanErr != nil {
+			err = scanErr
+			break
+		}
+		resultRequests = append(resultRequests, request)
+	}
+	return resultRequests, err
+}
+
+func scanSettlementRequest(row scanner) (objects.SettlementRequest, error) {
+	var (
+		id, studentID, buildingID, floor, roomID, priority int
+		studentNumber, comment                             string
+		status                                             objects.SettlementStatus
+		createdAt                                          time.Time
+	)
+	err := row.Scan(&id, &studentID, &studentNumber, &buildingID, &floor, &roomID, &comment, &priority, &status,
+		&createdAt)
+	if err != nil {
+		return objects.NewEmptySettlementRequest(), err
+	}
+	preferences := objects.NewSettlementPreferences(buildingID, floor, roomID, comment)
+	return objects.NewSettlementRequestWithParams(id, studentID, studentNumber, preferences, priority, status,
+		createdAt), nil
+}
+
+// optionalParam stores values which are not set (None) as NULL.
+func optionalParam(value int) any {
+	if value == objects.None {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,303 @@
+package settlementRepo
+
+import (
+	"database/sql"
+	"github.com/bloomberg/go-testgroup"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	"testing"
+	"time"
+)
+
+const (
+	InsertID     = 5
+	RowsAffected = 1
+)
+
+type TestPgSettlementRepo struct{}
+
+func Test_PgSettlementRepo(t *testing.T) {
+	testgroup.RunSerially(t, &TestPgSettlementRepo{})
+}
+
+func (*TestPgSettlementRepo) TestPgSettlementRepo_AddRequest(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	studentID := 1
+	preferences := objectMother.CreatePreferences()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO").
+		WithArgs(studentID, preferences.GetBuildingID(), preferences.GetFloor(), nil, preferences.GetComment(),
+			mother.DefaultPriority).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(InsertID))
+	mock.ExpectExec("INSERT INTO").
+		WithArgs(InsertID, objects.SettlementPending, mother.DefaultStaffID, preferences.GetComment()).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
+	repo := PgSettlementRepo{Conn: db}
+
+	// Act
+	id, execErr := repo.AddRequest(studentID, preferences, mother.DefaultPriority, mother.DefaultStaffID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, id, InsertID)
+}
+
+// TestPgSettlementRepo_AddRequestHistoryFailed проверяет, что заявка не сохранится без записи в истории.
+func (*TestPgSettlementRepo) TestPgSettlementRepo_AddRequestHistoryFailed(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	studentID := 1
+	preferences := objectMother.CreatePreferences()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(InsertID))
+	mock.ExpectExec("INSERT INTO").WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+	repo := PgSettlementRepo{Conn: db}
+
+	// Act
+	id, execErr := repo.AddRequest(studentID, preferences, mother.DefaultPriority, objects.None)
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrConnDone)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, id, objects.None)
+}
+
+func (*TestPgSettlementRepo) TestPgSettlementRepo_GetRequest(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	realRequests := objectMother.CreateDefaultRequests(1, objects.SettlementPending)
+	mock.ExpectQuery("SELECT").WithArgs(realRequests[0].GetID()).
+		WillReturnRows(objectMother.CreateRows(realRequests))
+	repo := PgSettlementRepo{Conn: db}
+
+	// Act
+	request, execErr := repo.GetRequest(realRequests[0].GetID())
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, request, realRequests[0])
+}
+
+// TestPgSettlementRepo_GetRequestNotFound проверяет, что для несуществующей заявки вернётся sql.ErrNoRows.
+func (*TestPgSettlementRepo) TestPgSettlementRepo_GetRequestNotFound(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	id := 10
+	mock.ExpectQuery("SELECT").WithArgs(id).WillReturnRows(objectMother.CreateRows(nil))
+	repo := PgSettlementRepo{Conn: db}
+
+	// Act
+	request, execErr := repo.GetRequest(id)
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrNoRows)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, request.GetID(), objects.None)
+}
+
+func (*TestPgSettlementRepo) TestPgSettlementRepo_GetRequests(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	N := 3
+	realRequests := objectMother.CreateDefaultRequests(N, objects.SettlementPending)
+	mock.ExpectQuery("SELECT").WithArgs(nil, objects.Null, objects.SettlementPending).
+		WillReturnRows(objectMother.CreateRows(realRequests))
+	repo := PgSettlementRepo{Conn: db}
+
+	// Act
+	resultRequests, execErr := repo.GetRequests(objects.SettlementPending, objects.Null, objects.Null)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, resultRequests, realRequests)
+}
+
+func (*TestPgSettlementRepo) TestPgSettlementRepo_GetStudentRequests(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	studentID := 1
+	realRequests := objectMother.CreateDefaultRequests(1, objects.SettlementRejected)
+	mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnRows(objectMother.CreateRows(realRequests))
+	repo := PgSettlementRepo{Conn: db}
+
+	// Act
+	resultRequests, execErr := repo.GetStudentRequests(studentID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, resultRequests, realRequests)
+}
+
+func (*TestPgSettlementRepo) TestPgSettlementRepo_ChangeRequestStatus(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	id := 1
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE").WithArgs(id, objects.SettlementRejected).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").
+		WithArgs(id, objects.SettlementRejected, mother.DefaultStaffID, mother.DefaultSettlementComment).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
+	repo := PgSettlementRepo{Conn: db}
+
+	// Act
+	execErr := repo.ChangeRequestStatus(id, objects.SettlementRejected, mother.DefaultStaffID,
+		mother.DefaultSettlementComment)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+// TestPgSettlementRepo_ChangeRequestStatusNotPending проверяет, что закрытая заявка не изменится и не попадёт в историю.
+func (*TestPgSettlementRepo) TestPgSettlementRepo_ChangeRequestStatusNotPending(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	id := 1
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE").WithArgs(id, objects.SettlementCancelled).
+		WillReturnResult(sqlmock.NewResult(InsertID, objects.Null))
+	mock.ExpectRollback()
+	repo := PgSettlementRepo{Conn: db}
+
+	// Act
+	execErr := repo.ChangeRequestStatus(id, objects.SettlementCancelled, mother.DefaultStaffID,
+		objects.EmptyString)
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrNoRows)
+	tests.AssertMocks(t, mock)
+}
+
+// TestPgSettlementRepo_ApproveRequest проверяет, что заселение и закрытие заявки пишутся в одной транзакции.
+func (*TestPgSettlementRepo) TestPgSettlementRepo_ApproveRequest(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SettlementRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	id, studentID := 1, 1
+	room := roomObjectMother.CreateRoom(1, objects.Null, objects.GenderAny)
+	students := mother.StudentRepoObjectMother{}.CreateDefaultStudents(1)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT").WithArgs(id).
+		WillReturnRows(mother.StudentRepoObjectMother{}.CreateRowForID(studentID))
+	roomObjectMother.ExpectLocks(mock, studentID, students, room)
+	mock.ExpectExec("INSERT").WithArgs(studentID, room.GetID(), objects.Get, mother.DefaultStaffID).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("UPDATE").WithArgs(id, objects.SettlementApproved).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").WithArgs(id, objects.SettlementApproved, mother.DefaultStaffID,
+		objects.EmptyString).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
+	repo := PgSettlementRepo{Conn: db}
+
+	// Act
+	execErr := repo.ApproveRequest(id, room.GetID(), mother.DefaultStaffID, objects.EmptyString,
+		func(objects.Student, objects.Room) error { return nil })
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+// TestPgSettlementRepo_ApproveRequestCheckFailed проверяет, что при неподходящей комнате заявка остаётся открытой.
+func (*TestPgSettlementRepo) TestPgSettlementRepo_ApproveRequestCheckFailed(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SettlementRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	id, studentID := 1, 1
+	room := roomObjectMother.CreateRoom(1, objects.Null, objects.GenderAny)
+	students := mother.StudentRepoObjectMother{}.CreateDefaultStudents(1)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT").WithArgs(id).
+		WillReturnRows(mother.StudentRepoObjectMother{}.CreateRowForID(studentID))
+	roomObjectMother.ExpectLocks(mock, studentID, students, room)
+	mock.ExpectRollback()
+	repo := PgSettlementRepo{Conn: db}
+
+	// Act
+	execErr := repo.ApproveRequest(id, room.GetID(), mother.DefaultStaffID, objects.EmptyString,
+		func(objects.Student, objects.Room) error { return sql.ErrTxDone })
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrTxDone)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestPgSettlementRepo) TestPgSettlementRepo_ChangeRequestPriority(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	id, priority := 1, 10
+	mock.ExpectExec("UPDATE").WithArgs(id, priority).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	repo := PgSettlementRepo{Conn: db}
+
+	// Act
+	execErr := repo.ChangeRequestPriority(id, priority)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestPgSettlementRepo) TestPgSettlementRepo_GetRequestHistory(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	id := 1
+	realHistory := objectMother.CreateHistory(objects.SettlementApproved)
+	mock.ExpectQuery("SELECT").WithArgs(id).WillReturnRows(objectMother.CreateHistoryRows(realHistory))
+	repo := PgSettlementRepo{Conn: db}
+
+	// Act
+	resultHistory, execErr := repo.GetRequestHistory(id)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, resultHistory, realHistory)
+}
@@ -0,0 +1,17 @@
+package settlementRepo
+
+import (
+	"src/db/studentRepo"
+	"src/objects"
+)
+
+type SettlementRepo interface {
+	AddRequest(studentID int, preferences objects.SettlementPreferences, priority, createdBy int) (int, error)
+	GetRequest(id int) (objects.SettlementRequest, error)
+	GetRequests(status objects.SettlementStatus, page, size int) ([]objects.SettlementRequest, error)
+	GetStudentRequests(studentID int) ([]objects.SettlementRequest, error)
+	ChangeRequestStatus(id int, status objects.SettlementStatus, changedBy int, comment string) error
+	ApproveRequest(id, roomID, changedBy int, comment string, check studentRepo.RoomCheck) error
+	ChangeRequestPriority(id, priority int) error
+	GetRequestHistory(id int) ([]objects.SettlementStatusChange, error)
+}
@@ -6,37 +6,108 @@ import (
 
 type PostgreSQLChangeStudent struct{}
 type PostgreSQLGetStudent struct{}
+type PostgreSQLGetRoomStudents struct{}
 type PostgreSQLGetStudentID struct{}
 type PostgreSQLGetStudentsThings struct{}
 type PostgreSQLGetAllStudents struct{}
+type PostgreSQLFindStudents struct{}
+type PostgreSQLCountStudents struct{}
+type PostgreSQLChangeStudentStatus struct{}
+type PostgreSQLCountStudentThings struct{}
+type PostgreSQLGetStudentFees struct{}
+type PostgreSQLCountStudentFees struct{}
+type PostgreSQLAddStudentUser struct{}
 type PostgreSQLAddStudent struct{}
 type PostgreSQLTransferStudent struct{}
+type PostgreSQLLockStudent struct{}
+type PostgreSQLLockRoom struct{}
+type PostgreSQLCountRoomStudents struct{}
+type PostgreSQLGetStudentLivingHistory struct{}
 type PostgreSQLTransferThing struct{}
 type PostgreSQLAddRoom struct{}
 type PostgreSQLGetRooms struct{}
 type PostgreSQLGetRoom struct{}
 type PostgreSQLGetRoomThings struct{}
 type PostgreSQLDeleteRoom struct{}
+type PostgreSQLUpdateRoom struct{}
+type PostgreSQLGetRoomHistoryCount struct{}
+type PostgreSQLRoomNumberExists struct{}
 type PostgreSQLTransferThingRoom struct{}
 type PostgreSQLAddThing struct{}
 type PostgreSQLGetThings struct{}
 type PostgreSQLGetThing struct{}
 type PostgreSQLGetThingID struct{}
 type PostgreSQLDeleteThing struct{}
+type PostgreSQLGetThingOwnerHistory struct{}
+type PostgreSQLGetThingRoomHistory struct{}
 type PostgreSQLGetUserId struct{}
 type PostgreSQLGetUser struct{}
 type PostgreSQLAddUser struct{}
+type PostgreSQLChangeUserPassword struct{}
+type PostgreSQLGetUsers struct{}
+type PostgreSQLSetUserDisabled struct{}
+type PostgreSQLChangeUserRole struct{}
+type PostgreSQLGetUserIDByExternalIdentity struct{}
+type PostgreSQLAddExternalIdentity struct{}
+type PostgreSQLAddRefreshToken struct{}
+type PostgreSQLGetRefreshToken struct{}
+type PostgreSQLRevokeRefreshToken struct{}
+type PostgreSQLRevokeUserRefreshTokens struct{}
+type PostgreSQLRevokeAccessToken struct{}
+type PostgreSQLCheckAccessToken struct{}
+type PostgreSQLRevokeUserAccessTokens struct{}
+type PostgreSQLAddPasswordReset struct{}
+type PostgreSQLGetPasswordReset struct{}
+type PostgreSQLUsePasswordReset struct{}
+type PostgreSQLCancelUserPasswordResets struct{}
+type PostgreSQLGetLoginAttempts struct{}
+type PostgreSQLAddAPIKey struct{}
+type PostgreSQLGetAPIKeyByHash struct{}
+type PostgreSQLGetAPIKey struct{}
+type PostgreSQLGetAPIKeys struct{}
+type PostgreSQLRevokeAPIKey struct{}
+type PostgreSQLTouchAPIKey struct{}
+type PostgreSQLTakeLoginAttempt struct{}
+type PostgreSQLCancelLoginAttempt struct{}
+type PostgreSQLResetLoginAttempts struct{}
+type PostgreSQLAddBuilding struct{}
+type PostgreSQLGetBuildings struct{}
+type PostgreSQLGetBuilding struct{}
+type PostgreSQLBuildingNameExists struct{}
+type PostgreSQLAddSettlementRequest struct{}
+type PostgreSQLGetSettlementRequest struct{}
+type PostgreSQLGetSettlementRequests struct{}
+type PostgreSQLGetStudentSettlementRequests struct{}
+type PostgreSQLLockSettlementRequest struct{}
+type PostgreSQLChangeSettlementStatus struct{}
+type PostgreSQLChangeSettlementPriority struct{}
+type PostgreSQLAddSettlementStatusChange struct{}
+type PostgreSQLGetSettlementHistory struct{}
+type PostgreSQLAddRoomSwap struct{}
+type PostgreSQLGetRoomSwap struct{}
+type PostgreSQLGetRoomSwaps struct{}
+type PostgreSQLGetStudentRoomSwaps struct{}
+type PostgreSQLChangeRoomSwapStatus struct{}
 
 func (pg PostgreSQLChangeStudent) GetString() string {
 	return "UPDATE  Student SET StudentName = $1, StudentSurname = $2, " +
-		"StudentGroup = $3, StudentNumber = $4 WHERE StudentID = $5;"
+		"StudentGroup = $3, StudentNumber = $4, Gender = $6, Patronymic = $7, Faculty = $8, Course = $9, " +
+		"Phone = $10, Email = $11, DateOfBirth = $12 WHERE StudentID = $5;"
 }
 
 func (pg PostgreSQLGetStudent) GetString() string {
 	return "SELECT S.studentid, S.webaccid, S.studentname, S.studentsurname, " +
-		"S.studentgroup, S.studentnumber,  FindStudentRoom(S.studentid) " +
+		"S.studentgroup, S.studentnumber,  FindStudentRoom(S.studentid), S.gender, " +
+		"S.patronymic, S.faculty, S.course, S.phone, S.email, S.dateofbirth, S.status " +
 		"FROM  Student as S WHERE S.studentid = $1;"
 }
+func (pg PostgreSQLGetRoomStudents) GetString() string {
+	return "SELECT S.studentid, S.webaccid, S.studentname, S.studentsurname, " +
+		"S.studentgroup, S.studentnumber,  FindStudentRoom(S.studentid), S.gender, " +
+		"S.patronymic, S.faculty, S.course, S.phone, S.email, S.dateofbirth, S.status " +
+		"FROM  Student as S WHERE FindStudentRoom(S.studentid) = $1 ORDER BY S.studentid;"
+}
+
 func (pg PostgreSQLGetStudentID) GetString() string {
 	return "SELECT S.studentid FROM  Student as S WHERE StudentNumber = $1;"
 }
@@ -48,24 +119,107 @@ func (pg PostgreSQLGetStudentsThings) GetString() string {
 func (pg PostgreSQLGetAllStudents) GetWithParamsString() string {
 	return "SELECT S.studentid, S.webaccid, S.studentname, S.studentsurname, " +
 		"S.studentgroup, S.studentnumber, " +
-		" FindStudentRoom(S.studentid) " +
+		" FindStudentRoom(S.studentid), S.gender, " +
+		"S.patronymic, S.faculty, S.course, S.phone, S.email, S.dateofbirth, S.status " +
 		"FROM  Student as S ORDER BY S.studentid LIMIT $1 OFFSET $2;"
 }
 
 func (pg PostgreSQLGetAllStudents) GetEmptyString() string {
 	return "SELECT S.studentid, S.webaccid, S.studentname, S.studentsurname, " +
 		"S.studentgroup, S.studentnumber, " +
-		" FindStudentRoom(S.studentid) " +
+		" FindStudentRoom(S.studentid), S.gender, " +
+		"S.patronymic, S.faculty, S.course, S.phone, S.email, S.dateofbirth, S.status " +
 		"FROM  Student as S;"
 }
 
+// studentFilterCondition takes the filter params of StudentFilter as $1-$7, NULL means any.
+const studentFilterCondition = "WHERE ($1::text IS NULL OR S.studentsurname ILIKE $1) " +
+	"AND ($2::text IS NULL OR S.studentgroup = $2) AND ($3::text IS NULL OR S.faculty = $3) " +
+	"AND ($4::int IS NULL OR FindStudentRoom(S.studentid) = $4) " +
+	"AND ($5::bool IS NULL OR (FindStudentRoom(S.studentid) <> 0) = $5) " +
+	"AND ($6::bool IS NULL OR EXISTS(SELECT 1 FROM Thing as T WHERE FindStudent(T.thingid) = S.studentid) = $6) " +
+	"AND ($7::text IS NULL OR S.status = $7) "
+
+// studentSortColumns maps sort params of StudentFilter to ORDER BY columns, students are sorted by id by default.
+var studentSortColumns = map[string]string{
+	"surname": "S.studentsurname, S.studentname",
+	"group":   "S.studentgroup, S.studentsurname",
+	"number":  "S.studentnumber",
+	"room":    "FindStudentRoom(S.studentid)",
+	"course":  "S.course",
+}
+
+func (pg PostgreSQLFindStudents) GetString(sortBy string, descending bool) string {
+	orderBy, isFound := studentSortColumns[sortBy]
+	if !isFound {
+		orderBy = "S.studentid"
+	}
+	order := "ASC"
+	if descending {
+		order = "DESC"
+	}
+	return fmt.Sprintf("SELECT S.studentid, S.webaccid, S.studentname, S.studentsurname, "+
+		"S.studentgroup, S.studentnumber, FindStudentRoom(S.studentid), S.gender, "+
+		"S.patronymic, S.faculty, S.course, S.phone, S.email, S.dateofbirth, S.status "+
+		"FROM Student as S %sORDER BY %s %s, S.studentid LIMIT $8 OFFSET $9;", studentFilterCondition, orderBy, order)
+}
+
+func (pg PostgreSQLCountStudents) GetString() string {
+	return "SELECT count(*) FROM Student as S " + studentFilterCondition + ";"
+}
+
+func (pg PostgreSQLChangeStudentStatus) GetString() string {
+	return "UPDATE Student SET Status = $2, StatusDate = current_date WHERE StudentID = $1;"
+}
+
+func (pg PostgreSQLCountStudentThings) GetString() string {
+	return "SELECT count(*) FROM  Thing as T WHERE FindStudent(T.ThingID) = $1;"
+}
+
+func (pg PostgreSQLGetStudentFees) GetString() string {
+	return "SELECT F.id, F.studentid, F.amount, F.description, F.duedate FROM StudentFees as F " +
+		"WHERE F.studentid = $1 AND F.paiddate IS NULL ORDER BY F.duedate, F.id;"
+}
+
+func (pg PostgreSQLCountStudentFees) GetString() string {
+	return "SELECT count(*) FROM StudentFees as F WHERE F.studentid = $1 AND F.paiddate IS NULL;"
+}
+
+func (pg PostgreSQLAddStudentUser) GetString() string {
+	return "INSERT INTO  Users(userlogin, userpassword, userrole) VALUES ($1, $2, $3) RETURNING id;"
+}
+
 func (pg PostgreSQLAddStudent) GetString() string {
 	return "INSERT INTO  Student(studentname, studentsurname, studentgroup, " +
-		"studentnumber, settledate, webaccid) VALUES ($1, $2, $3, $4, current_date, $5);"
+		"studentnumber, settledate, webaccid, gender) VALUES ($1, $2, $3, $4, current_date, $5, $6);"
 }
 func (pg PostgreSQLTransferStudent) GetString() string {
-	return "INSERT INTO  StudentRoomHistory (studentid, roomid, direction, transferdate) " +
-		"VALUES ($1, $2, $3, current_date);"
+	return "INSERT INTO  StudentRoomHistory (studentid, roomid, direction, transferdate, performedby) " +
+		"VALUES ($1, $2, $3, current_date, $4);"
+}
+
+// PostgreSQLLockStudent locks the student row till the end of the transaction, so living acts of the student
+// are written by one transaction at a time.
+func (pg PostgreSQLLockStudent) GetString() string {
+	return "SELECT studentid FROM  Student WHERE studentid = $1 FOR UPDATE;"
+}
+
+// PostgreSQLLockRoom locks the room row, settlements into the room wait for each other. Residents are counted
+// by PostgreSQLCountRoomStudents after the lock: a new statement sees the acts committed while it waited.
+func (pg PostgreSQLLockRoom) GetString() string {
+	return "SELECT roomid, roomtype, roomnumber, capacity, gender FROM  rooms WHERE RoomID = $1 FOR UPDATE;"
+}
+
+func (pg PostgreSQLCountRoomStudents) GetString() string {
+	return "SELECT count(*) FROM  Student as S WHERE FindStudentRoom(S.studentid) = $1;"
+}
+
+func (pg PostgreSQLGetStudentLivingHistory) GetString() string {
+	return "SELECT SRH.id, SRH.studentid, SRH.roomid, SRH.direction, SRH.transferdate, " +
+		"COALESCE(U.userlogin, '') FROM  StudentRoomHistory as SRH LEFT JOIN Users as U on (SRH.performedby = U.id) " +
+		"WHERE SRH.studentid = $1 AND ($2::date IS NULL OR SRH.transferdate >= $2) " +
+		"AND ($3::date IS NULL OR SRH.transferdate <= $3) " +
+		"ORDER BY SRH.transferdate, SRH.id LIMIT $4 OFFSET $5;"
 }
 func (pg PostgreSQLTransferThing) GetString() string {
 	return "INSERT INTO  StudentThingHistory (studentid, thingid, direction, transferdate)" +
@@ -73,15 +227,21 @@ func (pg PostgreSQLTransferThing) GetString() string {
 }
 
 func (pg PostgreSQLAddRoom) GetString() string {
-	return "INSERT INTO  Rooms(roomtype, roomnumber) VALUES ('$1', $2)"
+	return "INSERT INTO  Rooms(roomtype, roomnumber, capacity, gender, buildingid, floor, block) " +
+		"VALUES ($1, $2, $3, $4, $5, $6, $7);"
 }
 
 func (pg PostgreSQLGetRooms) GetString() string {
-	return "SELECT roomid, roomtype, roomnumber FROM rooms LIMIT $1 OFFSET $2;"
+	return "SELECT R.roomid, R.roomtype, R.roomnumber, R.capacity, R.gender, R.buildingid, R.floor, R.block, " +
+		"(SELECT count(*) FROM Student as S WHERE FindStudentRoom(S.studentid) = R.roomid) " +
+		"FROM rooms as R WHERE ($3::int IS NULL OR R.buildingid = $3) AND ($4::int IS NULL OR R.floor = $4) " +
+		"ORDER BY R.roomid LIMIT $1 OFFSET $2;"
 }
 
 func (pg PostgreSQLGetRoom) GetString() string {
-	return "SELECT roomid, roomtype, roomnumber FROM  rooms WHERE RoomID = $1;"
+	return "SELECT R.roomid, R.roomtype, R.roomnumber, R.capacity, R.gender, R.buildingid, R.floor, R.block, " +
+		"(SELECT count(*) FROM Student as S WHERE FindStudentRoom(S.studentid) = R.roomid) " +
+		"FROM  rooms as R WHERE R.RoomID = $1;"
 }
 
 func (pg PostgreSQLGetRoomThings) GetString() string {
@@ -92,6 +252,22 @@ func (pg PostgreSQLDeleteRoom) GetString() string {
 	return "DELETE FROM  rooms WHERE RoomID = $1;"
 }
 
+func (pg PostgreSQLUpdateRoom) GetString() string {
+	return "UPDATE  rooms SET roomtype = $2, roomnumber = $3, capacity = $4, gender = $5, " +
+		"buildingid = $6, floor = $7, block = $8 WHERE RoomID = $1;"
+}
+
+func (pg PostgreSQLGetRoomHistoryCount) GetString() string {
+	return "SELECT (SELECT count(*) FROM  StudentRoomHistory WHERE roomid = $1) + " +
+		"(SELECT count(*) FROM  ThingRoomHistory WHERE srcroomid = $1 OR dstroomid = $1) + " +
+		"(SELECT count(*) FROM  SettlementRequests WHERE roomid = $1) + " +
+		"(SELECT count(*) FROM  RoomSwaps WHERE initiatorroomid = $1 OR partnerroomid = $1);"
+}
+
+func (pg PostgreSQLRoomNumberExists) GetString() string {
+	return "SELECT EXISTS(SELECT 1 FROM  rooms WHERE buildingid = $1 AND roomnumber = $2 AND roomid <> $3);"
+}
+
 func (pg PostgreSQLTransferThingRoom) GetString() string {
 	return "INSERT INTO  ThingRoomHistory (srcroomid, dstroomid, thingid, transferdate) VALUES " +
 		"($1, $2, $3, current_date);"
@@ -127,15 +303,256 @@ func (pg PostgreSQLDeleteThing) GetString() string {
 	return "DELETE FROM  Thing WHERE ThingID = $1;"
 }
 
+func (pg PostgreSQLGetThingOwnerHistory) GetString() string {
+	return "SELECT STH.direction, STH.transferdate, S.studentnumber FROM  StudentThingHistory as STH " +
+		"JOIN Student as S on (STH.studentid = S.studentid) WHERE STH.thingid = $1 ORDER BY STH.transferdate, STH.id;"
+}
+
+func (pg PostgreSQLGetThingRoomHistory) GetString() string {
+	return "SELECT TRH.srcroomid, TRH.dstroomid, TRH.transferdate FROM  ThingRoomHistory as TRH " +
+		"WHERE TRH.thingid = $1 ORDER BY TRH.transferdate, TRH.id;"
+}
+
 func (pg PostgreSQLGetUserId) GetString() string {
 	return "SELECT id FROM  Users WHERE UserLogin = $1;"
 }
 
 func (pg PostgreSQLGetUser) GetString() string {
-	return "SELECT id, userlogin, userpassword, userrole FROM  Users WHERE ID = $1;"
+	return "SELECT id, userlogin, userpassword, userrole, disabled FROM  Users WHERE ID = $1;"
 }
 
 func (pg PostgreSQLAddUser) GetString() string {
 	return "INSERT INTO  Users(userlogin, userpassword, userrole) VALUES " +
 		"($1, $2, $3);"
 }
+
+func (pg PostgreSQLChangeUserPassword) GetString() string {
+	return "UPDATE  Users SET UserPassword = $1 WHERE ID = $2;"
+}
+
+func (pg PostgreSQLGetUsers) GetString() string {
+	return "SELECT id, userlogin, userpassword, userrole, disabled FROM  Users ORDER BY id LIMIT $1 OFFSET $2;"
+}
+
+func (pg PostgreSQLSetUserDisabled) GetString() string {
+	return "UPDATE  Users SET Disabled = $1 WHERE ID = $2;"
+}
+
+func (pg PostgreSQLChangeUserRole) GetString() string {
+	return "UPDATE  Users SET UserRole = $1 WHERE ID = $2;"
+}
+
+func (pg PostgreSQLGetUserIDByExternalIdentity) GetString() string {
+	return "SELECT userid FROM  ExternalIdentities WHERE Provider = $1 AND Subject = $2;"
+}
+
+func (pg PostgreSQLAddExternalIdentity) GetString() string {
+	return "INSERT INTO  ExternalIdentities(provider, subject, userid) VALUES ($1, $2, $3);"
+}
+
+func (pg PostgreSQLAddRefreshToken) GetString() string {
+	return "INSERT INTO  RefreshTokens(userid, tokenhash, expiresat, revoked, createdat) VALUES " +
+		"($1, $2, $3, false, now());"
+}
+
+func (pg PostgreSQLGetRefreshToken) GetString() string {
+	return "SELECT id, userid, tokenhash, expiresat, revoked FROM  RefreshTokens WHERE TokenHash = $1;"
+}
+
+func (pg PostgreSQLRevokeRefreshToken) GetString() string {
+	return "UPDATE  RefreshTokens SET Revoked = true WHERE ID = $1 AND Revoked = false;"
+}
+
+func (pg PostgreSQLRevokeUserRefreshTokens) GetString() string {
+	return "UPDATE  RefreshTokens SET Revoked = true WHERE UserID = $1 AND Revoked = false;"
+}
+
+func (pg PostgreSQLRevokeAccessToken) GetString() string {
+	return "INSERT INTO  RevokedTokens(tokenid, expiresat) VALUES ($1, $2) ON CONFLICT (tokenid) DO NOTHING;"
+}
+
+func (pg PostgreSQLCheckAccessToken) GetString() string {
+	return "SELECT (SELECT count(*) FROM  RevokedTokens WHERE TokenID = $1 AND ExpiresAt > now()) + " +
+		"(SELECT count(*) FROM  UserRevocations WHERE UserID = $2 AND RevokedAt > $3);"
+}
+
+// PostgreSQLRevokeUserAccessTokens truncates the time to seconds like iat of the token,
+// so a token issued right after the revocation stays valid.
+func (pg PostgreSQLRevokeUserAccessTokens) GetString() string {
+	return "INSERT INTO  UserRevocations(userid, revokedat) VALUES ($1, date_trunc('second', now())) " +
+		"ON CONFLICT (userid) DO UPDATE SET RevokedAt = EXCLUDED.RevokedAt;"
+}
+
+func (pg PostgreSQLAddPasswordReset) GetString() string {
+	return "INSERT INTO  PasswordResets(userid, tokenhash, expiresat, used, createdat) VALUES " +
+		"($1, $2, $3, false, now());"
+}
+
+func (pg PostgreSQLGetPasswordReset) GetString() string {
+	return "SELECT id, userid, tokenhash, expiresat, used FROM  PasswordResets WHERE TokenHash = $1;"
+}
+
+func (pg PostgreSQLUsePasswordReset) GetString() string {
+	return "UPDATE  PasswordResets SET Used = true WHERE ID = $1 AND Used = false;"
+}
+
+func (pg PostgreSQLCancelUserPasswordResets) GetString() string {
+	return "UPDATE  PasswordResets SET Used = true WHERE UserID = $1 AND Used = false;"
+}
+
+func (pg PostgreSQLGetLoginAttempts) GetString() string {
+	return "SELECT key, failures, lastfailure FROM  LoginAttempts WHERE Key = $1;"
+}
+
+// PostgreSQLTakeLoginAttempt checks the lock and counts the attempt in one statement, so concurrent
+// requests and replicas can't pass the limit together. No row is returned if the key is locked.
+// $2 is the period in seconds after which old failures are forgotten, $3 is the number of free attempts,
+// $4 and $5 are the base and the max delay in seconds. The exponent is capped as AttemptLimits.Delay stops doubling,
+// so a long series of failures can't overflow float8 and break every next login of the key.
+func (pg PostgreSQLTakeLoginAttempt) GetString() string {
+	return "INSERT INTO  LoginAttempts(key, failures, lastfailure) VALUES ($1, 1, now()) " +
+		"ON CONFLICT (key) DO UPDATE SET Failures = CASE " +
+		"WHEN LoginAttempts.LastFailure < now() - $2 * interval '1 second' THEN 1 " +
+		"ELSE LoginAttempts.Failures + 1 END, LastFailure = now() " +
+		"WHERE LoginAttempts.LastFailure < now() - $2 * interval '1 second' " +
+		"OR LoginAttempts.Failures <= $3 " +
+		"OR LoginAttempts.LastFailure + least($4::float8 * " +
+		"power(2::float8, least(LoginAttempts.Failures - $3 - 1, 30)), " +
+		"$5::float8) * interval '1 second' <= now() " +
+		"RETURNING key, failures, lastfailure;"
+}
+
+// PostgreSQLCancelLoginAttempt returns a taken attempt that turned out not to be a failure.
+func (pg PostgreSQLCancelLoginAttempt) GetString() string {
+	return "UPDATE  LoginAttempts SET Failures = Failures - 1 WHERE Key = $1 AND Failures > 0;"
+}
+
+func (pg PostgreSQLResetLoginAttempts) GetString() string {
+	return "DELETE FROM  LoginAttempts WHERE Key = $1;"
+}
+
+func (pg PostgreSQLAddAPIKey) GetString() string {
+	return "INSERT INTO  ApiKeys(name, keyhash, role, readonly, createdby, createdat) VALUES " +
+		"($1, $2, $3, $4, $5, now()) RETURNING id;"
+}
+
+func (pg PostgreSQLGetAPIKeyByHash) GetString() string {
+	return "SELECT id, name, keyhash, role, readonly, createdby, createdat, lastusedat, revoked " +
+		"FROM  ApiKeys WHERE KeyHash = $1;"
+}
+
+func (pg PostgreSQLGetAPIKey) GetString() string {
+	return "SELECT id, name, keyhash, role, readonly, createdby, createdat, lastusedat, revoked " +
+		"FROM  ApiKeys WHERE ID = $1;"
+}
+
+func (pg PostgreSQLGetAPIKeys) GetString() string {
+	return "SELECT id, name, keyhash, role, readonly, createdby, createdat, lastusedat, revoked " +
+		"FROM  ApiKeys ORDER BY id LIMIT $1 OFFSET $2;"
+}
+
+func (pg PostgreSQLRevokeAPIKey) GetString() string {
+	return "UPDATE  ApiKeys SET Revoked = true WHERE ID = $1;"
+}
+
+func (pg PostgreSQLTouchAPIKey) GetString() string {
+	return "UPDATE  ApiKeys SET LastUsedAt = now() WHERE ID = $1;"
+}
+
+func (pg PostgreSQLAddBuilding) GetString() string {
+	return "INSERT INTO  Buildings(name, address, floors) VALUES ($1, $2, $3);"
+}
+
+func (pg PostgreSQLGetBuildings) GetString() string {
+	return "SELECT B.buildingid, B.name, B.address, B.floors FROM  Buildings as B " +
+		"ORDER BY B.buildingid LIMIT $1 OFFSET $2;"
+}
+
+func (pg PostgreSQLGetBuilding) GetString() string {
+	return "SELECT B.buildingid, B.name, B.address, B.floors FROM  Buildings as B WHERE B.buildingid = $1;"
+}
+
+func (pg PostgreSQLBuildingNameExists) GetString() string {
+	return "SELECT EXISTS(SELECT 1 FROM  Buildings WHERE name = $1);"
+}
+
+func (pg PostgreSQLAddSettlementRequest) GetString() string {
+	return "INSERT INTO  SettlementRequests(studentid, buildingid, floor, roomid, comment, priority) " +
+		"VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;"
+}
+
+func (pg PostgreSQLGetSettlementRequest) GetString() string {
+	return "SELECT SR.id, SR.studentid, S.studentnumber, COALESCE(SR.buildingid, -1), COALESCE(SR.floor, -1), " +
+		"COALESCE(SR.roomid, -1), SR.comment, SR.priority, SR.status, SR.createdat " +
+		"FROM  SettlementRequests as SR JOIN Student as S on (SR.studentid = S.studentid) " +
+		"WHERE SR.id = $1;"
+}
+
+func (pg PostgreSQLGetSettlementRequests) GetString() string {
+	return "SELECT SR.id, SR.studentid, S.studentnumber, COALESCE(SR.buildingid, -1), COALESCE(SR.floor, -1), " +
+		"COALESCE(SR.roomid, -1), SR.comment, SR.priority, SR.status, SR.createdat " +
+		"FROM  SettlementRequests as SR JOIN Student as S on (SR.studentid = S.studentid) " +
+		"WHERE SR.status = $3 ORDER BY SR.priority DESC, SR.createdat, SR.id LIMIT $1 OFFSET $2;"
+}
+
+func (pg PostgreSQLGetStudentSettlementRequests) GetString() string {
+	return "SELECT SR.id, SR.studentid, S.studentnumber, COALESCE(SR.buildingid, -1), COALESCE(SR.floor, -1), " +
+		"COALESCE(SR.roomid, -1), SR.comment, SR.priority, SR.status, SR.createdat " +
+		"FROM  SettlementRequests as SR JOIN Student as S on (SR.studentid = S.studentid) " +
+		"WHERE SR.studentid = $1 ORDER BY SR.createdat, SR.id;"
+}
+
+// PostgreSQLLockSettlementRequest locks a pending request, approvals and closings of it wait for each other.
+func (pg PostgreSQLLockSettlementRequest) GetString() string {
+	return "SELECT studentid FROM  SettlementRequests WHERE id = $1 AND status = 'pending' FOR UPDATE;"
+}
+
+func (pg PostgreSQLChangeSettlementStatus) GetString() string {
+	return "UPDATE  SettlementRequests SET status = $2 WHERE id = $1 AND status = 'pending';"
+}
+
+func (pg PostgreSQLChangeSettlementPriority) GetString() string {
+	return "UPDATE  SettlementRequests SET priority = $2 WHERE id = $1 AND status = 'pending';"
+}
+
+func (pg PostgreSQLAddSettlementStatusChange) GetString() string {
+	return "INSERT INTO  SettlementRequestHistory(requestid, status, changedby, comment) VALUES ($1, $2, $3, $4);"
+}
+
+func (pg PostgreSQLGetSettlementHistory) GetString() string {
+	return "SELECT SRH.status, SRH.changedat, COALESCE(U.userlogin, ''), SRH.comment " +
+		"FROM  SettlementRequestHistory as SRH LEFT JOIN Users as U on (SRH.changedby = U.id) " +
+		"WHERE SRH.requestid = $1 ORDER BY SRH.changedat, SRH.id;"
+}
+
+func (pg PostgreSQLAddRoomSwap) GetString() string {
+	return "INSERT INTO  RoomSwaps(initiatorid, initiatorroomid, partnerid, partnerroomid) " +
+		"VALUES ($1, $2, $3, $4) RETURNING id;"
+}
+
+func (pg PostgreSQLGetRoomSwap) GetString() string {
+	return "SELECT RS.id, RS.initiatorid, SI.studentnumber, RS.initiatorroomid, RS.partnerid, SP.studentnumber, " +
+		"RS.partnerroomid, RS.status, RS.createdat, RS.updatedat " +
+		"FROM  RoomSwaps as RS JOIN Student as SI on (RS.initiatorid = SI.studentid) " +
+		"JOIN Student as SP on (RS.partnerid = SP.studentid) WHERE RS.id = $1;"
+}
+
+func (pg PostgreSQLGetRoomSwaps) GetString() string {
+	return "SELECT RS.id, RS.initiatorid, SI.studentnumber, RS.initiatorroomid, RS.partnerid, SP.studentnumber, " +
+		"RS.partnerroomid, RS.status, RS.createdat, RS.updatedat " +
+		"FROM  RoomSwaps as RS JOIN Student as SI on (RS.initiatorid = SI.studentid) " +
+		"JOIN Student as SP on (RS.partnerid = SP.studentid) " +
+		"WHERE RS.status = $3 ORDER BY RS.updatedat, RS.id LIMIT $1 OFFSET $2;"
+}
+
+func (pg PostgreSQLGetStudentRoomSwaps) GetString() string {
+	return "SELECT RS.id, RS.initiatorid, SI.studentnumber, RS.initiatorroomid, RS.partnerid, SP.studentnumber, " +
+		"RS.partnerroomid, RS.status, RS.createdat, RS.updatedat " +
+		"FROM  RoomSwaps as RS JOIN Student as SI on (RS.initiatorid = SI.studentid) " +
+		"JOIN Student as SP on (RS.partnerid = SP.studentid) " +
+		"WHERE RS.initiatorid = $1 OR RS.partnerid = $1 ORDER BY RS.createdat, RS.id;"
+}
+
+func (pg PostgreSQLChangeRoomSwapStatus) GetString() string {
+	return "UPDATE  RoomSwaps SET status = $3, updatedat = now() WHERE id = $1 AND status = $2;"
+}
@@ -4,9 +4,12 @@ import (
 	"database/sql"
 	"src/db/sql"
 	"src/objects"
-	"strconv"
+	"strings"
+	"time"
 )
 
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type PgStudentRepo struct {
 	Conn *sql.DB
 }
@@ -14,18 +17,42 @@ type PgStudentRepo struct {
 func (pg *PgStudentRepo) AddStudent(newStudent objects.StudentDTO, accID int) error {
 	sqlString := pgsql.PostgreSQLAddStudent{}.GetString()
 	_, err := pg.Conn.Exec(sqlString, newStudent.GetName(), newStudent.GetSurname(), newStudent.GetStudentGroup(),
-		newStudent.GetStudentNumber(), accID)
+		newStudent.GetStudentNumber(), accID, newStudent.GetGender())
 	return err
 }
 
+// AddStudentAccounts creates users and students in one transaction: nothing is added if any row fails.
+func (pg *PgStudentRepo) AddStudentAccounts(accounts []objects.StudentAccount) error {
+	tx, err := pg.Conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	addUserString := pgsql.PostgreSQLAddStudentUser{}.GetString()
+	addStudentString := pgsql.PostgreSQLAddStudent{}.GetString()
+	for _, account := range accounts {
+		var accID int
+		err = tx.QueryRow(addUserString, account.GetLogin(), account.GetPasswordHash(), objects.StudentRole).
+			Scan(&accID)
+		if err == nil {
+			student := account.GetStudent()
+			_, err = tx.Exec(addStudentString, student.GetName(), student.GetSurname(), student.GetStudentGroup(),
+				student.GetStudentNumber(), accID, student.GetGender())
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 func (pg *PgStudentRepo) GetAllStudents(page, size int) ([]objects.Student, error) {
 	var (
-		resultStudents                                           = make([]objects.Student, objects.Empty)
-		studentID, accID, roomID                                 int
-		studentName, studentSurname, studentGroup, studentNumber string
-		err                                                      error
-		execErr                                                  error
-		rows                                                     *sql.Rows
+		resultStudents = make([]objects.Student, objects.Empty)
+		err            error
+		execErr        error
+		rows           *sql.Rows
 	)
 	if size == objects.Null {
 		sqlString := pgsql.PostgreSQLGetAllStudents{}.GetEmptyString()
@@ -36,10 +63,8 @@ func (pg *PgStudentRepo) GetAllStudents(page, size int) ([]objects.Student, erro
 	}
 	if execErr == nil {
 		for rows.Next() {
-			scanErr := rows.Scan(&studentID, &accID, &studentName, &studentSurname, &studentGroup, &studentNumber, &roomID)
+			tmpStudent, scanErr := scanStudent(rows)
 			if scanErr == nil {
-				tmpStudent := objects.NewStudentWithParams(studentID, accID, studentName, studentSurname,
-					studentGroup, studentNumber, roomID)
 				resultStudents = append(resultStudents, tmpStudent)
 			} else {
 				err = scanErr
@@ -51,6 +76,70 @@ func (pg *PgStudentRepo) GetAllStudents(page, size int) ([]objects.Student, erro
 	return resultStudents, err
 }
 
+// FindStudents returns a page of students matching the filter, sorted as the filter says.
+func (pg *PgStudentRepo) FindStudents(filter objects.StudentFilter, page, size int) ([]objects.Student, error) {
+	var (
+		resultStudents = make([]objects.Student, objects.Empty)
+		err            error
+		sizeParam      any
+	)
+	if size != objects.Null {
+		sizeParam = size
+	}
+	sqlString := pgsql.PostgreSQLFindStudents{}.GetString(filter.GetSortBy(), filter.IsDescending())
+	params := append(studentFilterParams(filter), sizeParam, page*size)
+	rows, execError := pg.Conn.Query(sqlString, params...)
+	if execError == nil {
+		for rows.Next() {
+			tmpStudent, scanErr := scanStudent(rows)
+			if scanErr == nil {
+				resultStudents = append(resultStudents, tmpStudent)
+			} else {
+				err = scanErr
+				break
+			}
+		}
+	} else {
+		err = execError
+	}
+	return resultStudents, err
+}
+
+// CountStudents returns the number of students matching the filter on all pages.
+func (pg *PgStudentRepo) CountStudents(filter objects.StudentFilter) (int, error) {
+	var result int
+	sqlString := pgsql.PostgreSQLCountStudents{}.GetString()
+	err := pg.Conn.QueryRow(sqlString, studentFilterParams(filter)...).Scan(&result)
+	return result, err
+}
+
+// studentFilterParams stores "any" filter values as NULL. Surname prefix is escaped for ILIKE.
+func studentFilterParams(filter objects.StudentFilter) []any {
+	var surnameParam, groupParam, facultyParam, roomParam, livingParam, hasThingsParam, statusParam any
+	if filter.GetSurnamePrefix() != objects.EmptyString {
+		surnameParam = likeEscaper.Replace(filter.GetSurnamePrefix()) + "%"
+	}
+	if filter.GetGroup() != objects.EmptyString {
+		groupParam = filter.GetGroup()
+	}
+	if filter.GetFaculty() != objects.EmptyString {
+		facultyParam = filter.GetFaculty()
+	}
+	if filter.GetRoomID() != objects.None {
+		roomParam = filter.GetRoomID()
+	}
+	if filter.GetLiving() != nil {
+		livingParam = *filter.GetLiving()
+	}
+	if filter.GetHasThings() != nil {
+		hasThingsParam = *filter.GetHasThings()
+	}
+	if filter.GetStatus() != objects.EmptyString {
+		statusParam = filter.GetStatus()
+	}
+	return []any{surnameParam, groupParam, facultyParam, roomParam, livingParam, hasThingsParam, statusParam}
+}
+
 func (pg *PgStudentRepo) GetStudentID(studentNumber string) (int, error) {
 	var result = objects.None
 	sqlString := pgsql.PostgreSQLGetStudentID{}.GetString()
@@ -61,19 +150,16 @@ func (pg *PgStudentRepo) GetStudentID(studentNumber string) (int, error) {
 
 func (pg *PgStudentRepo) GetStudent(id int) (objects.Student, error) {
 	var (
-		student                                                  = objects.NewEmptyStudent()
-		studentID, accID, roomID                                 int
-		studentName, studentSurname, studentGroup, studentNumber string
-		err                                                      error
+		student = objects.NewEmptyStudent()
+		err     error
 	)
 	sqlString := pgsql.PostgreSQLGetStudent{}.GetString()
 	rows, execError := pg.Conn.Query(sqlString, id)
 	if execError == nil {
 		for rows.Next() {
-			scanErr := rows.Scan(&studentID, &accID, &studentName, &studentSurname, &studentGroup, &studentNumber, &roomID)
+			tmpStudent, scanErr := scanStudent(rows)
 			if scanErr == nil {
-				student = objects.NewStudentWithParams(studentID, accID, studentName, studentSurname,
-					studentGroup, studentNumber, roomID)
+				student = tmpStudent
 			} else {
 				err = scanErr
 			}
@@ -84,36 +170,347 @@ func (pg *PgStudentRepo) GetStudent(id int) (objects.Student, error) {
 	return student, err
 }
 
-func (pg *PgStudentRepo) TransferStudent(studentID, roomID int, direct objects.TransferDirection) error {
+// GetRoomStudents returns students living in the room now.
+func (pg *PgStudentRepo) GetRoomStudents(roomID int) ([]objects.Student, error) {
+	var (
+		resultStudents = make([]objects.Student, objects.Empty)
+		err            error
+	)
+	sqlString := pgsql.PostgreSQLGetRoomStudents{}.GetString()
+	rows, execError := pg.Conn.Query(sqlString, roomID)
+	if execError == nil {
+		for rows.Next() {
+			tmpStudent, scanErr := scanStudent(rows)
+			if scanErr == nil {
+				resultStudents = append(resultStudents, tmpStudent)
+			} else {
+				err = scanErr
+				break
+			}
+		}
+	} else {
+		err = execError
+	}
+	return resultStudents, err
+}
+
+// scanStudent reads a student row of the student SELECTs together with the profile.
+func scanStudent(rows *sql.Rows) (objects.Student, error) {
+	var (
+		studentID, accID, roomID, course                         int
+		studentName, studentSurname, studentGroup, studentNumber string
+		patronymic, faculty, phone, email                        string
+		gender                                                   objects.Gender
+		dateOfBirth                                              sql.NullTime
+		status                                                   objects.StudentStatus
+	)
+	err := rows.Scan(&studentID, &accID, &studentName, &studentSurname, &studentGroup, &studentNumber, &roomID,
+		&gender, &patronymic, &faculty, &course, &phone, &email, &dateOfBirth, &status)
+	if err != nil {
+		return objects.NewEmptyStudent(), err
+	}
+	student := objects.NewStudentWithParams(studentID, accID, studentName, studentSurname, studentGroup,
+		studentNumber, roomID, gender)
+	student.SetProfile(objects.NewStudentProfile(patronymic, faculty, course, phone, email, dateOfBirth.Time))
+	student.SetStatus(status)
+	return student, nil
+}
+
+// dateParam stores unknown dates as NULL.
+func dateParam(date time.Time) any {
+	if date.IsZero() {
+		return nil
+	}
+	return date
+}
+
+// performedByParam stores acts which have no staff user behind them (performedBy is None) with NULL.
+func performedByParam(performedBy int) any {
+	if performedBy == objects.None {
+		return nil
+	}
+	return performedBy
+}
+
+func (pg *PgStudentRepo) TransferStudent(studentID, roomID int, direct objects.TransferDirection,
+	performedBy int) error {
+	sqlString := pgsql.PostgreSQLTransferStudent{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, studentID, roomID, int(direct), performedByParam(performedBy))
+	return err
+}
+
+// SettleStudent writes the settle act in one transaction with the check, see SettleStudentTx.
+func (pg *PgStudentRepo) SettleStudent(studentID, roomID, performedBy int, check RoomCheck) error {
+	tx, err := pg.Conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = SettleStudentTx(tx, studentID, roomID, performedBy, check)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// RelocateStudent writes the evict and settle acts in one transaction. The student and the new room
+// are locked before the check, as in SettleStudentTx.
+func (pg *PgStudentRepo) RelocateStudent(studentID, roomID, performedBy int, check RoomCheck) error {
+	tx, err := pg.Conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	student, room, err := lockStudentAndRoom(tx, studentID, roomID)
+	if err == nil {
+		err = check(student, room)
+	}
+	if err == nil {
+		err = TransferStudentTx(tx, studentID, student.GetRoomID(), objects.Ret, performedBy)
+	}
+	if err == nil {
+		err = TransferStudentTx(tx, studentID, roomID, objects.Get, performedBy)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// EvicStudent writes the evict act from the current room of the student. The student is locked before
+// the check, so no thing is given to the student between the check and the act.
+func (pg *PgStudentRepo) EvicStudent(studentID, performedBy int, check EvictCheck) error {
+	tx, err := pg.Conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	var things, fees int
+	student, err := LockStudent(tx, studentID)
+	if err == nil && student.GetID() != objects.None {
+		err = tx.QueryRow(pgsql.PostgreSQLCountStudentThings{}.GetString(), studentID).Scan(&things)
+		if err == nil {
+			err = tx.QueryRow(pgsql.PostgreSQLCountStudentFees{}.GetString(), studentID).Scan(&fees)
+		}
+	}
+	if err == nil {
+		err = check(student, things, fees)
+	}
+	if err == nil {
+		err = TransferStudentTx(tx, studentID, student.GetRoomID(), objects.Ret, performedBy)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// SettleStudentTx locks the student and the room, calls check with their state read after the locks
+// and writes the settle act in the transaction of the caller. Other settlements into the room wait
+// for the commit, so the room can't get more residents than beds.
+func SettleStudentTx(tx *sql.Tx, studentID, roomID, performedBy int, check RoomCheck) error {
+	student, room, err := lockStudentAndRoom(tx, studentID, roomID)
+	if err == nil {
+		err = check(student, room)
+	}
+	if err == nil {
+		err = TransferStudentTx(tx, studentID, roomID, objects.Get, performedBy)
+	}
+	return err
+}
+
+// TransferStudentTx writes a living act in the transaction of the caller, e.g. of a room swap.
+func TransferStudentTx(tx *sql.Tx, studentID, roomID int, direct objects.TransferDirection,
+	performedBy int) error {
 	sqlString := pgsql.PostgreSQLTransferStudent{}.GetString()
-	_, err := pg.Conn.Exec(sqlString, studentID, roomID, int(direct))
+	_, err := tx.Exec(sqlString, studentID, roomID, int(direct), performedByParam(performedBy))
 	return err
 }
 
+// LockStudent locks the student row till the end of the transaction and reads the student with
+// the current room. An unknown student is returned empty, like GetStudent does.
+func LockStudent(tx *sql.Tx, studentID int) (objects.Student, error) {
+	var id int
+	err := tx.QueryRow(pgsql.PostgreSQLLockStudent{}.GetString(), studentID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return objects.NewEmptyStudent(), nil
+	} else if err != nil {
+		return objects.NewEmptyStudent(), err
+	}
+
+	rows, err := tx.Query(pgsql.PostgreSQLGetStudent{}.GetString(), studentID)
+	if err != nil {
+		return objects.NewEmptyStudent(), err
+	}
+	defer rows.Close()
+	student := objects.NewEmptyStudent()
+	for rows.Next() {
+		if student, err = scanStudent(rows); err != nil {
+			break
+		}
+	}
+	return student, err
+}
+
+// LockRoom locks the room row and counts its residents. An unknown room is returned empty.
+func LockRoom(tx *sql.Tx, roomID int) (objects.Room, error) {
+	var (
+		id, roomNumber, capacity, occupied int
+		roomType                           string
+		gender                             objects.Gender
+	)
+	err := tx.QueryRow(pgsql.PostgreSQLLockRoom{}.GetString(), roomID).
+		Scan(&id, &roomType, &roomNumber, &capacity, &gender)
+	if err == nil {
+		err = tx.QueryRow(pgsql.PostgreSQLCountRoomStudents{}.GetString(), roomID).Scan(&occupied)
+	}
+	if err == sql.ErrNoRows {
+		return objects.NewEmptyRoom(), nil
+	} else if err != nil {
+		return objects.NewEmptyRoom(), err
+	}
+	room := objects.NewRoomWithParams(id, roomType, roomNumber, capacity, gender)
+	room.SetOccupied(occupied)
+	return room, nil
+}
+
+// lockStudentAndRoom takes the locks in the same order in every transaction: the student, then the room.
+func lockStudentAndRoom(tx *sql.Tx, studentID, roomID int) (objects.Student, objects.Room, error) {
+	room := objects.NewEmptyRoom()
+	student, err := LockStudent(tx, studentID)
+	if err == nil {
+		room, err = LockRoom(tx, roomID)
+	}
+	return student, room, err
+}
+
+// GetStudentLivingHistory returns settle and evict acts in date order. Zero from or to leaves the range open.
+func (pg *PgStudentRepo) GetStudentLivingHistory(studentID int, from, to time.Time,
+	page, size int) ([]objects.LivingRecord, error) {
+	var (
+		resultRecords             = make([]objects.LivingRecord, objects.Empty)
+		id, recordStudentID, room int
+		direction                 objects.TransferDirection
+		transferDate              time.Time
+		performedBy               string
+		fromParam, toParam        any
+		err                       error
+		sizeParam                 any
+	)
+	if !from.IsZero() {
+		fromParam = from
+	}
+	if !to.IsZero() {
+		toParam = to
+	}
+	if size != objects.Null {
+		sizeParam = size
+	}
+	sqlString := pgsql.PostgreSQLGetStudentLivingHistory{}.GetString()
+	rows, execError := pg.Conn.Query(sqlString, studentID, fromParam, toParam, sizeParam, page*size)
+	if execError == nil {
+		for rows.Next() {
+			scanErr := rows.Scan(&id, &recordStudentID, &room, &direction, &transferDate, &performedBy)
+			if scanErr == nil {
+				resultRecords = append(resultRecords, objects.NewLivingRecordWithParams(id, recordStudentID, room,
+					direction, transferDate, performedBy))
+			} else {
+				err = scanErr
+				break
+			}
+		}
+	} else {
+		err = execError
+	}
+	return resultRecords, err
+}
+
 func (pg *PgStudentRepo) ChangeStudent(studentID int, studentInfo objects.StudentDTO) error {
 	sqlString := pgsql.PostgreSQLChangeStudent{}.GetString()
+	profile := studentInfo.GetProfile()
 	_, err := pg.Conn.Exec(sqlString, studentInfo.GetName(), studentInfo.GetSurname(),
-		studentInfo.GetStudentGroup(), studentInfo.GetStudentNumber(), studentID)
+		studentInfo.GetStudentGroup(), studentInfo.GetStudentNumber(), studentID, studentInfo.GetGender(),
+		profile.GetPatronymic(), profile.GetFaculty(), profile.GetCourse(), profile.GetPhone(), profile.GetEmail(),
+		dateParam(profile.GetDateOfBirth()))
 	return err
 }
 
+// ChangeStudentStatus changes the status and disables the user account of a student who is not active,
+// or enables it again, in one transaction. The student is locked before the check, so nobody is settled
+// or given a thing between the check and the change.
+func (pg *PgStudentRepo) ChangeStudentStatus(studentID int, status objects.StudentStatus, check StatusCheck) error {
+	tx, err := pg.Conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	var things int
+	student, err := LockStudent(tx, studentID)
+	if err == nil && student.GetID() != objects.None {
+		sqlString := pgsql.PostgreSQLCountStudentThings{}.GetString()
+		err = tx.QueryRow(sqlString, studentID).Scan(&things)
+	}
+	if err == nil {
+		err = check(student, things)
+	}
+	if err == nil {
+		sqlString := pgsql.PostgreSQLChangeStudentStatus{}.GetString()
+		_, err = tx.Exec(sqlString, studentID, status)
+	}
+	if err == nil {
+		sqlString := pgsql.PostgreSQLSetUserDisabled{}.GetString()
+		_, err = tx.Exec(sqlString, status != objects.StudentActive, student.GetAccID())
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
 func (pg *PgStudentRepo) TransferThing(studentID, thingID int, direct objects.TransferDirection) error {
 	sqlString := pgsql.PostgreSQLTransferThing{}.GetString()
 	_, err := pg.Conn.Exec(sqlString, studentID, thingID, int(direct))
 	return err
 }
 
+// GiveThing writes the act of giving the thing with the student locked, as ChangeStudentStatus does,
+// so a student can't get a thing while leaving the dormitory.
+func (pg *PgStudentRepo) GiveThing(studentID, thingID int, check StudentCheck) error {
+	tx, err := pg.Conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	student, err := LockStudent(tx, studentID)
+	if err == nil {
+		err = check(student)
+	}
+	if err == nil {
+		sqlString := pgsql.PostgreSQLTransferThing{}.GetString()
+		_, err = tx.Exec(sqlString, studentID, thingID, int(objects.Get))
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
 func (pg *PgStudentRepo) GetStudentThings(id int, page, size int) ([]objects.Thing, error) {
 	var (
 		resultThings                         = make([]objects.Thing, objects.Empty)
 		thingID, markNumber, ownerID, roomID int
 		thingType                            string
 		err                                  error
-		sizeParam                            = "ALL"
+		sizeParam                            any
 	)
 	sqlString := pgsql.PostgreSQLGetStudentsThings{}.GetString()
 	if size != objects.Null {
-		sizeParam = strconv.Itoa(size)
+		sizeParam = size
 	}
 	rows, execError := pg.Conn.Query(sqlString, id, sizeParam, page*size)
 	if execError == nil {
@@ -132,3 +529,31 @@ func (pg *PgStudentRepo) GetStudentThings(id int, page, size int) ([]objects.Thi
 	}
 	return resultThings, err
 }
+
+// GetStudentFees returns unpaid fees of the student.
+func (pg *PgStudentRepo) GetStudentFees(studentID int) ([]objects.Fee, error) {
+	var (
+		resultFees               = make([]objects.Fee, objects.Empty)
+		id, feeStudentID, amount int
+		description              string
+		dueDate                  time.Time
+		err                      error
+	)
+	sqlString := pgsql.PostgreSQLGetStudentFees{}.GetString()
+	rows, execError := pg.Conn.Query(sqlString, studentID)
+	if execError == nil {
+		for rows.Next() {
+			scanErr := rows.Scan(&id, &feeStudentID, &amount, &description, &dueDate)
+			if scanErr == nil {
+				resultFees = append(resultFees, objects.NewFeeWithParams(id, feeStudentID, amount, description,
+					dueDate))
+			} else {
+				err = scanErr
+				break
+			}
+		}
+	} else {
+		err = execError
+	}
+	return resultFees, err
+}
@@ -30,7 +30,7 @@ func (*TestPgStudentRepo) TestPgStudentRepo_AddStudent(t *testgroup.T) {
 	db, mock := objectMother.CreateRepo()
 	studentDTO := objectMother.CreateStudentDTO()
 	mock.ExpectExec("INSERT INTO").WithArgs(studentDTO.GetName(), studentDTO.GetSurname(),
-		studentDTO.GetStudentGroup(), studentDTO.GetStudentNumber(), InsertID).
+		studentDTO.GetStudentGroup(), studentDTO.GetStudentNumber(), InsertID, studentDTO.GetGender()).
 		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
 
 	repo := PgStudentRepo{Conn: db}
@@ -58,7 +58,7 @@ func (*TestPgStudentRepo) TestPgStudentRepo_GetAllStudents(t *testgroup.T) {
 	repo := PgStudentRepo{Conn: db}
 
 	// Act
-	resultStudents, execErr := repo.GetAllStudents()
+	resultStudents, execErr := repo.GetAllStudents(objects.Null, objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
@@ -73,8 +73,11 @@ func (*TestPgStudentRepo) TestPgStudentRepo_ChangeStudent(t *testgroup.T) {
 	objectMother := mother.StudentRepoObjectMother{}
 	db, mock := objectMother.CreateRepo()
 	studentDTO := objectMother.CreateStudentDTO()
+	profile := studentDTO.GetProfile()
 	mock.ExpectExec("UPDATE").WithArgs(studentDTO.GetName(), studentDTO.GetSurname(),
-		studentDTO.GetStudentGroup(), studentDTO.GetStudentNumber(), InsertID).
+		studentDTO.GetStudentGroup(), studentDTO.GetStudentNumber(), InsertID, studentDTO.GetGender(),
+		profile.GetPatronymic(), profile.GetFaculty(), profile.GetCourse(), profile.GetPhone(), profile.GetEmail(),
+		nil).
 		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
 
 	repo := PgStudentRepo{Conn: db}
@@ -87,6 +90,64 @@ func (*TestPgStudentRepo) TestPgStudentRepo_ChangeStudent(t *testgroup.T) {
 	tests.AssertMocks(t, mock)
 }
 
+// TestPgStudentRepo_ChangeStudentStatus проверяет, что статус и отключение аккаунта пишутся в одной транзакции
+// после блокировки студента.
+func (*TestPgStudentRepo) TestPgStudentRepo_ChangeStudentStatus(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.StudentRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	students := objectMother.CreateDefaultStudents(1)
+	mock.ExpectBegin()
+	objectMother.ExpectLock(mock, int(InsertID), students)
+	mock.ExpectQuery("SELECT").WithArgs(InsertID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("UPDATE").WithArgs(InsertID, objects.StudentGraduated).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("UPDATE").WithArgs(true, students[0].GetAccID()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
+
+	repo := PgStudentRepo{Conn: db}
+
+	// Act
+	execErr := repo.ChangeStudentStatus(int(InsertID), objects.StudentGraduated,
+		func(objects.Student, int) error { return nil })
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+// TestPgStudentRepo_ChangeStudentStatusCheckFailed проверяет, что проверка получает число вещей студента
+// и при ошибке ничего не меняется.
+func (*TestPgStudentRepo) TestPgStudentRepo_ChangeStudentStatusCheckFailed(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var things int
+	objectMother := mother.StudentRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectBegin()
+	objectMother.ExpectLock(mock, int(InsertID), objectMother.CreateDefaultStudents(1))
+	mock.ExpectQuery("SELECT").WithArgs(InsertID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectRollback()
+
+	repo := PgStudentRepo{Conn: db}
+
+	// Act
+	execErr := repo.ChangeStudentStatus(int(InsertID), objects.StudentExpelled,
+		func(_ objects.Student, count int) error {
+			things = count
+			return sql.ErrTxDone
+		})
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrTxDone)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, things, 2)
+}
+
 // TestPgStudentRepo_GetStudentPositive проверяет, что если студент есть, он успешно вернётся.
 func (*TestPgStudentRepo) TestPgStudentRepo_GetStudentPositive(t *testgroup.T) {
 	defer tests.TimeTrack(time.Now())
@@ -111,6 +172,81 @@ func (*TestPgStudentRepo) TestPgStudentRepo_GetStudentPositive(t *testgroup.T) {
 	tests.AssertResult(t, student, realStudents[0])
 }
 
+func (*TestPgStudentRepo) TestPgStudentRepo_GetRoomStudents(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.StudentRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	N := 1
+	roomID := 1
+	realStudents := objectMother.CreateDefaultStudents(N)
+	rows := objectMother.CreateRows(realStudents)
+	mock.ExpectQuery("SELECT").WithArgs(roomID).
+		WillReturnError(nil).WillReturnRows(rows)
+	repo := PgStudentRepo{Conn: db}
+
+	// Act
+	students, execErr := repo.GetRoomStudents(roomID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, students, realStudents)
+}
+
+// TestPgStudentRepo_FindStudents проверяет, что неуказанные параметры фильтра передаются как NULL,
+// а префикс фамилии экранируется.
+func (*TestPgStudentRepo) TestPgStudentRepo_FindStudents(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.StudentRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	N := 2
+	page, size := 1, 10
+	living := true
+	realStudents := objectMother.CreateDefaultStudents(N)
+	filter := objects.NewStudentFilter("Iv_", mother.DefaultGroup, objects.EmptyString, objects.None, &living, nil,
+		objects.EmptyString)
+	filter.SetSort(objects.SortBySurname, true)
+	mock.ExpectQuery("ORDER BY S.studentsurname, S.studentname DESC").
+		WithArgs(`Iv\_%`, mother.DefaultGroup, nil, nil, living, nil, nil, size, page*size).
+		WillReturnError(nil).WillReturnRows(objectMother.CreateRows(realStudents))
+	repo := PgStudentRepo{Conn: db}
+
+	// Act
+	students, execErr := repo.FindStudents(filter, page, size)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, students, realStudents)
+}
+
+func (*TestPgStudentRepo) TestPgStudentRepo_CountStudents(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.StudentRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	total := 7
+	roomID := 3
+	filter := objects.NewStudentFilter(objects.EmptyString, objects.EmptyString, objects.EmptyString, roomID, nil,
+		nil, objects.StudentGraduated)
+	mock.ExpectQuery("SELECT count").WithArgs(nil, nil, nil, roomID, nil, nil, objects.StudentGraduated).
+		WillReturnError(nil).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(total))
+	repo := PgStudentRepo{Conn: db}
+
+	// Act
+	result, execErr := repo.CountStudents(filter)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result, total)
+}
+
 // TestPgStudentRepo_GetStudentNegative проверяет, что если студента нет, то вернётся ошибка.
 func (*TestPgStudentRepo) TestPgStudentRepo_GetStudentNegative(t *testgroup.T) {
 	defer tests.TimeTrack(time.Now())
@@ -140,13 +276,40 @@ func (*TestPgStudentRepo) TestPgStudentRepo_TransferStudent(t *testgroup.T) {
 	)
 	objectMother := mother.StudentRepoObjectMother{}
 	db, mock := objectMother.CreateRepo()
-	mock.ExpectExec("INSERT INTO").WithArgs(roomID, studentID, dir).
+	mock.ExpectExec("INSERT INTO").WithArgs(roomID, studentID, dir, mother.DefaultStaffID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	repo := PgStudentRepo{Conn: db}
+
+	// Act
+	execErr := repo.TransferStudent(studentID, roomID, dir, mother.DefaultStaffID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+// TestPgStudentRepo_GiveThing проверяет, что вещь выдаётся только после блокировки студента.
+func (*TestPgStudentRepo) TestPgStudentRepo_GiveThing(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		thingID   = int(InsertID)
+		studentID = int(InsertID)
+	)
+	objectMother := mother.StudentRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectBegin()
+	objectMother.ExpectLock(mock, studentID, objectMother.CreateDefaultStudents(1))
+	mock.ExpectExec("INSERT INTO").WithArgs(studentID, thingID, objects.Get).
 		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
 
 	repo := PgStudentRepo{Conn: db}
 
 	// Act
-	execErr := repo.TransferStudent(studentID, roomID, dir)
+	execErr := repo.GiveThing(studentID, thingID, func(objects.Student) error { return nil })
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
@@ -227,15 +390,290 @@ func (*TestPgStudentRepo) TestPgStudentRepo_GetStudentThings(t *testgroup.T) {
 	id := 1
 	realThings := thingObjectMother.CreateDefaultThings(N)
 	rows := thingObjectMother.CreateRows(realThings)
-	mock.ExpectQuery("SELECT").WithArgs(id).
+	mock.ExpectQuery("SELECT").WithArgs(id, nil, objects.Null).
 		WillReturnError(nil).WillReturnRows(rows)
 	repo := PgStudentRepo{Conn: db}
 
 	// Act
-	things, execErr := repo.GetStudentThings(id)
+	things, execErr := repo.GetStudentThings(id, objects.Null, objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
 	tests.AssertMocks(t, mock)
 	tests.AssertResult(t, things, realThings)
 }
+
+func (*TestPgStudentRepo) TestPgStudentRepo_GetStudentFees(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.StudentRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	N := 2
+	id := 1
+	realFees := objectMother.CreateDefaultFees(id, N)
+	mock.ExpectQuery("paiddate IS NULL").WithArgs(id).
+		WillReturnError(nil).WillReturnRows(objectMother.CreateFeeRows(realFees))
+	repo := PgStudentRepo{Conn: db}
+
+	// Act
+	fees, execErr := repo.GetStudentFees(id)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, fees, realFees)
+}
+
+// TestPgStudentRepo_AddStudentAccountsNegative проверяет, что при ошибке на любой строке
+// транзакция откатывается.
+func (*TestPgStudentRepo) TestPgStudentRepo_AddStudentAccountsNegative(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		accID        = 7
+		passwordHash = "hash"
+	)
+	objectMother := mother.StudentRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	studentDTO := objectMother.CreateStudentDTO()
+	secondDTO := objects.NewStudentDTO(mother.DefaultStudentName, mother.DefaultStudentSurname, mother.DefaultGroup,
+		mother.DefaultStudentNumber, mother.DefaultGender)
+	accounts := []objects.StudentAccount{
+		objects.NewStudentAccount(studentDTO, studentDTO.GetStudentNumber(), passwordHash),
+		objects.NewStudentAccount(secondDTO, secondDTO.GetStudentNumber(), passwordHash),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO  Users").WithArgs(studentDTO.GetStudentNumber(), passwordHash, objects.StudentRole).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(accID))
+	mock.ExpectExec("INSERT INTO  Student").WithArgs(studentDTO.GetName(), studentDTO.GetSurname(),
+		studentDTO.GetStudentGroup(), studentDTO.GetStudentNumber(), accID, studentDTO.GetGender()).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectQuery("INSERT INTO  Users").WithArgs(secondDTO.GetStudentNumber(), passwordHash, objects.StudentRole).
+		WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+
+	repo := PgStudentRepo{Conn: db}
+
+	// Act
+	execErr := repo.AddStudentAccounts(accounts)
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrConnDone)
+	tests.AssertMocks(t, mock)
+}
+
+// TestPgStudentRepo_EvicStudent проверяет, что проверка получает долги студента, прочитанные после блокировки,
+// а акт выселения пишется из текущей комнаты.
+func (*TestPgStudentRepo) TestPgStudentRepo_EvicStudent(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		studentID    = int(InsertID)
+		things, fees int
+	)
+	objectMother := mother.StudentRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	students := objectMother.CreateDefaultStudents(1)
+	mock.ExpectBegin()
+	objectMother.ExpectLock(mock, studentID, students)
+	objectMother.ExpectDebts(mock, studentID, 1, 2)
+	mock.ExpectExec("INSERT INTO").WithArgs(studentID, students[0].GetRoomID(), objects.Ret, mother.DefaultStaffID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
+
+	repo := PgStudentRepo{Conn: db}
+
+	// Act
+	execErr := repo.EvicStudent(studentID, mother.DefaultStaffID,
+		func(_ objects.Student, thingsCount, feesCount int) error {
+			things, fees = thingsCount, feesCount
+			return nil
+		})
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, []int{things, fees}, []int{1, 2})
+}
+
+func (*TestPgStudentRepo) TestPgStudentRepo_SettleStudent(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		studentID = int(InsertID)
+		roomID    = 3
+		checked   objects.Room
+	)
+	objectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	students := objectMother.CreateDefaultStudents(1)
+	room := roomObjectMother.CreateRoom(roomID, 2, objects.GenderAny)
+	mock.ExpectBegin()
+	roomObjectMother.ExpectLocks(mock, studentID, students, room)
+	mock.ExpectExec("INSERT INTO").WithArgs(studentID, roomID, objects.Get, mother.DefaultStaffID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
+
+	repo := PgStudentRepo{Conn: db}
+
+	// Act
+	execErr := repo.SettleStudent(studentID, roomID, mother.DefaultStaffID,
+		func(student objects.Student, room objects.Room) error {
+			checked = room
+			return nil
+		})
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, checked.GetOccupied(), 2)
+}
+
+func (*TestPgStudentRepo) TestPgStudentRepo_SettleStudentCheckFailed(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		studentID = int(InsertID)
+		roomID    = 3
+	)
+	objectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	students := objectMother.CreateDefaultStudents(1)
+	room := roomObjectMother.CreateRoom(roomID, mother.DefaultCapacity, objects.GenderAny)
+	mock.ExpectBegin()
+	roomObjectMother.ExpectLocks(mock, studentID, students, room)
+	mock.ExpectRollback()
+
+	repo := PgStudentRepo{Conn: db}
+
+	// Act
+	execErr := repo.SettleStudent(studentID, roomID, mother.DefaultStaffID,
+		func(student objects.Student, room objects.Room) error {
+			return sql.ErrTxDone
+		})
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrTxDone)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestPgStudentRepo) TestPgStudentRepo_RelocateStudent(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		studentID = int(InsertID)
+		dstRoomID = 3
+	)
+	objectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	students := objectMother.CreateDefaultStudents(1)
+	room := roomObjectMother.CreateRoom(dstRoomID, objects.Null, objects.GenderAny)
+	mock.ExpectBegin()
+	roomObjectMother.ExpectLocks(mock, studentID, students, room)
+	mock.ExpectExec("INSERT INTO").WithArgs(studentID, students[0].GetRoomID(), objects.Ret, mother.DefaultStaffID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").WithArgs(studentID, dstRoomID, objects.Get, mother.DefaultStaffID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
+
+	repo := PgStudentRepo{Conn: db}
+
+	// Act
+	execErr := repo.RelocateStudent(studentID, dstRoomID, mother.DefaultStaffID,
+		func(student objects.Student, room objects.Room) error { return nil })
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestPgStudentRepo) TestPgStudentRepo_RelocateStudentRollback(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		studentID = int(InsertID)
+		dstRoomID = 3
+	)
+	objectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	students := objectMother.CreateDefaultStudents(1)
+	room := roomObjectMother.CreateRoom(dstRoomID, objects.Null, objects.GenderAny)
+	mock.ExpectBegin()
+	roomObjectMother.ExpectLocks(mock, studentID, students, room)
+	mock.ExpectExec("INSERT INTO").WithArgs(studentID, students[0].GetRoomID(), objects.Ret, mother.DefaultStaffID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").WithArgs(studentID, dstRoomID, objects.Get, mother.DefaultStaffID).
+		WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+
+	repo := PgStudentRepo{Conn: db}
+
+	// Act
+	execErr := repo.RelocateStudent(studentID, dstRoomID, mother.DefaultStaffID,
+		func(student objects.Student, room objects.Room) error { return nil })
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrConnDone)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestPgStudentRepo) TestPgStudentRepo_GetStudentLivingHistory(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	db, mock := studentObjectMother.CreateRepo()
+	N := 3
+	id := 1
+	realRecords := studentObjectMother.CreateLivingRecords(id, N)
+	rows := studentObjectMother.CreateLivingRecordRows(realRecords)
+	mock.ExpectQuery("SELECT").WithArgs(id, nil, nil, nil, objects.Null).
+		WillReturnError(nil).WillReturnRows(rows)
+	repo := PgStudentRepo{Conn: db}
+
+	// Act
+	records, execErr := repo.GetStudentLivingHistory(id, time.Time{}, time.Time{}, objects.Null, objects.Null)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, records, realRecords)
+}
+
+func (*TestPgStudentRepo) TestPgStudentRepo_GetStudentLivingHistoryWithRange(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	db, mock := studentObjectMother.CreateRepo()
+	id := 1
+	page := 1
+	size := 2
+	realRecords := studentObjectMother.CreateLivingRecords(id, size)
+	from := realRecords[0].GetTransferDate()
+	to := realRecords[1].GetTransferDate()
+	rows := studentObjectMother.CreateLivingRecordRows(realRecords)
+	mock.ExpectQuery("SELECT").WithArgs(id, from, to, size, page*size).
+		WillReturnError(nil).WillReturnRows(rows)
+	repo := PgStudentRepo{Conn: db}
+
+	// Act
+	records, execErr := repo.GetStudentLivingHistory(id, from, to, page, size)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, records, realRecords)
+}
@@ -1,14 +1,42 @@
 package studentRepo
 
-import "src/objects"
+import (
+	"src/objects"
+	"time"
+)
+
+// RoomCheck decides whether the student may move into the room, both are read under row locks.
+type RoomCheck func(student objects.Student, room objects.Room) error
+
+// StudentCheck decides whether the act is allowed for the student read under the row lock.
+type StudentCheck func(student objects.Student) error
+
+// StatusCheck decides whether the student may get the status, things is the number of things
+// the student holds. Both are read under the student row lock.
+type StatusCheck func(student objects.Student, things int) error
+
+// EvictCheck decides whether the student may be evicted, things and fees are the numbers of unreturned
+// things and unpaid fees. All are read under the student row lock.
+type EvictCheck func(student objects.Student, things, fees int) error
 
 type StudentRepo interface {
 	AddStudent(newStudent objects.StudentDTO, accID int) error
+	AddStudentAccounts(accounts []objects.StudentAccount) error
 	GetAllStudents(page, size int) ([]objects.Student, error)
+	FindStudents(filter objects.StudentFilter, page, size int) ([]objects.Student, error)
+	CountStudents(filter objects.StudentFilter) (int, error)
 	GetStudentID(studentNumber string) (int, error)
 	GetStudent(id int) (objects.Student, error)
-	TransferStudent(studentID, roomID int, direct objects.TransferDirection) error
+	GetRoomStudents(roomID int) ([]objects.Student, error)
+	TransferStudent(studentID, roomID int, direct objects.TransferDirection, performedBy int) error
+	SettleStudent(studentID, roomID, performedBy int, check RoomCheck) error
+	RelocateStudent(studentID, roomID, performedBy int, check RoomCheck) error
+	EvicStudent(studentID, performedBy int, check EvictCheck) error
+	GetStudentLivingHistory(studentID int, from, to time.Time, page, size int) ([]objects.LivingRecord, error)
 	ChangeStudent(studentID int, studentInfo objects.StudentDTO) error
+	ChangeStudentStatus(studentID int, status objects.StudentStatus, check StatusCheck) error
 	TransferThing(studentID, thingID int, direct objects.TransferDirection) error
+	GiveThing(studentID, thingID int, check StudentCheck) error
 	GetStudentThings(id int, page, size int) ([]objects.Thing, error)
+	GetStudentFees(studentID int) ([]objects.Fee, error)
 }
@@ -0,0 +1,170 @@
+package swapRepo
+
+import (
+	"database/sql"
+	"src/db/sql"
+	"src/db/studentRepo"
+	"src/objects"
+	"time"
+)
+
+type PgSwapRepo struct {
+	Conn *sql.DB
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func (pg *PgSwapRepo) AddSwap(initiator, partner objects.SwapParty) (int, error) {
+	var id = objects.None
+	sqlString := pgsql.PostgreSQLAddRoomSwap{}.GetString()
+	err := pg.Conn.QueryRow(sqlString, initiator.GetStudentID(), initiator.GetRoomID(), partner.GetStudentID(),
+		partner.GetRoomID()).Scan(&id)
+	return id, err
+}
+
+func (pg *PgSwapRepo) GetSwap(id int) (objects.RoomSwap, error) {
+	sqlString := pgsql.PostgreSQLGetRoomSwap{}.GetString()
+	return scanSwap(pg.Conn.QueryRow(sqlString, id))
+}
+
+func (pg *PgSwapRepo) GetSwaps(status objects.SwapStatus, page, size int) ([]objects.RoomSwap, error) {
+	var sizeParam any
+	if size != objects.Null {
+		sizeParam = size
+	}
+	sqlString := pgsql.PostgreSQLGetRoomSwaps{}.GetString()
+	rows, err := pg.Conn.Query(sqlString, sizeParam, page*size, status)
+	if err != nil {
+		return make([]objects.RoomSwap, objects.Empty), err
+	}
+	return scanSwaps(rows)
+}
+
+// GetStudentSwaps returns swaps where the student is the initiator or the partner.
+func (pg *PgSwapRepo) GetStudentSwaps(studentID int) ([]objects.RoomSwap, error) {
+	sqlString := pgsql.PostgreSQLGetStudentRoomSwaps{}.GetString()
+	rows, err := pg.Conn.Query(sqlString, studentID)
+	if err != nil {
+		return make([]objects.RoomSwap, objects.Empty), err
+	}
+	return scanSwaps(rows)
+}
+
+// ChangeSwapStatus moves the swap from one status to another.
+// sql.ErrNoRows means the swap is not in the from status anymore.
+func (pg *PgSwapRepo) ChangeSwapStatus(id int, from, to objects.SwapStatus) error {
+	return changeSwapStatus(pg.Conn, id, from, to)
+}
+
+// ConfirmSwap closes the accepted swap and writes evict and settle acts of both students in one transaction,
+// so nobody is left without a room and the swap can't be carried out twice. Both students and then both rooms
+// are locked before the check, so no settle, relocate or evict act of them is written in between.
+func (pg *PgSwapRepo) ConfirmSwap(swap objects.RoomSwap, performedBy int, check SwapCheck) error {
+	tx, err := pg.Conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	var (
+		initiatorStudent, partnerStudent = objects.NewEmptyStudent(), objects.NewEmptyStudent()
+		initiatorRoom, partnerRoom       = objects.NewEmptyRoom(), objects.NewEmptyRoom()
+	)
+	initiator, partner := swap.GetInitiator(), swap.GetPartner()
+	err = changeSwapStatus(tx, swap.GetID(), objects.SwapAccepted, objects.SwapConfirmed)
+	if err == nil {
+		initiatorStudent, partnerStudent, err = lockInOrder(tx, initiator.GetStudentID(), partner.GetStudentID(),
+			studentRepo.LockStudent)
+	}
+	if err == nil {
+		initiatorRoom, partnerRoom, err = lockInOrder(tx, initiator.GetRoomID(), partner.GetRoomID(),
+			studentRepo.LockRoom)
+	}
+	if err == nil {
+		err = check(initiatorStudent, partnerStudent, initiatorRoom, partnerRoom)
+	}
+	acts := []struct {
+		studentID, roomID int
+		direct            objects.TransferDirection
+	}{
+		{initiator.GetStudentID(), initiator.GetRoomID(), objects.Ret},
+		{partner.GetStudentID(), partner.GetRoomID(), objects.Ret},
+		{initiator.GetStudentID(), partner.GetRoomID(), objects.Get},
+		{partner.GetStudentID(), initiator.GetRoomID(), objects.Get},
+	}
+	for _, act := range acts {
+		if err != nil {
+			break
+		}
+		err = studentRepo.TransferStudentTx(tx, act.studentID, act.roomID, act.direct, performedBy)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// lockInOrder locks the rows with the smaller id first, so transactions locking the same two rows
+// wait for each other instead of deadlocking.
+func lockInOrder[T any](tx *sql.Tx, first, second int, lock func(*sql.Tx, int) (T, error)) (T, T, error) {
+	if second < first {
+		secondResult, firstResult, err := lockInOrder(tx, second, first, lock)
+		return firstResult, secondResult, err
+	}
+	var secondResult T
+	firstResult, err := lock(tx, first)
+	if err == nil {
+		secondResult, err = lock(tx, second)
+	}
+	return firstResult, secondResult, err
+}
+
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+func changeSwapStatus(conn execer, id int, from, to objects.SwapStatus) error {
+	sqlString := pgsql.PostgreSQLChangeRoomSwapStatus{}.GetString()
+	result, err := conn.Exec(sqlString, id, from, to)
+	if err == nil {
+		var affected int64
+		if affected, err = result.RowsAffected(); err == nil && affected == objects.Null {
+			err = sql.ErrNoRows
+		}
+	}
+	return err
+}
+
+func scanSwaps(rows *sql.Rows) ([]objects.RoomSwap, error) {
+	var (
+		resultSwaps = make([]objects.RoomSwap, objects.Empty)
+		err         error
+	)
+	for rows.Next() {
+		swap, scanErr := scanSwap(rows)
+		if scanErr != nil {
+			err = scanErr
+			break
+		}
+		resultSwaps = append(resultSwaps, swap)
+	}
+	return resultSwaps, err
+}
+
+func scanSwap(row scanner) (objects.RoomSwap, error) {
+	var (
+		id, initiatorID, initiatorRoomID, partnerID, partnerRoomID int
+		initiatorNumber, partnerNumber                             string
+		status                                                     objects.SwapStatus
+		createdAt, updatedAt                                       time.Time
+	)
+	err := row.Scan(&id, &initiatorID, &initiatorNumber, &initiatorRoomID, &partnerID, &partnerNumber,
+		&partnerRoomID, &status, &createdAt, &updatedAt)
+	if err != nil {
+		return objects.NewEmptyRoomSwap(), err
+	}
+	return objects.NewRoomSwapWithParams(id, objects.NewSwapParty(initiatorID, initiatorNumber, initiatorRoomID),
+		objects.NewSwapParty(partnerID, partnerNumber, partnerRoomID), status, createdAt, updatedAt), nil
+}
@@ -0,0 +1,200 @@
+package swapRepo
+
+import (
+	"database/sql"
+	"github.com/bloomberg/go-testgroup"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	"testing"
+	"time"
+)
+
+const (
+	InsertID     = 5
+	RowsAffected = 1
+)
+
+type TestPgSwapRepo struct{}
+
+func Test_PgSwapRepo(t *testing.T) {
+	testgroup.RunSerially(t, &TestPgSwapRepo{})
+}
+
+func (*TestPgSwapRepo) TestPgSwapRepo_AddSwap(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SwapRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	initiator, partner := objectMother.CreateParty(1), objectMother.CreateParty(2)
+	mock.ExpectQuery("INSERT INTO").
+		WithArgs(initiator.GetStudentID(), initiator.GetRoomID(), partner.GetStudentID(), partner.GetRoomID()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(InsertID))
+	repo := PgSwapRepo{Conn: db}
+
+	// Act
+	id, execErr := repo.AddSwap(initiator, partner)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, id, InsertID)
+}
+
+func (*TestPgSwapRepo) TestPgSwapRepo_GetSwap(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SwapRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	realSwap := objectMother.CreateSwap(1, 1, 2, objects.SwapAccepted)
+	mock.ExpectQuery("SELECT").WithArgs(realSwap.GetID()).
+		WillReturnRows(objectMother.CreateRows([]objects.RoomSwap{realSwap}))
+	repo := PgSwapRepo{Conn: db}
+
+	// Act
+	swap, execErr := repo.GetSwap(realSwap.GetID())
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, swap, realSwap)
+}
+
+func (*TestPgSwapRepo) TestPgSwapRepo_GetStudentSwaps(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SwapRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	studentID := 2
+	realSwaps := []objects.RoomSwap{objectMother.CreateSwap(1, 1, studentID, objects.SwapRejected),
+		objectMother.CreateSwap(2, studentID, 3, objects.SwapPending)}
+	mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnRows(objectMother.CreateRows(realSwaps))
+	repo := PgSwapRepo{Conn: db}
+
+	// Act
+	swaps, execErr := repo.GetStudentSwaps(studentID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, swaps, realSwaps)
+}
+
+// TestPgSwapRepo_ChangeSwapStatusChanged проверяет, что обмен, который уже перевели в другой статус, не изменится.
+func (*TestPgSwapRepo) TestPgSwapRepo_ChangeSwapStatusChanged(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SwapRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	id := 1
+	mock.ExpectExec("UPDATE").WithArgs(id, objects.SwapPending, objects.SwapAccepted).
+		WillReturnResult(sqlmock.NewResult(InsertID, objects.Null))
+	repo := PgSwapRepo{Conn: db}
+
+	// Act
+	execErr := repo.ChangeSwapStatus(id, objects.SwapPending, objects.SwapAccepted)
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrNoRows)
+	tests.AssertMocks(t, mock)
+}
+
+// TestPgSwapRepo_ConfirmSwap проверяет, что оба студента переселяются вместе с закрытием обмена,
+// а студенты и комнаты блокируются по возрастанию id.
+func (*TestPgSwapRepo) TestPgSwapRepo_ConfirmSwap(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var checkedInitiator objects.Student
+	objectMother := mother.SwapRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	swap := objectMother.CreateSwap(1, 2, 1, objects.SwapAccepted)
+	students := mother.StudentRepoObjectMother{}.CreateDefaultStudents(2)
+	rooms := mother.RoomRepoObjectMother{}.CreateDefaultRooms(2)
+	initiator, partner := swap.GetInitiator(), swap.GetPartner()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE").WithArgs(swap.GetID(), objects.SwapAccepted, objects.SwapConfirmed).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	objectMother.ExpectLocks(mock, students, rooms)
+	mock.ExpectExec("INSERT INTO").
+		WithArgs(initiator.GetStudentID(), initiator.GetRoomID(), int(objects.Ret), mother.DefaultStaffID).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").
+		WithArgs(partner.GetStudentID(), partner.GetRoomID(), int(objects.Ret), mother.DefaultStaffID).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").
+		WithArgs(initiator.GetStudentID(), partner.GetRoomID(), int(objects.Get), mother.DefaultStaffID).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").
+		WithArgs(partner.GetStudentID(), initiator.GetRoomID(), int(objects.Get), mother.DefaultStaffID).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
+	repo := PgSwapRepo{Conn: db}
+
+	// Act
+	execErr := repo.ConfirmSwap(swap, mother.DefaultStaffID,
+		func(initiator, _ objects.Student, _, _ objects.Room) error {
+			checkedInitiator = initiator
+			return nil
+		})
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, checkedInitiator, students[1])
+}
+
+// TestPgSwapRepo_ConfirmSwapCheckFailed проверяет, что при ошибке проверки никто не переселяется.
+func (*TestPgSwapRepo) TestPgSwapRepo_ConfirmSwapCheckFailed(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SwapRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	swap := objectMother.CreateSwap(1, 1, 2, objects.SwapAccepted)
+	students := mother.StudentRepoObjectMother{}.CreateDefaultStudents(2)
+	rooms := mother.RoomRepoObjectMother{}.CreateDefaultRooms(2)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE").WithArgs(swap.GetID(), objects.SwapAccepted, objects.SwapConfirmed).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	objectMother.ExpectLocks(mock, students, rooms)
+	mock.ExpectRollback()
+	repo := PgSwapRepo{Conn: db}
+
+	// Act
+	execErr := repo.ConfirmSwap(swap, mother.DefaultStaffID, func(_, _ objects.Student, _, _ objects.Room) error {
+		return sql.ErrTxDone
+	})
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrTxDone)
+	tests.AssertMocks(t, mock)
+}
+
+// TestPgSwapRepo_ConfirmSwapNotAccepted проверяет, что без принятого обмена никто не переселяется.
+func (*TestPgSwapRepo) TestPgSwapRepo_ConfirmSwapNotAccepted(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SwapRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	swap := objectMother.CreateSwap(1, 1, 2, objects.SwapAccepted)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(InsertID, objects.Null))
+	mock.ExpectRollback()
+	repo := PgSwapRepo{Conn: db}
+
+	// Act
+	execErr := repo.ConfirmSwap(swap, mother.DefaultStaffID, func(_, _ objects.Student, _, _ objects.Room) error {
+		return nil
+	})
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrNoRows)
+	tests.AssertMocks(t, mock)
+}
@@ -0,0 +1,15 @@
+package swapRepo
+
+import "src/objects"
+
+// SwapCheck decides whether the swap may be carried out, both students and both rooms are read under row locks.
+type SwapCheck func(initiator, partner objects.Student, initiatorRoom, partnerRoom objects.Room) error
+
+type SwapRepo interface {
+	AddSwap(initiator, partner objects.SwapParty) (int, error)
+	GetSwap(id int) (objects.RoomSwap, error)
+	GetSwaps(status objects.SwapStatus, page, size int) ([]objects.RoomSwap, error)
+	GetStudentSwaps(studentID int) ([]objects.RoomSwap, error)
+	ChangeSwapStatus(id int, from, to objects.SwapStatus) error
+	ConfirmSwap(swap objects.RoomSwap, performedBy int, check SwapCheck) error
+}
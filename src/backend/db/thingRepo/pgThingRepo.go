@@ -5,6 +5,7 @@ import (
 	"log"
 	"src/db/sql"
 	"src/objects"
+	"time"
 )
 
 type PgThingRepo struct {
@@ -95,3 +96,54 @@ func (pg *PgThingRepo) GetThingIDByMarkNumber(markNumber int) (int, error) {
 	err := row.Scan(&result)
 	return result, err
 }
+
+func (pg *PgThingRepo) GetThingOwnerHistory(id int) ([]objects.ThingEvent, error) {
+	var (
+		resultEvents  = make([]objects.ThingEvent, objects.Empty)
+		direction     objects.TransferDirection
+		transferDate  time.Time
+		studentNumber string
+		err           error
+	)
+	sqlString := pgsql.PostgreSQLGetThingOwnerHistory{}.GetString()
+	rows, execError := pg.Conn.Query(sqlString, id)
+	if execError == nil {
+		for rows.Next() {
+			scanErr := rows.Scan(&direction, &transferDate, &studentNumber)
+			if scanErr == nil {
+				resultEvents = append(resultEvents, objects.NewOwnerEvent(direction, transferDate, studentNumber))
+			} else {
+				err = scanErr
+				break
+			}
+		}
+	} else {
+		err = execError
+	}
+	return resultEvents, err
+}
+
+func (pg *PgThingRepo) GetThingRoomHistory(id int) ([]objects.ThingEvent, error) {
+	var (
+		resultEvents         = make([]objects.ThingEvent, objects.Empty)
+		srcRoomID, dstRoomID int
+		transferDate         time.Time
+		err                  error
+	)
+	sqlString := pgsql.PostgreSQLGetThingRoomHistory{}.GetString()
+	rows, execError := pg.Conn.Query(sqlString, id)
+	if execError == nil {
+		for rows.Next() {
+			scanErr := rows.Scan(&srcRoomID, &dstRoomID, &transferDate)
+			if scanErr == nil {
+				resultEvents = append(resultEvents, objects.NewMoveEvent(transferDate, srcRoomID, dstRoomID))
+			} else {
+				err = scanErr
+				break
+			}
+		}
+	} else {
+		err = execError
+	}
+	return resultEvents, err
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"github.com/bloomberg/go-testgroup"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/objects"
 	"src/tests"
 	mot "src/tests/mother"
 	"testing"
@@ -72,7 +73,7 @@ func (*TestPgThingRepo) TestPgThingRepo_GetThings(t *testgroup.T) {
 	repo := PgThingRepo{Conn: db}
 
 	// Act
-	resultThings, execErr := repo.GetThings()
+	resultThings, execErr := repo.GetThings(objects.Null, objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
@@ -182,3 +183,48 @@ func (*TestPgThingRepo) TestPgThingRepo_GetThingIDByMarkNumberNegative(t *testgr
 	tests.AssertErrors(t, execErr, sql.ErrNoRows)
 	tests.AssertMocks(t, mock)
 }
+
+func (*TestPgThingRepo) TestPgThingRepo_GetThingOwnerHistory(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mot.ThingRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	id := 1
+	realEvents := objectMother.CreateOwnerEvents(2)
+	rows := objectMother.CreateOwnerEventRows(realEvents)
+	mock.ExpectQuery("SELECT").WithArgs(id).WillReturnError(nil).WillReturnRows(rows)
+	repo := PgThingRepo{Conn: db}
+
+	// Act
+	events, execErr := repo.GetThingOwnerHistory(id)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, events, realEvents)
+}
+
+func (*TestPgThingRepo) TestPgThingRepo_GetThingRoomHistory(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mot.ThingRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	id := 1
+	realEvents := []objects.ThingEvent{
+		objects.NewMoveEvent(time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC), 1, 2),
+		objects.NewMoveEvent(time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC), 2, 3),
+	}
+	rows := objectMother.CreateMoveEventRows(realEvents)
+	mock.ExpectQuery("SELECT").WithArgs(id).WillReturnError(nil).WillReturnRows(rows)
+	repo := PgThingRepo{Conn: db}
+
+	// Act
+	events, execErr := repo.GetThingRoomHistory(id)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, events, realEvents)
+}
@@ -9,4 +9,6 @@ type ThingRepo interface {
 	GetThing(id int) (objects.Thing, error)
 	TransferThingRoom(id, srcRoomID int, dstRoomID int) error
 	GetThingIDByMarkNumber(markNumber int) (int, error)
+	GetThingOwnerHistory(id int) ([]objects.ThingEvent, error)
+	GetThingRoomHistory(id int) ([]objects.ThingEvent, error)
 }
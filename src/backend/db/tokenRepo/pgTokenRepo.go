@@ -0,0 +1,118 @@
+package tokenRepo
+
+import (
+	"database/sql"
+	"src/db/sql"
+	"src/objects"
+	"time"
+)
+
+type PgTokenRepo struct {
+	Conn *sql.DB
+}
+
+func (pg *PgTokenRepo) AddRefreshToken(userID int, tokenHash string, expiresAt time.Time) error {
+	sqlString := pgsql.PostgreSQLAddRefreshToken{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, userID, tokenHash, expiresAt)
+	return err
+}
+
+func (pg *PgTokenRepo) GetRefreshToken(tokenHash string) (objects.RefreshToken, error) {
+	var (
+		result     = objects.NewEmptyRefreshToken()
+		id, userID int
+		hash       string
+		expiresAt  time.Time
+		revoked    bool
+	)
+	sqlString := pgsql.PostgreSQLGetRefreshToken{}.GetString()
+	row := pg.Conn.QueryRow(sqlString, tokenHash)
+	err := row.Scan(&id, &userID, &hash, &expiresAt, &revoked)
+	if err == nil {
+		result = objects.NewRefreshTokenWithParams(id, userID, hash, expiresAt, revoked)
+	}
+	return result, err
+}
+
+// RevokeRefreshToken reports false if the token had already been revoked,
+// so two concurrent refreshes can't both rotate the same token.
+func (pg *PgTokenRepo) RevokeRefreshToken(id int) (bool, error) {
+	var isRevoked bool
+	sqlString := pgsql.PostgreSQLRevokeRefreshToken{}.GetString()
+	result, err := pg.Conn.Exec(sqlString, id)
+	if err == nil {
+		affected, affectedErr := result.RowsAffected()
+		isRevoked = affected > objects.Null
+		err = affectedErr
+	}
+	return isRevoked, err
+}
+
+func (pg *PgTokenRepo) RevokeUserRefreshTokens(userID int) error {
+	sqlString := pgsql.PostgreSQLRevokeUserRefreshTokens{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, userID)
+	return err
+}
+
+func (pg *PgTokenRepo) RevokeAccessToken(tokenID string, expiresAt time.Time) error {
+	sqlString := pgsql.PostgreSQLRevokeAccessToken{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, tokenID, expiresAt)
+	return err
+}
+
+// IsAccessTokenRevoked checks both the token itself and the revocation of all tokens of its user.
+func (pg *PgTokenRepo) IsAccessTokenRevoked(tokenID string, userID int, issuedAt time.Time) (bool, error) {
+	var count int
+	sqlString := pgsql.PostgreSQLCheckAccessToken{}.GetString()
+	row := pg.Conn.QueryRow(sqlString, tokenID, userID, issuedAt)
+	err := row.Scan(&count)
+	return count > objects.Null, err
+}
+
+func (pg *PgTokenRepo) RevokeUserAccessTokens(userID int) error {
+	sqlString := pgsql.PostgreSQLRevokeUserAccessTokens{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, userID)
+	return err
+}
+
+func (pg *PgTokenRepo) AddPasswordResetToken(userID int, tokenHash string, expiresAt time.Time) error {
+	sqlString := pgsql.PostgreSQLAddPasswordReset{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, userID, tokenHash, expiresAt)
+	return err
+}
+
+func (pg *PgTokenRepo) GetPasswordResetToken(tokenHash string) (objects.PasswordResetToken, error) {
+	var (
+		result     = objects.NewEmptyPasswordResetToken()
+		id, userID int
+		hash       string
+		expiresAt  time.Time
+		used       bool
+	)
+	sqlString := pgsql.PostgreSQLGetPasswordReset{}.GetString()
+	row := pg.Conn.QueryRow(sqlString, tokenHash)
+	err := row.Scan(&id, &userID, &hash, &expiresAt, &used)
+	if err == nil {
+		result = objects.NewPasswordResetTokenWithParams(id, userID, hash, expiresAt, used)
+	}
+	return result, err
+}
+
+// UsePasswordResetToken reports false if the token had already been used.
+func (pg *PgTokenRepo) UsePasswordResetToken(id int) (bool, error) {
+	var isUsed bool
+	sqlString := pgsql.PostgreSQLUsePasswordReset{}.GetString()
+	result, err := pg.Conn.Exec(sqlString, id)
+	if err == nil {
+		affected, affectedErr := result.RowsAffected()
+		isUsed = affected > objects.Null
+		err = affectedErr
+	}
+	return isUsed, err
+}
+
+func (pg *PgTokenRepo) CancelUserPasswordResetTokens(userID int) error {
+	sqlString := pgsql.PostgreSQLCancelUserPasswordResets{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, userID)
+	return err
+}
@@ -0,0 +1,223 @@
+package tokenRepo
+
+import (
+	"database/sql"
+	"github.com/bloomberg/go-testgroup"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	"src/utils/hashUtils"
+	"testing"
+	"time"
+)
+
+var (
+	InsertID     int64 = 5
+	RowsAffected int64 = 1
+)
+
+type TestPgTokenRepo struct{}
+
+func Test_PgTokenRepo(t *testing.T) {
+	testgroup.RunSerially(t, &TestPgTokenRepo{})
+}
+
+func (*TestPgTokenRepo) TestPgTokenRepo_AddRefreshToken(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	userID := 1
+	expiresAt := time.Now()
+	tokenHash := hashUtils.HashToken(mother.DefaultRefreshToken)
+	mock.ExpectExec("INSERT INTO").WithArgs(userID, tokenHash, expiresAt).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	repo := PgTokenRepo{Conn: db}
+
+	// Act
+	execErr := repo.AddRefreshToken(userID, tokenHash, expiresAt)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestPgTokenRepo) TestPgTokenRepo_GetRefreshTokenPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	userID := 1
+	realToken := objectMother.CreateRefreshToken(userID, time.Now(), false)
+	rows := objectMother.CreateRows([]objects.RefreshToken{realToken})
+	mock.ExpectQuery("SELECT").WithArgs(realToken.GetTokenHash()).WillReturnError(nil).WillReturnRows(rows)
+	repo := PgTokenRepo{Conn: db}
+
+	// Act
+	token, execErr := repo.GetRefreshToken(realToken.GetTokenHash())
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, token, realToken)
+}
+
+func (*TestPgTokenRepo) TestPgTokenRepo_GetRefreshTokenNegative(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	tokenHash := hashUtils.HashToken(mother.DefaultRefreshToken)
+	mock.ExpectQuery("SELECT").WithArgs(tokenHash).WillReturnError(sql.ErrNoRows)
+	repo := PgTokenRepo{Conn: db}
+
+	// Act
+	token, execErr := repo.GetRefreshToken(tokenHash)
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrNoRows)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, token.GetID(), objects.None)
+}
+
+func (*TestPgTokenRepo) TestPgTokenRepo_RevokeRefreshTokenPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectExec("UPDATE").WithArgs(InsertID).WillReturnError(nil).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	repo := PgTokenRepo{Conn: db}
+
+	// Act
+	isRevoked, execErr := repo.RevokeRefreshToken(int(InsertID))
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, isRevoked, true)
+}
+
+func (*TestPgTokenRepo) TestPgTokenRepo_RevokeRefreshTokenAlreadyRevoked(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectExec("UPDATE").WithArgs(InsertID).WillReturnError(nil).
+		WillReturnResult(sqlmock.NewResult(InsertID, objects.Null))
+	repo := PgTokenRepo{Conn: db}
+
+	// Act
+	isRevoked, execErr := repo.RevokeRefreshToken(int(InsertID))
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, isRevoked, false)
+}
+
+func (*TestPgTokenRepo) TestPgTokenRepo_RevokeAccessToken(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	expiresAt := time.Now()
+	mock.ExpectExec("INSERT INTO").WithArgs(mother.DefaultTokenID, expiresAt).WillReturnError(nil).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	repo := PgTokenRepo{Conn: db}
+
+	// Act
+	execErr := repo.RevokeAccessToken(mother.DefaultTokenID, expiresAt)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestPgTokenRepo) TestPgTokenRepo_IsAccessTokenRevoked(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	userID := 1
+	issuedAt := time.Now()
+	rows := objectMother.CreateCountRows(1)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultTokenID, userID, issuedAt).
+		WillReturnError(nil).WillReturnRows(rows)
+	repo := PgTokenRepo{Conn: db}
+
+	// Act
+	isRevoked, execErr := repo.IsAccessTokenRevoked(mother.DefaultTokenID, userID, issuedAt)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, isRevoked, true)
+}
+
+func (*TestPgTokenRepo) TestPgTokenRepo_RevokeUserAccessTokens(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	userID := 1
+	mock.ExpectExec("INSERT INTO").WithArgs(userID).WillReturnError(nil).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	repo := PgTokenRepo{Conn: db}
+
+	// Act
+	execErr := repo.RevokeUserAccessTokens(userID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestPgTokenRepo) TestPgTokenRepo_GetPasswordResetToken(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	token := objectMother.CreatePasswordResetToken(1, time.Now().Add(time.Hour), false)
+	rows := objectMother.CreatePasswordResetRows([]objects.PasswordResetToken{token})
+	mock.ExpectQuery("SELECT").WithArgs(token.GetTokenHash()).WillReturnError(nil).WillReturnRows(rows)
+	repo := PgTokenRepo{Conn: db}
+
+	// Act
+	result, execErr := repo.GetPasswordResetToken(token.GetTokenHash())
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result, token)
+}
+
+func (*TestPgTokenRepo) TestPgTokenRepo_UsePasswordResetTokenAlreadyUsed(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	id := 1
+	mock.ExpectExec("UPDATE").WithArgs(id).WillReturnError(nil).
+		WillReturnResult(sqlmock.NewResult(InsertID, objects.Null))
+	repo := PgTokenRepo{Conn: db}
+
+	// Act
+	isUsed, execErr := repo.UsePasswordResetToken(id)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, isUsed, false)
+}
@@ -0,0 +1,20 @@
+package tokenRepo
+
+import (
+	"src/objects"
+	"time"
+)
+
+type TokenRepo interface {
+	AddRefreshToken(userID int, tokenHash string, expiresAt time.Time) error
+	GetRefreshToken(tokenHash string) (objects.RefreshToken, error)
+	RevokeRefreshToken(id int) (bool, error)
+	RevokeUserRefreshTokens(userID int) error
+	RevokeAccessToken(tokenID string, expiresAt time.Time) error
+	IsAccessTokenRevoked(tokenID string, userID int, issuedAt time.Time) (bool, error)
+	RevokeUserAccessTokens(userID int) error
+	AddPasswordResetToken(userID int, tokenHash string, expiresAt time.Time) error
+	GetPasswordResetToken(tokenHash string) (objects.PasswordResetToken, error)
+	UsePasswordResetToken(id int) (bool, error)
+	CancelUserPasswordResetTokens(userID int) error
+}
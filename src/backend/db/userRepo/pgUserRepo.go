@@ -26,18 +26,20 @@ func (pg *PgUserRepo) GetUser(id int) (objects.User, error) {
 		login    string
 		password string
 		level    objects.Levels
+		disabled bool
 		err      error
 	)
 	sqlString := pgsql.PostgreSQLGetUser{}.GetString()
 	rows, execError := pg.Conn.Query(sqlString, id)
 	if execError == nil {
 		for rows.Next() {
-			readRowError := rows.Scan(&userID, &login, &password, &level)
+			readRowError := rows.Scan(&userID, &login, &password, &level, &disabled)
 			if readRowError != nil {
 				err = readRowError
 				break
 			} else {
 				result = objects.NewUserWithParams(int(userID), login, password, level)
+				result.SetDisabled(disabled)
 			}
 		}
 	} else {
@@ -51,3 +53,66 @@ func (pg *PgUserRepo) AddUser(login, password string, privelegeLevel objects.Lev
 	_, err := pg.Conn.Exec(sqlString, login, password, int(privelegeLevel))
 	return err
 }
+
+func (pg *PgUserRepo) ChangePassword(id int, password string) error {
+	sqlString := pgsql.PostgreSQLChangeUserPassword{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, password, id)
+	return err
+}
+
+func (pg *PgUserRepo) GetUsers(page, size int) ([]objects.User, error) {
+	var (
+		resultUsers = make([]objects.User, objects.Empty)
+		id          int
+		login       string
+		password    string
+		level       objects.Levels
+		disabled    bool
+		err         error
+		sizeParam   any
+	)
+	sqlString := pgsql.PostgreSQLGetUsers{}.GetString()
+	if size != objects.Null {
+		sizeParam = size
+	}
+	rows, execError := pg.Conn.Query(sqlString, sizeParam, page*size)
+	if execError == nil {
+		for rows.Next() {
+			scanErr := rows.Scan(&id, &login, &password, &level, &disabled)
+			if scanErr == nil {
+				tmpUser := objects.NewUserWithParams(id, login, password, level)
+				tmpUser.SetDisabled(disabled)
+				resultUsers = append(resultUsers, tmpUser)
+			}
+		}
+	} else {
+		err = execError
+	}
+	return resultUsers, err
+}
+
+func (pg *PgUserRepo) SetUserDisabled(id int, disabled bool) error {
+	sqlString := pgsql.PostgreSQLSetUserDisabled{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, disabled, id)
+	return err
+}
+
+func (pg *PgUserRepo) ChangeUserRole(id int, privelegeLevel objects.Levels) error {
+	sqlString := pgsql.PostgreSQLChangeUserRole{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, int(privelegeLevel), id)
+	return err
+}
+
+func (pg *PgUserRepo) GetUserIDByExternalIdentity(provider, subject string) (int, error) {
+	var id = objects.None
+	sqlString := pgsql.PostgreSQLGetUserIDByExternalIdentity{}.GetString()
+	row := pg.Conn.QueryRow(sqlString, provider, subject)
+	err := row.Scan(&id)
+	return id, err
+}
+
+func (pg *PgUserRepo) AddExternalIdentity(provider, subject string, userID int) error {
+	sqlString := pgsql.PostgreSQLAddExternalIdentity{}.GetString()
+	_, err := pg.Conn.Exec(sqlString, provider, subject, userID)
+	return err
+}
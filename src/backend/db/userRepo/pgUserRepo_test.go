@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"github.com/bloomberg/go-testgroup"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/objects"
 	"src/tests"
 	"src/tests/mother"
 	"testing"
@@ -120,3 +121,124 @@ func (*TestPgUserRepo) TestPgUserRepo_GetUserIDNegative(t *testgroup.T) {
 	tests.AssertErrors(t, execErr, sql.ErrNoRows)
 	tests.AssertMocks(t, mock)
 }
+
+func (*TestPgUserRepo) TestPgUserRepo_ChangePassword(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	user := objectMother.CreateUser()
+	mock.ExpectExec("UPDATE").WithArgs(user.GetPassword(), user.GetID()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	repo := PgUserRepo{Conn: db}
+
+	// Act
+	execErr := repo.ChangePassword(user.GetID(), user.GetPassword())
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestPgUserRepo) TestPgUserRepo_GetUsers(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	N := 3
+	realUsers := objectMother.CreateDefaultUsers(N)
+	rows := objectMother.CreateRows(realUsers)
+	mock.ExpectQuery("SELECT").WithArgs(nil, objects.Null).WillReturnError(nil).WillReturnRows(rows)
+	repo := PgUserRepo{Conn: db}
+
+	// Act
+	users, execErr := repo.GetUsers(objects.Null, objects.Null)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, users, realUsers)
+}
+
+func (*TestPgUserRepo) TestPgUserRepo_SetUserDisabled(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	id := 1
+	mock.ExpectExec("UPDATE").WithArgs(true, id).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	repo := PgUserRepo{Conn: db}
+
+	// Act
+	execErr := repo.SetUserDisabled(id, true)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestPgUserRepo) TestPgUserRepo_ChangeUserRole(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	id := 1
+	mock.ExpectExec("UPDATE").WithArgs(objects.ComendRole, id).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	repo := PgUserRepo{Conn: db}
+
+	// Act
+	execErr := repo.ChangeUserRole(id, objects.ComendRole)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestPgUserRepo) TestPgUserRepo_GetUserIDByExternalIdentity(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	rows := objectMother.CreateRowForID(ID)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultProvider, mother.DefaultSubject).
+		WillReturnError(nil).WillReturnRows(rows)
+
+	repo := PgUserRepo{Conn: db}
+
+	// Act
+	result, execErr := repo.GetUserIDByExternalIdentity(mother.DefaultProvider, mother.DefaultSubject)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result, ID)
+}
+
+func (*TestPgUserRepo) TestPgUserRepo_AddExternalIdentity(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	mock.ExpectExec("INSERT INTO").WithArgs(mother.DefaultProvider, mother.DefaultSubject, ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	repo := PgUserRepo{Conn: db}
+
+	// Act
+	execErr := repo.AddExternalIdentity(mother.DefaultProvider, mother.DefaultSubject, ID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
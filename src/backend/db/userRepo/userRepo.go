@@ -6,4 +6,10 @@ type UserRepo interface {
 	GetUserID(login string) (int, error)
 	GetUser(id int) (objects.User, error)
 	AddUser(login, password string, privelegeLevel objects.Levels) error
+	ChangePassword(id int, password string) error
+	GetUsers(page, size int) ([]objects.User, error)
+	SetUserDisabled(id int, disabled bool) error
+	ChangeUserRole(id int, privelegeLevel objects.Levels) error
+	GetUserIDByExternalIdentity(provider, subject string) (int, error)
+	AddExternalIdentity(provider, subject string, userID int) error
 }
@@ -0,0 +1,179 @@
+package apiKeyHandler
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"src/delivery/http/models"
+	"src/logic/managers/apiKeyManager"
+	"src/objects"
+	"src/utils"
+	appErrors "src/utils/error"
+	"src/utils/logger"
+	"strconv"
+)
+
+type APIKeyHandler struct {
+	logger  *logrus.Entry
+	manager apiKeyManager.APIKeyManager
+}
+
+func CreateNewAPIKeyHandler(logger *logrus.Entry, manager apiKeyManager.APIKeyManager) *APIKeyHandler {
+	return &APIKeyHandler{
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+// GetAPIKeys
+// @Summary Get all API keys
+// @Description View names, roles and last use of API keys. The keys themselves are never returned.
+// @Tags api-keys
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param page query int false "Page param for pagination"
+// @Param size query int false "Size param for pagination"
+// @Success 200 {array} objects.APIKeyResponseDTO
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/api-keys [GET]
+func (kh *APIKeyHandler) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	page, size := utils.GetPageAndSizeFromQuery(r)
+	if checkErr := utils.CheckPageAndSize(page, size); checkErr != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(kh.logger, r, statusCode, handleMessage, checkErr)
+		return
+	}
+
+	keys, err := kh.manager.GetAPIKeys(page, size)
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.AddOK
+		result := objects.CreateAPIKeyResponse(keys)
+		bytes, _ := json.Marshal(&result)
+		_, _ = w.Write(bytes)
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+		utils.SendResponseWithInternalErr(w)
+	}
+	logger.WriteInfoInLog(kh.logger, r, statusCode, handleMessage, err)
+}
+
+// CreateAPIKey
+// @Summary Create API key
+// @Description Create a key for scripts with supply manager (role 2) or commandant (role 3) rights.
+// @Description The key is returned only in this response, send it in "Authorization: ApiKey <key>" header.
+// @Tags api-keys
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param  key-params body models.CreateAPIKeyRequestMessage true "Key params"
+// @Success 200 {object} models.CreateAPIKeyResponseMessage
+// @Failure 400 {object} models.ShortResponseMessage "Параметр не должен быть пустой" | "Указана неверная роль пользователя!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/api-keys [POST]
+func (kh *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.CreateAPIKeyRequestMessage
+
+	body, readBodyErr := io.ReadAll(r.Body)
+	if readBodyErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(kh.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString, readBodyErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(kh.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	identity := objects.IdentityFromContext(r.Context())
+	id, key, err := kh.manager.CreateAPIKey(identity, params.Name, params.Role, params.ReadOnly)
+	switch err {
+	case nil:
+		result := models.CreateAPIKeyResponseMessage{ID: id, Key: key}
+		bytes, _ := json.Marshal(&result)
+		_, _ = w.Write(bytes)
+		logger.WriteInfoInLog(kh.logger, r, http.StatusOK, objects.AddOK, nil)
+		return
+	case appErrors.BadUserParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.EmptyParamsErrorString
+	case appErrors.BadRoleErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.BadRoleErrorString
+	case appErrors.AccessDeniedErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.ForbiddenErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(kh.logger, r, statusCode, handleMessage, err)
+}
+
+// RevokeAPIKey
+// @Summary Revoke API key
+// @Description Revoked key is rejected at once.
+// @Tags api-keys
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param  key-id path int true "API key id"
+// @Success 200 {object} models.ShortResponseMessage "API-ключ отозван!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметр обязательно должен быть числом!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "API-ключ не найден!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/api-keys/{key-id} [DELETE]
+func (kh *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	keyIDString, _ := mux.Vars(r)["key-id"]
+	keyID, atoiErr := strconv.Atoi(keyIDString)
+	if atoiErr != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.MustBeIntErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(kh.logger, r, statusCode, handleMessage, atoiErr)
+		return
+	}
+
+	err := kh.manager.RevokeAPIKey(keyID)
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.APIKeyRevokeOKString
+	case appErrors.APIKeyNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.APIKeyNotFoundErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(kh.logger, r, statusCode, handleMessage, err)
+}
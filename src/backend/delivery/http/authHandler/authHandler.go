@@ -1,42 +1,56 @@
 package authHandler
 
 import (
+	"crypto/subtle"
 	"encoding/json"
+	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 	"io"
+	"math"
 	"net/http"
 	"src/delivery/http/models"
-	"src/logic/managers/appManager"
 	"src/logic/managers/authManager"
 	"src/objects"
 	"src/utils"
 	appErrors "src/utils/error"
-	"src/utils/jwtUtils"
+	"src/utils/hashUtils"
+	"src/utils/logger"
+	"strconv"
+)
+
+const (
+	authStateCookie = "auth-state"
+	authStateMaxAge = 600
 )
 
 type AuthHandler struct {
-	logger      *logrus.Entry
-	AuthManager authManager.AuthManager
-	AppManager  appManager.AppManager
+	logger         *logrus.Entry
+	AuthManager    authManager.AuthManager
+	trustedProxies utils.TrustedProxies
 }
 
-func CreateNewAuthHandler(logger *logrus.Entry, am authManager.AuthManager, ap appManager.AppManager) *AuthHandler {
+func CreateNewAuthHandler(logger *logrus.Entry, am authManager.AuthManager,
+	trustedProxies utils.TrustedProxies) *AuthHandler {
 	return &AuthHandler{
-		logger:      logger,
-		AuthManager: am,
-		AppManager:  ap,
+		logger:         logger,
+		AuthManager:    am,
+		trustedProxies: trustedProxies,
 	}
 }
 
 // Authorize
 // @Summary Try to authorize in system
-// @Description Try to authorize in system. JWT-Token send with success
+// @Description Try to authorize in system. Short-lived JWT-Token and refresh token send with success
+// @Description After several failed attempts for the login or the client address the next attempt is delayed,
+// @Description the delay in seconds is sent in Retry-After header.
 // @Produce json
 // @Tags auth
 // @Param  requestParams body models.AuthRequestMessage true "Request params"
 // @Success 200 {object} models.ResponseWithJWTMessage
-// @Failure 403 {object} models.ShortResponseMessage "Пароль введен неверно!"
-// @Failure 404 {object} models.ShortResponseMessage "Пользователь не найден"
+// @Failure 401 {object} models.ShortResponseMessage "Неверный логин или пароль!"
+// @Failure 403 {object} models.ShortResponseMessage "Учётная запись заблокирована!" | "Пользователь с таким логином уже есть, привяжите внешний вход в своей учётной записи!"
+// @Failure 400 {object} models.ShortResponseMessage "Способ входа не поддерживается!"
+// @Failure 429 {object} models.ShortResponseMessage "Слишком много неудачных попыток входа, попробуйте позже!"
 // @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
 // @Router /api/v1/login [POST]
 func (h *AuthHandler) Authorize(w http.ResponseWriter, r *http.Request) {
@@ -57,33 +71,512 @@ func (h *AuthHandler) Authorize(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	newRole, err := h.AuthManager.TryToAuth(authParams.Login, authParams.Password)
+	user, retryAfter, err := h.AuthManager.Login(authParams.Provider, authParams.Login, authParams.Password,
+		utils.GetClientIP(r, h.trustedProxies))
+	if err == nil {
+		session, sessionErr := h.AuthManager.CreateSession(user.GetLogin(), user.GetPrivelegeLevel())
+		if sessionErr == nil {
+			result := models.CreateResponseWithJWTMessage(session)
+			bytes, _ := json.Marshal(&result)
+			_, _ = w.Write(bytes)
+			logger.WriteInfoInLog(h.logger, r, http.StatusOK, objects.AuthOK, nil)
+			return
+		}
+		err = sessionErr
+	}
+
+	switch err {
+	case appErrors.UserNotFoundErr, appErrors.PasswordNotEqualErr:
+		statusCode = http.StatusUnauthorized
+		handleMessage = objects.InvalidCredentialsErrorString
+	case appErrors.UserDisabledErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.UserDisabledErrorString
+	case appErrors.ExternalNotLinkedErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.ExternalNotLinkedErrorString
+	case appErrors.TooManyAttemptsErr:
+		statusCode = http.StatusTooManyRequests
+		handleMessage = objects.TooManyAttemptsErrorString
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	case appErrors.UnknownProviderErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.UnknownProviderErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(h.logger, r, statusCode, handleMessage, err)
+}
+
+// Refresh
+// @Summary Exchange refresh token for a new pair of tokens
+// @Description Refresh token can be used only once: a new refresh token is returned with a new JWT-Token.
+// @Produce json
+// @Tags auth
+// @Param  requestParams body models.RefreshTokenRequestMessage true "Request params"
+// @Success 200 {object} models.ResponseWithJWTMessage
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!"
+// @Failure 401 {object} models.ShortResponseMessage "Токен недействителен, требуется повторная авторизация!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/refresh [POST]
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.RefreshTokenRequestMessage
+
+	body, readErr := io.ReadAll(r.Body)
+	if readErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(h.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString, readErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(h.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	session, err := h.AuthManager.RefreshSession(params.RefreshToken)
+	switch err {
+	case nil:
+		result := models.CreateResponseWithJWTMessage(session)
+		bytes, _ := json.Marshal(&result)
+		_, _ = w.Write(bytes)
+		logger.WriteInfoInLog(h.logger, r, http.StatusOK, objects.AuthOK, nil)
+		return
+	case appErrors.BadRefreshTokenErr, appErrors.RefreshTokenExpiredErr, appErrors.RefreshTokenReusedErr,
+		appErrors.UserNotFoundErr, appErrors.UserDisabledErr:
+		statusCode = http.StatusUnauthorized
+		handleMessage = objects.UnauthorizedErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(h.logger, r, statusCode, handleMessage, err)
+}
+
+// Logout
+// @Summary Log out from system
+// @Description Revoke refresh token and current JWT-Token, so they can't be used anymore.
+// @Produce json
+// @Tags auth
+// @param access-token header string false "JWT Token"
+// @Param  requestParams body models.RefreshTokenRequestMessage true "Request params"
+// @Success 200 {object} models.ShortResponseMessage "Выход из системы выполнен!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!"
+// @Failure 401 {object} models.ShortResponseMessage "Токен недействителен, требуется повторная авторизация!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/logout [POST]
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.RefreshTokenRequestMessage
+
+	body, readErr := io.ReadAll(r.Body)
+	if readErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(h.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString, readErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(h.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	err = h.AuthManager.Logout(r.Header.Get("access-token"), params.RefreshToken)
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.LogoutOK
+	case appErrors.BadRefreshTokenErr:
+		statusCode = http.StatusUnauthorized
+		handleMessage = objects.UnauthorizedErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(h.logger, r, statusCode, handleMessage, err)
+}
+
+// GetJWKS
+// @Summary Get public keys for JWT-Token verification
+// @Description Public keys in JWKS format. Other services choose the key by kid header of the token.
+// @Produce json
+// @Tags auth
+// @Success 200 {object} jwtUtils.JWKS
+// @Router /api/v1/.well-known/jwks.json [GET]
+func (h *AuthHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	result := h.AuthManager.GetJWKS()
+	bytes, _ := json.Marshal(&result)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	_, _ = w.Write(bytes)
+	logger.WriteInfoInLog(h.logger, r, http.StatusOK, objects.EmptyString, nil)
+}
+
+// ChangePassword
+// @Summary Change own password
+// @Description Change password of the current user. All sessions of the user are closed, log in again is required.
+// @Tags auth
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param  requestParams body models.ChangePasswordRequestMessage true "Old and new passwords"
+// @Success 200 {object} models.ShortResponseMessage "Пароль успешно изменён!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!" | "Параметр не должен быть пустой"
+// @Failure 403 {object} models.ShortResponseMessage "Пароль введен неверно!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/password [PUT]
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.ChangePasswordRequestMessage
+
+	body, readErr := io.ReadAll(r.Body)
+	if readErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(h.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString, readErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(h.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	identity := objects.IdentityFromContext(r.Context())
+	err = h.AuthManager.ChangePassword(identity.GetUserID(), params.OldPassword, params.NewPassword)
 	switch err {
 	case nil:
 		statusCode = http.StatusOK
-		handleMessage = objects.AddOK
-		jwtToken := jwtUtils.CreateJWTToken(authParams.Login, newRole)
-		bytes, _ := json.Marshal(&jwtToken)
+		handleMessage = objects.PasswordChangeOKString
+	case appErrors.BadUserParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.EmptyParamsErrorString
+	case appErrors.PasswordNotEqualErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.PasswordErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(h.logger, r, statusCode, handleMessage, err)
+}
+
+// CreatePasswordReset
+// @Summary Issue password reset token for user
+// @Description One-time token for setting a new password without the old one. Previous tokens of the user stop working.
+// @Tags users
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param  user-id path int true "User id"
+// @Success 200 {object} models.PasswordResetResponseMessage
+// @Failure 400 {object} models.ShortResponseMessage "Параметр обязательно должен быть числом!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Пользователь не найден!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/users/{user-id}/password-reset [POST]
+func (h *AuthHandler) CreatePasswordReset(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	userIDString, _ := mux.Vars(r)["user-id"]
+	userID, atoiErr := strconv.Atoi(userIDString)
+	if atoiErr != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.MustBeIntErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(h.logger, r, statusCode, handleMessage, atoiErr)
+		return
+	}
+
+	resetToken, expiresAt, err := h.AuthManager.CreatePasswordReset(userID)
+	switch err {
+	case nil:
+		result := models.CreatePasswordResetResponseMessage(resetToken, expiresAt)
+		bytes, _ := json.Marshal(&result)
 		_, _ = w.Write(bytes)
+		logger.WriteInfoInLog(h.logger, r, http.StatusOK, objects.AddOK, nil)
 		return
 	case appErrors.UserNotFoundErr:
 		statusCode = http.StatusNotFound
-		handleMessage = objects.LoginErrorString
-	case appErrors.PasswordNotEqualErr:
+		handleMessage = objects.UserNotFoundErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(h.logger, r, statusCode, handleMessage, err)
+}
+
+// ResetPassword
+// @Summary Set new password with reset token
+// @Description Reset token is issued by commandant and can be used only once. All sessions of the user are closed.
+// @Produce json
+// @Tags auth
+// @Param  requestParams body models.ResetPasswordRequestMessage true "Reset token and new password"
+// @Success 200 {object} models.ShortResponseMessage "Пароль успешно изменён!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!" | "Параметр не должен быть пустой"
+// @Failure 401 {object} models.ShortResponseMessage "Ссылка для сброса пароля недействительна или устарела!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/password-reset [POST]
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.ResetPasswordRequestMessage
+
+	body, readErr := io.ReadAll(r.Body)
+	if readErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(h.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString, readErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(h.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	err = h.AuthManager.ResetPassword(params.ResetToken, params.NewPassword)
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.PasswordChangeOKString
+	case appErrors.BadUserParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.EmptyParamsErrorString
+	case appErrors.BadResetTokenErr, appErrors.ResetTokenExpiredErr:
+		statusCode = http.StatusUnauthorized
+		handleMessage = objects.BadResetTokenErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(h.logger, r, statusCode, handleMessage, err)
+}
+
+// ExternalLogin
+// @Summary Log in with university SSO
+// @Description Redirect to the login page of the provider, it returns the user to the callback.
+// @Tags auth
+// @Param  provider path string true "Provider name"
+// @Success 302
+// @Failure 404 {object} models.ShortResponseMessage "Способ входа не поддерживается!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/login/{provider} [GET]
+func (h *AuthHandler) ExternalLogin(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	provider, _ := mux.Vars(r)["provider"]
+	state, err := hashUtils.GenerateToken(hashUtils.DefaultTokenBytes)
+	if err == nil {
+		var authURL string
+		authURL, err = h.AuthManager.GetAuthURL(provider, state)
+		if err == nil {
+			http.SetCookie(w, &http.Cookie{
+				Name:     authStateCookie,
+				Value:    state,
+				Path:     objects.AuthURI,
+				MaxAge:   authStateMaxAge,
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+			http.Redirect(w, r, authURL, http.StatusFound)
+			logger.WriteInfoInLog(h.logger, r, http.StatusFound, objects.EmptyString, nil)
+			return
+		}
+	}
+
+	switch err {
+	case appErrors.UnknownProviderErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.UnknownProviderErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(h.logger, r, statusCode, handleMessage, err)
+}
+
+// ExternalLoginCallback
+// @Summary Finish login with university SSO
+// @Description The provider redirects here after login. Short-lived JWT-Token and refresh token send with success.
+// @Produce json
+// @Tags auth
+// @Param  provider path string true "Provider name"
+// @Param  code query string true "Authorization code"
+// @Param  state query string true "State from the login redirect"
+// @Success 200 {object} models.ResponseWithJWTMessage
+// @Failure 401 {object} models.ShortResponseMessage "Не удалось войти через внешний сервис!"
+// @Failure 403 {object} models.ShortResponseMessage "Учётная запись заблокирована!" | "Пользователь с таким логином уже есть, привяжите внешний вход в своей учётной записи!"
+// @Failure 404 {object} models.ShortResponseMessage "Пользователь не найден!" | "Способ входа не поддерживается!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/login/{provider}/callback [GET]
+func (h *AuthHandler) ExternalLoginCallback(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	provider, _ := mux.Vars(r)["provider"]
+	stateCookie, cookieErr := r.Cookie(authStateCookie)
+	state := r.URL.Query().Get("state")
+	if cookieErr != nil || state == objects.EmptyString ||
+		subtle.ConstantTimeCompare([]byte(stateCookie.Value), []byte(state)) != 1 {
+		statusCode = http.StatusUnauthorized
+		handleMessage = objects.ExternalAuthErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(h.logger, r, statusCode, handleMessage, cookieErr)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: authStateCookie, Path: objects.AuthURI, MaxAge: -1})
+
+	user, err := h.AuthManager.LoginWithCode(r.Context(), provider, r.URL.Query().Get("code"))
+	if err == nil {
+		session, sessionErr := h.AuthManager.CreateSession(user.GetLogin(), user.GetPrivelegeLevel())
+		if sessionErr == nil {
+			result := models.CreateResponseWithJWTMessage(session)
+			bytes, _ := json.Marshal(&result)
+			_, _ = w.Write(bytes)
+			logger.WriteInfoInLog(h.logger, r, http.StatusOK, objects.AuthOK, nil)
+			return
+		}
+		err = sessionErr
+	}
+
+	switch err {
+	case appErrors.ExternalAuthErr:
+		statusCode = http.StatusUnauthorized
+		handleMessage = objects.ExternalAuthErrorString
+	case appErrors.UserDisabledErr:
 		statusCode = http.StatusForbidden
-		handleMessage = objects.PasswordErrorString
+		handleMessage = objects.UserDisabledErrorString
+	case appErrors.ExternalNotLinkedErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.ExternalNotLinkedErrorString
+	case appErrors.UserNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.UserNotFoundErrorString
+	case appErrors.UnknownProviderErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.UnknownProviderErrorString
 	default:
 		statusCode = http.StatusInternalServerError
 		handleMessage = objects.InternalServerErrorString
 	}
 
-	if newRole == objects.NonAuth {
-		h.AppManager.FoldState()
-	} else {
-		h.AppManager.SetNewState(authParams.Login, newRole)
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(h.logger, r, statusCode, handleMessage, err)
+}
+
+// LinkExternalAccount
+// @Summary Link university account to own user
+// @Description An existing user can log in with the provider only after this action, accounts are not linked
+// @Description by the same login. "ldap" checks login and password, redirect providers need the authorization code.
+// @Tags auth
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param  provider path string true "Provider name"
+// @Param  requestParams body models.LinkAccountRequestMessage true "Account of the provider"
+// @Success 200 {object} models.ShortResponseMessage "Внешняя учётная запись привязана!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!"
+// @Failure 401 {object} models.ShortResponseMessage "Неверный логин или пароль!" | "Не удалось войти через внешний сервис!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас недостаточно прав для выполнения этого запроса!"
+// @Failure 404 {object} models.ShortResponseMessage "Способ входа не поддерживается!"
+// @Failure 409 {object} models.ShortResponseMessage "Внешняя учётная запись уже привязана!"
+// @Failure 429 {object} models.ShortResponseMessage "Слишком много неудачных попыток входа, попробуйте позже!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/login/{provider}/link [POST]
+func (h *AuthHandler) LinkExternalAccount(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.LinkAccountRequestMessage
+
+	body, readErr := io.ReadAll(r.Body)
+	if readErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(h.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString, readErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(h.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	provider, _ := mux.Vars(r)["provider"]
+	identity := objects.IdentityFromContext(r.Context())
+	retryAfter, err := h.AuthManager.LinkAccount(r.Context(), identity.GetUserID(), provider, params.Login,
+		params.Password, params.Code, utils.GetClientIP(r, h.trustedProxies))
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.ExternalLinkOKString
+	case appErrors.UserNotFoundErr, appErrors.PasswordNotEqualErr:
+		statusCode = http.StatusUnauthorized
+		handleMessage = objects.InvalidCredentialsErrorString
+	case appErrors.ExternalAuthErr:
+		statusCode = http.StatusUnauthorized
+		handleMessage = objects.ExternalAuthErrorString
+	case appErrors.AccessDeniedErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.ForbiddenErrorString
+	case appErrors.ExternalLinkedErr:
+		statusCode = http.StatusConflict
+		handleMessage = objects.ExternalLinkedErrorString
+	case appErrors.TooManyAttemptsErr:
+		statusCode = http.StatusTooManyRequests
+		handleMessage = objects.TooManyAttemptsErrorString
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	case appErrors.UnknownProviderErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.UnknownProviderErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
 	}
 
 	utils.SendShortResponse(w, statusCode, handleMessage)
-	h.logger.Infof("Request: method - %s,  url - %s, Result: status_code = %d, text = %s",
-		r.Method, r.URL.Path, statusCode, handleMessage)
+	logger.WriteInfoInLog(h.logger, r, statusCode, handleMessage, err)
 }
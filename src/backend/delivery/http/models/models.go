@@ -3,6 +3,9 @@ package models
 import (
 	"src/logic/managers/models"
 	"src/objects"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type ShortResponseMessage struct {
@@ -13,6 +16,12 @@ type StudentResponseMessage struct {
 	Students []objects.Student `json:"students"`
 }
 
+// StudentsPageResponseMessage total is the number of students matching the filter on all pages.
+type StudentsPageResponseMessage struct {
+	Total    int                          `json:"total"`
+	Students []objects.StudentResponseDTO `json:"students"`
+}
+
 type StudentHistoryResponseMessage struct {
 	RoomID int `json:"room-id"`
 }
@@ -21,9 +30,18 @@ type ThingOwnerHistoryResponseMessage struct {
 	OwnerStudentNumber string `json:"owner-student-number"`
 }
 
+// AuthRequestMessage provider is "local" by default, "ldap" checks the university account.
 type AuthRequestMessage struct {
 	Login    string `json:"login"`
 	Password string `json:"password"`
+	Provider string `json:"provider,omitempty"`
+}
+
+// LinkAccountRequestMessage login and password are checked by "ldap", code is sent by the redirect providers.
+type LinkAccountRequestMessage struct {
+	Login    string `json:"login,omitempty"`
+	Password string `json:"password,omitempty"`
+	Code     string `json:"code,omitempty"`
 }
 
 type AddNewThingRequestMessage struct {
@@ -31,25 +49,155 @@ type AddNewThingRequestMessage struct {
 	ThingType  string `json:"thingType"`
 }
 
+type AddNewRoomRequestMessage struct {
+	RoomType   string         `json:"room-type"`
+	RoomNumber int            `json:"room-number"`
+	BuildingID int            `json:"building-id"`
+	Floor      int            `json:"floor"`
+	Block      string         `json:"block"`
+	Capacity   int            `json:"capacity"`
+	Gender     objects.Gender `json:"gender"`
+}
+
+// ChangeRoomRequestMessage fields are optional, only the given ones are changed.
+type ChangeRoomRequestMessage struct {
+	RoomType   *string         `json:"room-type,omitempty"`
+	RoomNumber *int            `json:"room-number,omitempty"`
+	BuildingID *int            `json:"building-id,omitempty"`
+	Floor      *int            `json:"floor,omitempty"`
+	Block      *string         `json:"block,omitempty"`
+	Capacity   *int            `json:"capacity,omitempty"`
+	Gender     *objects.Gender `json:"gender,omitempty"`
+}
+
+type AddNewBuildingRequestMessage struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Floors  int    `json:"floors"`
+}
+
 type TransferThingRequestMessage struct {
 	NewRoomID int `json:"room-id"`
 }
 
 type AddNewStudentRequestMessage struct {
-	Login         string `json:"login"`
-	Password      string `json:"password"`
-	Name          string `json:"name"`
-	Surname       string `json:"surname"`
-	Group         string `json:"group"`
-	StudentNumber string `json:"studentNumber"`
+	Login         string         `json:"login"`
+	Password      string         `json:"password"`
+	Name          string         `json:"name"`
+	Surname       string         `json:"surname"`
+	Group         string         `json:"group"`
+	StudentNumber string         `json:"studentNumber"`
+	Gender        objects.Gender `json:"gender"`
 }
 
 type ChangeStudentGroupRequestMessage struct {
 	NewGroup string `json:"newGroup"`
 }
 
+// ChangeStudentRequestMessage fields are optional, only the given ones are changed.
+// Patronymic, faculty, course, phone, email and date of birth are cleared with empty values.
+type ChangeStudentRequestMessage struct {
+	Name          *string         `json:"name,omitempty"`
+	Surname       *string         `json:"surname,omitempty"`
+	Patronymic    *string         `json:"patronymic,omitempty"`
+	Group         *string         `json:"group,omitempty"`
+	StudentNumber *string         `json:"studentNumber,omitempty"`
+	Gender        *objects.Gender `json:"gender,omitempty"`
+	Faculty       *string         `json:"faculty,omitempty"`
+	Course        *int            `json:"course,omitempty"`
+	Phone         *string         `json:"phone,omitempty"`
+	Email         *string         `json:"email,omitempty"`
+	DateOfBirth   *string         `json:"dateOfBirth,omitempty"`
+}
+
+type ChangeStudentStatusRequestMessage struct {
+	Status objects.StudentStatus `json:"status"`
+}
+
+type FieldErrorResponse struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// ValidationErrorResponseMessage lists every invalid field of the request.
+type ValidationErrorResponseMessage struct {
+	Comment string               `json:"comment"`
+	Fields  []FieldErrorResponse `json:"fields"`
+}
+
+// ClearanceResponseMessage is either the signed clearance document or the list of blockers.
+type ClearanceResponseMessage struct {
+	Comment       string                     `json:"comment"`
+	StudentNumber string                     `json:"student-number"`
+	RoomID        int                        `json:"room-id,omitempty"`
+	Things        []objects.ThingResponseDTO `json:"things,omitempty"`
+	Fees          []objects.FeeResponseDTO   `json:"fees,omitempty"`
+	Document      string                     `json:"document,omitempty"`
+	IssuedAt      string                     `json:"issued-at,omitempty"`
+}
+
+type ImportedStudentResponse struct {
+	Line          int    `json:"line"`
+	StudentNumber string `json:"studentNumber"`
+	Login         string `json:"login"`
+}
+
+type ImportRowErrorResponse struct {
+	Line   int                  `json:"line"`
+	Fields []FieldErrorResponse `json:"fields"`
+}
+
+// StudentImportResponseMessage is the dry-run report: students that would be added and rows with errors.
+type StudentImportResponseMessage struct {
+	Comment  string                    `json:"comment"`
+	Total    int                       `json:"total"`
+	Students []ImportedStudentResponse `json:"students"`
+	Errors   []ImportRowErrorResponse  `json:"errors"`
+}
+
+// StudentLiveActsRequestMessage evicts the student if RoomID is 0, Final marks the departure from the dormitory.
 type StudentLiveActsRequestMessage struct {
-	RoomID int `json:"roomID"`
+	RoomID   int  `json:"roomID"`
+	Relocate bool `json:"relocate"`
+	Final    bool `json:"final"`
+}
+
+// SubmitSettlementRequestMessage preferences are optional, priority is taken into account only from staff.
+type SubmitSettlementRequestMessage struct {
+	StudentNumber string `json:"stud-number"`
+	BuildingID    *int   `json:"building-id,omitempty"`
+	Floor         *int   `json:"floor,omitempty"`
+	RoomID        *int   `json:"room-id,omitempty"`
+	Comment       string `json:"comment"`
+	Priority      int    `json:"priority"`
+}
+
+type SubmitSettlementResponseMessage struct {
+	RequestID int `json:"request-id"`
+}
+
+// ChangeSettlementRequestMessage closes the request if status is given, otherwise changes its priority.
+// Approval settles the student into room-id or into the preferred room.
+type ChangeSettlementRequestMessage struct {
+	Status   *objects.SettlementStatus `json:"status,omitempty"`
+	RoomID   int                       `json:"room-id"`
+	Priority *int                      `json:"priority,omitempty"`
+	Comment  string                    `json:"comment"`
+}
+
+type ProposeSwapRequestMessage struct {
+	StudentNumber        string `json:"stud-number"`
+	PartnerStudentNumber string `json:"partner-stud-number"`
+}
+
+type ProposeSwapResponseMessage struct {
+	SwapID int `json:"swap-id"`
+}
+
+// ChangeSwapRequestMessage status is accepted (by the partner), rejected (by any of the students or staff)
+// or confirmed (by the commandant).
+type ChangeSwapRequestMessage struct {
+	Status objects.SwapStatus `json:"status"`
 }
 
 type StudentThingsActsRequestMessage struct {
@@ -58,13 +206,80 @@ type StudentThingsActsRequestMessage struct {
 }
 
 type ResponseWithJWTMessage struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh-token"`
+	ExpiresIn    int    `json:"expires-in"`
+}
+
+type AddNewUserRequestMessage struct {
+	Login    string         `json:"login"`
+	Password string         `json:"password"`
+	Role     objects.Levels `json:"role"`
+}
+
+// ChangeUserRequestMessage fields are optional, only the given ones are changed.
+type ChangeUserRequestMessage struct {
+	Role     *objects.Levels `json:"role,omitempty"`
+	Disabled *bool           `json:"disabled,omitempty"`
+}
+
+type CreateAPIKeyRequestMessage struct {
+	Name     string         `json:"name"`
+	Role     objects.Levels `json:"role"`
+	ReadOnly bool           `json:"read-only"`
+}
+
+type CreateAPIKeyResponseMessage struct {
+	ID  int    `json:"id"`
+	Key string `json:"key"`
+}
+
+type RefreshTokenRequestMessage struct {
+	RefreshToken string `json:"refresh-token"`
+}
+
+type ChangePasswordRequestMessage struct {
+	OldPassword string `json:"old-password"`
+	NewPassword string `json:"new-password"`
+}
+
+type ResetPasswordRequestMessage struct {
+	ResetToken  string `json:"reset-token"`
+	NewPassword string `json:"new-password"`
+}
+
+type PasswordResetResponseMessage struct {
+	ResetToken string `json:"reset-token"`
+	ExpiresIn  int    `json:"expires-in"`
 }
 
 type ThingFullInfoResponse struct {
 	Thing objects.ThingResponseDTO `json:"thing"`
 }
 
+type ThingHistoryResponse struct {
+	Thing  objects.ThingResponseDTO             `json:"thing"`
+	Owners []objects.OwnershipPeriodResponseDTO `json:"owners"`
+	Events []objects.ThingEventResponseDTO      `json:"events"`
+}
+
+// RoomThingResponse owner is omitted if the thing is not given to anyone.
+type RoomThingResponse struct {
+	Thing objects.ThingResponseDTO    `json:"thing"`
+	Owner *objects.StudentResponseDTO `json:"owner,omitempty"`
+}
+
+type RoomFullInfoResponse struct {
+	Room      objects.RoomResponseDTO      `json:"room"`
+	Residents []objects.StudentResponseDTO `json:"residents"`
+	Things    []RoomThingResponse          `json:"things"`
+}
+
+type SettlementRequestInfoResponse struct {
+	Request objects.SettlementRequestResponseDTO        `json:"request"`
+	History []objects.SettlementStatusChangeResponseDTO `json:"history"`
+}
+
 type StudentFullInfoResponse struct {
 	Student objects.StudentResponseDTO `json:"student"`
 }
@@ -83,8 +298,134 @@ func CreateThingInfoResponse(thing models.ThingFullInfo) ThingFullInfoResponse {
 	return ThingFullInfoResponse{Thing: objects.CreateThingResponse(thing.Thing)}
 }
 
+func CreateThingHistoryResponse(history models.ThingHistory) ThingHistoryResponse {
+	return ThingHistoryResponse{
+		Thing:  objects.CreateThingResponse(history.Thing),
+		Owners: objects.CreateOwnershipPeriodResponse(history.Owners, time.Now()),
+		Events: objects.CreateThingEventResponse(history.Events),
+	}
+}
+
+func CreateRoomFullInfoResponse(roomInfo models.RoomFullInfo) RoomFullInfoResponse {
+	things := make([]RoomThingResponse, objects.Empty)
+	for _, roomThing := range roomInfo.Things {
+		thingResponse := RoomThingResponse{Thing: objects.CreateThingResponse(roomThing.Thing)}
+		if roomThing.Owner.GetID() != objects.None {
+			owner := objects.CreateStudentResponseSingle(roomThing.Owner)
+			thingResponse.Owner = &owner
+		}
+		things = append(things, thingResponse)
+	}
+	return RoomFullInfoResponse{
+		Room:      objects.CreateRoomResponse(roomInfo.Room),
+		Residents: objects.CreateStudentResponse(roomInfo.Residents),
+		Things:    things,
+	}
+}
+
+func CreateSettlementRequestInfoResponse(info models.SettlementRequestInfo) SettlementRequestInfoResponse {
+	return SettlementRequestInfoResponse{
+		Request: objects.CreateSettlementRequestResponse(info.Request),
+		History: objects.CreateSettlementStatusChangeResponse(info.History),
+	}
+}
+
+func CreateStudentsPageResponseMessage(students []objects.Student, total int) StudentsPageResponseMessage {
+	return StudentsPageResponseMessage{
+		Total:    total,
+		Students: objects.CreateStudentResponse(students),
+	}
+}
+
+func CreateClearanceResponseMessage(comment string, report models.ClearanceReport) ClearanceResponseMessage {
+	result := ClearanceResponseMessage{
+		Comment:       comment,
+		StudentNumber: report.Student.GetStudentNumber(),
+		RoomID:        report.RoomID,
+		Document:      report.Document,
+	}
+	for _, thing := range report.Things {
+		result.Things = append(result.Things, objects.CreateThingResponse(thing))
+	}
+	if len(report.Fees) != objects.Empty {
+		result.Fees = objects.CreateFeesResponse(report.Fees)
+	}
+	if !report.IssuedAt.IsZero() {
+		result.IssuedAt = report.IssuedAt.Format(time.RFC3339)
+	}
+	return result
+}
+
+func CreateStudentImportResponseMessage(comment string,
+	result models.StudentImportResult) StudentImportResponseMessage {
+	message := StudentImportResponseMessage{
+		Comment:  comment,
+		Total:    result.Total,
+		Students: make([]ImportedStudentResponse, objects.Empty),
+		Errors:   make([]ImportRowErrorResponse, objects.Empty),
+	}
+	for _, student := range result.Students {
+		message.Students = append(message.Students, ImportedStudentResponse{Line: student.Line,
+			StudentNumber: student.StudentNumber, Login: student.Login})
+	}
+	for _, rowError := range result.Errors {
+		validation := CreateValidationErrorResponseMessage(comment, rowError.Fields)
+		message.Errors = append(message.Errors, ImportRowErrorResponse{Line: rowError.Line,
+			Fields: validation.Fields})
+	}
+	return message
+}
+
+// CreateImportCredentialsTable makes the file with initial passwords, the header is the first row.
+// Skipped rows are listed in the order of the file too, with their errors in the last column.
+func CreateImportCredentialsTable(result models.StudentImportResult) [][]string {
+	table := [][]string{{"line", "studentNumber", "login", "password", "error"}}
+	students, rowErrors := result.Students, result.Errors
+	for len(students) != objects.Empty || len(rowErrors) != objects.Empty {
+		if len(rowErrors) == objects.Empty || (len(students) != objects.Empty && students[0].Line < rowErrors[0].Line) {
+			student := students[0]
+			table = append(table, []string{strconv.Itoa(student.Line), student.StudentNumber, student.Login,
+				student.Password, objects.EmptyString})
+			students = students[1:]
+		} else {
+			reasons := make([]string, objects.Empty)
+			for _, field := range rowErrors[0].Fields {
+				reasons = append(reasons, field.Field+": "+field.Reason)
+			}
+			table = append(table, []string{strconv.Itoa(rowErrors[0].Line), objects.EmptyString, objects.EmptyString,
+				objects.EmptyString, strings.Join(reasons, "; ")})
+			rowErrors = rowErrors[1:]
+		}
+	}
+	return table
+}
+
 func CreateStudentFullInfoResponse(student models.StudentFullInfo) StudentFullInfoResponse {
 	return StudentFullInfoResponse{
 		Student: objects.CreateStudentResponseSingle(student.Student),
 	}
 }
+
+func CreateValidationErrorResponseMessage(comment string,
+	fieldErrors []models.FieldError) ValidationErrorResponseMessage {
+	fields := make([]FieldErrorResponse, objects.Empty)
+	for _, fieldError := range fieldErrors {
+		fields = append(fields, FieldErrorResponse{Field: fieldError.Field, Reason: fieldError.Reason})
+	}
+	return ValidationErrorResponseMessage{Comment: comment, Fields: fields}
+}
+
+func CreatePasswordResetResponseMessage(resetToken string, expiresAt time.Time) PasswordResetResponseMessage {
+	return PasswordResetResponseMessage{
+		ResetToken: resetToken,
+		ExpiresIn:  int(time.Until(expiresAt).Seconds()),
+	}
+}
+
+func CreateResponseWithJWTMessage(session models.Session) ResponseWithJWTMessage {
+	return ResponseWithJWTMessage{
+		Token:        session.AccessToken,
+		RefreshToken: session.RefreshToken,
+		ExpiresIn:    int(time.Until(session.ExpiresAt).Seconds()),
+	}
+}
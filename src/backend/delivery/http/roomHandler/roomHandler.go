@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
+	"io"
 	"net/http"
+	"src/delivery/http/models"
+	models2 "src/logic/managers/models"
 	"src/logic/managers/roomManager"
 	"src/objects"
 	"src/utils"
@@ -27,15 +30,20 @@ func CreateNewRoomHandler(logger *logrus.Entry, manager roomManager.RoomManager)
 
 // GetAllRooms
 // @Summary Get all rooms in dormitory
-// @Description View full information about rooms in dormitory.
+// @Description View full information about rooms in dormitory: building, floor, capacity, gender the room
+// @Description is reserved for and free beds. Rooms can be filtered by building and floor.
 // @Tags rooms
 // @Security JWT-Token
 // @param access-token header string true "JWT Token"
 // @Produce json
+// @Param building query int false "Building id"
+// @Param floor query int false "Floor"
 // @Param page query int false "Page param for pagination"
 // @Param size query int false "Size param for pagination"
 // @Success 200 {array} objects.RoomResponseDTO
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!"
 // @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Корпус не найден!"
 // @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
 // @Router /api/v1/rooms [GET]
 func (rh *RoomHandler) GetAllRooms(w http.ResponseWriter, r *http.Request) {
@@ -44,16 +52,18 @@ func (rh *RoomHandler) GetAllRooms(w http.ResponseWriter, r *http.Request) {
 	var err error
 
 	page, size := utils.GetPageAndSizeFromQuery(r)
-	if checkErr := utils.CheckPageAndSize(page, size); checkErr != nil {
+	buildingID, buildingErr := utils.GetOptionalIntParamByKey(r, "building")
+	floor, floorErr := utils.GetOptionalIntParamByKey(r, "floor")
+	if checkErr := utils.CheckPageAndSize(page, size); checkErr != nil || buildingErr != nil || floorErr != nil {
 		statusCode = http.StatusBadRequest
 		handleMessage = objects.WrongParamsErrorString
-		err = checkErr
+		err = appErrors.WrongRequestParamsErr
 		utils.SendShortResponse(w, statusCode, handleMessage)
 		logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, err)
 		return
 	}
 
-	allRooms, err := rh.manager.GetAllRooms(page, size)
+	allRooms, err := rh.manager.GetAllRooms(objects.NewRoomFilter(buildingID, floor), page, size)
 	resultRooms := objects.CreateRoomResponseArr(allRooms)
 	switch err {
 	case nil:
@@ -61,6 +71,10 @@ func (rh *RoomHandler) GetAllRooms(w http.ResponseWriter, r *http.Request) {
 		handleMessage = objects.AddOK
 		bytes, _ := json.Marshal(&resultRooms)
 		_, _ = w.Write(bytes)
+	case appErrors.BuildingNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.BuildingNotFoundErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
 	default:
 		statusCode = http.StatusInternalServerError
 		handleMessage = objects.InternalServerErrorString
@@ -117,3 +131,416 @@ func (rh *RoomHandler) GetRoom(w http.ResponseWriter, r *http.Request) {
 	utils.SendShortResponse(w, statusCode, handleMessage)
 	logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, err)
 }
+
+// GetRoomDetails
+// @Summary Get consolidated room view
+// @Description View room with free beds, students living there now and things located there with their owners.
+// @Tags rooms
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param  room-id path int true "Room id"
+// @Success 200 {object} models.RoomFullInfoResponse
+// @Failure 400 {object} models.ShortResponseMessage "Параметр обязательно должен быть числом!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Комната не найдена!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/rooms/{room-id}/details [GET]
+func (rh *RoomHandler) GetRoomDetails(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	roomIDString, _ := mux.Vars(r)["room-id"]
+	roomID, atoiErr := strconv.Atoi(roomIDString)
+	if atoiErr != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.MustBeIntErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, atoiErr)
+		return
+	}
+
+	roomInfo, err := rh.manager.GetRoomFullInfo(roomID)
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.AddOK
+		resultRoomInfo := models.CreateRoomFullInfoResponse(roomInfo)
+		bytes, _ := json.Marshal(&resultRoomInfo)
+		_, _ = w.Write(bytes)
+		logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, err)
+		return
+	case appErrors.RoomNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.RoomNotFoundErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, err)
+}
+
+// AddRoom
+// @Summary Add new room
+// @Description Add new room on the floor of dormitory building. Room number must be unique in the building.
+// @Description Room with zero capacity is not for living, empty gender means mixed room.
+// @Tags rooms
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param params body models.AddNewRoomRequestMessage true "Room params"
+// @Success 200 {object} models.ShortResponseMessage "Операция успешно проведена!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Корпус не найден!"
+// @Failure 422 {object} models.ShortResponseMessage "Комната с таким номером уже есть в этом корпусе!" | "В корпусе нет такого этажа!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/rooms [POST]
+func (rh *RoomHandler) AddRoom(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.AddNewRoomRequestMessage
+
+	body, readBodyErr := io.ReadAll(r.Body)
+	if readBodyErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(rh.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString, readBodyErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	roomDTO := objects.NewRoomDTO(params.RoomType, params.RoomNumber, params.Capacity, params.Gender)
+	roomDTO.SetLocation(params.BuildingID, params.Floor, params.Block)
+	err = rh.manager.AddRoom(roomDTO)
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.AddOK
+	case appErrors.BadRoomParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+	case appErrors.RoomAlreadyExistErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.RoomAlreadyExistErrorString
+	case appErrors.BuildingNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.BuildingNotFoundErrorString
+	case appErrors.FloorNotFoundErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.FloorNotFoundErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, err)
+}
+
+// ChangeRoom
+// @Summary Change room params
+// @Description Change type, number, location, capacity or gender of the room. Only the given params are changed.
+// @Description Capacity can't be less than number of students living in the room.
+// @Tags rooms
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param  room-id path int true "Room id"
+// @Param  params body models.ChangeRoomRequestMessage true "New room params"
+// @Success 200 {object} models.ShortResponseMessage "Данные комнаты успешно обновлены!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!" | "Параметр обязательно должен быть числом!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Комната не найдена!" | "Корпус не найден!"
+// @Failure 422 {object} models.ShortResponseMessage "Комната с таким номером уже есть в этом корпусе!" | "В корпусе нет такого этажа!" | "Вместимость меньше числа проживающих!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/rooms/{room-id} [PATCH]
+func (rh *RoomHandler) ChangeRoom(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.ChangeRoomRequestMessage
+
+	body, readBodyErr := io.ReadAll(r.Body)
+	if readBodyErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(rh.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString, readBodyErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err == nil && params.RoomType == nil && params.RoomNumber == nil && params.Capacity == nil &&
+		params.Gender == nil && params.BuildingID == nil && params.Floor == nil && params.Block == nil {
+		err = appErrors.WrongRequestParamsErr
+	}
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	roomIDString, _ := mux.Vars(r)["room-id"]
+	roomID, atoiErr := strconv.Atoi(roomIDString)
+	if atoiErr != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.MustBeIntErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, atoiErr)
+		return
+	}
+
+	err = rh.manager.ChangeRoom(roomID, models2.RoomChanges{
+		RoomType:   params.RoomType,
+		RoomNumber: params.RoomNumber,
+		Capacity:   params.Capacity,
+		Gender:     params.Gender,
+		BuildingID: params.BuildingID,
+		Floor:      params.Floor,
+		Block:      params.Block,
+	})
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.RoomChangeOKString
+	case appErrors.BadRoomParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+	case appErrors.RoomNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.RoomNotFoundErrorString
+	case appErrors.RoomAlreadyExistErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.RoomAlreadyExistErrorString
+	case appErrors.BuildingNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.BuildingNotFoundErrorString
+	case appErrors.FloorNotFoundErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.FloorNotFoundErrorString
+	case appErrors.RoomCapacityErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.RoomCapacityErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, err)
+}
+
+// DeleteRoom
+// @Summary Delete room
+// @Description Delete room from dormitory. Room can't be deleted while students live there or things are located there.
+// @Description Rooms mentioned in the settling or thing moving history are kept as well.
+// @Tags rooms
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param  room-id path int true "Room id"
+// @Success 200 {object} models.ShortResponseMessage "Комната удалена!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметр обязательно должен быть числом!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Комната не найдена!"
+// @Failure 422 {object} models.ShortResponseMessage "В комнате проживают студенты!" | "В комнате находятся вещи!" | "Комната упоминается в истории перемещений!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/rooms/{room-id} [DELETE]
+func (rh *RoomHandler) DeleteRoom(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	roomIDString, _ := mux.Vars(r)["room-id"]
+	roomID, atoiErr := strconv.Atoi(roomIDString)
+	if atoiErr != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.MustBeIntErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, atoiErr)
+		return
+	}
+
+	err := rh.manager.DeleteRoom(roomID)
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.RoomDeleteOKString
+	case appErrors.RoomNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.RoomNotFoundErrorString
+	case appErrors.RoomHasStudentsErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.RoomHasStudentsErrorString
+	case appErrors.RoomHasThingsErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.RoomHasThingsErrorString
+	case appErrors.RoomHasHistoryErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.RoomHasHistoryErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.DeleteRoomErrorString
+	}
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, err)
+}
+
+// GetAllBuildings
+// @Summary Get all dormitory buildings
+// @Description View buildings of dormitory with their addresses and number of floors.
+// @Tags buildings
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param page query int false "Page param for pagination"
+// @Param size query int false "Size param for pagination"
+// @Success 200 {array} objects.BuildingResponseDTO
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/buildings [GET]
+func (rh *RoomHandler) GetAllBuildings(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	page, size := utils.GetPageAndSizeFromQuery(r)
+	if checkErr := utils.CheckPageAndSize(page, size); checkErr != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, checkErr)
+		return
+	}
+
+	buildings, err := rh.manager.GetAllBuildings(page, size)
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.AddOK
+		resultBuildings := objects.CreateBuildingResponseArr(buildings)
+		bytes, _ := json.Marshal(&resultBuildings)
+		_, _ = w.Write(bytes)
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+		utils.SendResponseWithInternalErr(w)
+	}
+	logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, err)
+}
+
+// GetBuilding
+// @Summary Get building information
+// @Description View information about dormitory building.
+// @Tags buildings
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param  building-id path int true "Building id"
+// @Success 200 {object} objects.BuildingResponseDTO
+// @Failure 400 {object} models.ShortResponseMessage "Параметр обязательно должен быть числом!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Корпус не найден!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/buildings/{building-id} [GET]
+func (rh *RoomHandler) GetBuilding(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	buildingIDString, _ := mux.Vars(r)["building-id"]
+	buildingID, atoiErr := strconv.Atoi(buildingIDString)
+	if atoiErr != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.MustBeIntErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, atoiErr)
+		return
+	}
+
+	building, err := rh.manager.GetBuilding(buildingID)
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.AddOK
+		resultBuilding := objects.CreateBuildingResponse(building)
+		bytes, _ := json.Marshal(&resultBuilding)
+		_, _ = w.Write(bytes)
+		logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, err)
+		return
+	case appErrors.BuildingNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.BuildingNotFoundErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, err)
+}
+
+// AddBuilding
+// @Summary Add new building
+// @Description Add new dormitory building. Building name must be unique, building has at least one floor.
+// @Tags buildings
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param params body models.AddNewBuildingRequestMessage true "Building params"
+// @Success 200 {object} models.ShortResponseMessage "Операция успешно проведена!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 422 {object} models.ShortResponseMessage "Корпус с таким названием уже существует!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/buildings [POST]
+func (rh *RoomHandler) AddBuilding(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.AddNewBuildingRequestMessage
+
+	body, readBodyErr := io.ReadAll(r.Body)
+	if readBodyErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(rh.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString, readBodyErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	err = rh.manager.AddBuilding(params.Name, params.Address, params.Floors)
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.AddOK
+	case appErrors.BadBuildingParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+	case appErrors.BuildingAlreadyExistErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.BuildingExistsErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(rh.logger, r, statusCode, handleMessage, err)
+}
@@ -0,0 +1,423 @@
+package settlementHandler
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"src/delivery/http/models"
+	"src/logic/managers/settlementManager"
+	"src/logic/managers/studentManager"
+	"src/objects"
+	"src/utils"
+	appErrors "src/utils/error"
+	"src/utils/logger"
+	"strconv"
+)
+
+type SettlementHandler struct {
+	logger         *logrus.Entry
+	manager        settlementManager.SettlementManager
+	studentManager studentManager.StudentManager
+}
+
+func CreateNewSettlementHandler(logger *logrus.Entry, man settlementManager.SettlementManager,
+	studentMan studentManager.StudentManager) *SettlementHandler {
+	return &SettlementHandler{
+		logger:         logger,
+		manager:        man,
+		studentManager: studentMan,
+	}
+}
+
+// valueOrNone turns an optional request field into None when it is not given.
+func valueOrNone(value *int) int {
+	if value == nil {
+		return objects.None
+	}
+	return *value
+}
+
+// SubmitSettlementRequest
+// @Summary Submit settlement request
+// @Description Put a student who does not live in the dormitory into the waiting list. Building, floor and room
+// @Description are optional preferences. Students may submit only their own request, priority is set by staff only.
+// @Tags settlement-requests
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param params body models.SubmitSettlementRequestMessage true "Request params"
+// @Success 200 {object} models.SubmitSettlementResponseMessage
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!" | "Параметр не должен быть пустой"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Студент не найден"
+// @Failure 422 {object} models.ShortResponseMessage "Студент уже живёт в другой комнате!" | "У студента уже есть заявка на заселение!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/settlement-requests [POST]
+func (sh *SettlementHandler) SubmitSettlementRequest(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.SubmitSettlementRequestMessage
+
+	body, readBodyErr := io.ReadAll(r.Body)
+	if readBodyErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(sh.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString, readBodyErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	identity := objects.IdentityFromContext(r.Context())
+	if identity.GetRole() == objects.StudentRole {
+		params.Priority = objects.Null
+	}
+
+	var requestID int
+	err = sh.studentManager.CheckStudentAccess(identity.GetLogin(), identity.GetRole(), params.StudentNumber)
+	if err == nil {
+		preferences := objects.NewSettlementPreferences(valueOrNone(params.BuildingID), valueOrNone(params.Floor),
+			valueOrNone(params.RoomID), params.Comment)
+		requestID, err = sh.manager.SubmitRequest(params.StudentNumber, preferences, params.Priority,
+			identity.GetUserID())
+	}
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.AddOK
+		bytes, _ := json.Marshal(&models.SubmitSettlementResponseMessage{RequestID: requestID})
+		_, _ = w.Write(bytes)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+		return
+	case appErrors.AccessDeniedErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.ForbiddenErrorString
+	case appErrors.BadStudentParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.EmptyParamsErrorString
+	case appErrors.BadSettlementParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+	case appErrors.StudentNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.StudentNotFoundErrorString
+	case appErrors.StudentAlreadyLiveErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.SettleStudentErrorString
+	case appErrors.StudentNotActiveErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.StudentNotActiveErrorString
+	case appErrors.SettlementExistsErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.SettlementExistsErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+}
+
+// GetSettlementRequests
+// @Summary Get waiting list
+// @Description View settlement requests with the status, the most prioritized first, pending ones by default.
+// @Description With stud-number all requests of the student are returned, students may view only their own.
+// @Tags settlement-requests
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param status query string false "Possible values: pending, approved, rejected, cancelled"
+// @Param stud-number query string false "Student number"
+// @Param page query int false "Page param for pagination"
+// @Param size query int false "Size param for pagination"
+// @Success 200 {array} objects.SettlementRequestResponseDTO
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Студент не найден"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/settlement-requests [GET]
+func (sh *SettlementHandler) GetSettlementRequests(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+	var err error
+	var requests []objects.SettlementRequest
+
+	page, size := utils.GetPageAndSizeFromQuery(r)
+	status := objects.SettlementStatus(r.URL.Query().Get("status"))
+	if status == objects.EmptyString {
+		status = objects.SettlementPending
+	}
+	studentNumber := r.URL.Query().Get("stud-number")
+
+	if checkErr := utils.CheckPageAndSize(page, size); checkErr != nil {
+		err = appErrors.WrongRequestParamsErr
+	} else {
+		identity := objects.IdentityFromContext(r.Context())
+		err = sh.studentManager.CheckStudentAccess(identity.GetLogin(), identity.GetRole(), studentNumber)
+		if err == nil && studentNumber != objects.EmptyString {
+			requests, err = sh.manager.GetStudentRequests(studentNumber)
+		} else if err == nil {
+			requests, err = sh.manager.GetWaitingList(status, page, size)
+		}
+	}
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.AddOK
+		resultRequests := objects.CreateSettlementRequestResponseArr(requests)
+		bytes, _ := json.Marshal(&resultRequests)
+		_, _ = w.Write(bytes)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+		return
+	case appErrors.WrongRequestParamsErr, appErrors.BadSettlementParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+	case appErrors.AccessDeniedErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.ForbiddenErrorString
+	case appErrors.StudentNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.StudentNotFoundErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+}
+
+// GetSettlementRequest
+// @Summary Get settlement request
+// @Description View settlement request with the history of its status changes. Students may view only their own.
+// @Tags settlement-requests
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param  request-id path int true "Request id"
+// @Success 200 {object} models.SettlementRequestInfoResponse
+// @Failure 400 {object} models.ShortResponseMessage "Параметр обязательно должен быть числом!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Заявка на заселение не найдена!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/settlement-requests/{request-id} [GET]
+func (sh *SettlementHandler) GetSettlementRequest(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	requestIDString, _ := mux.Vars(r)["request-id"]
+	requestID, atoiErr := strconv.Atoi(requestIDString)
+	if atoiErr != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.MustBeIntErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, atoiErr)
+		return
+	}
+
+	requestInfo, err := sh.manager.GetRequestInfo(requestID)
+	if err == nil {
+		identity := objects.IdentityFromContext(r.Context())
+		err = sh.studentManager.CheckStudentAccess(identity.GetLogin(), identity.GetRole(),
+			requestInfo.Request.GetStudentNumber())
+	}
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.AddOK
+		resultRequestInfo := models.CreateSettlementRequestInfoResponse(requestInfo)
+		bytes, _ := json.Marshal(&resultRequestInfo)
+		_, _ = w.Write(bytes)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+		return
+	case appErrors.AccessDeniedErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.ForbiddenErrorString
+	case appErrors.SettlementNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.SettlementNotFoundErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+}
+
+// ChangeSettlementRequest
+// @Summary Review settlement request
+// @Description Approve or reject a pending request, or change its priority in the waiting list.
+// @Description Approval settles the student into room-id, or into the preferred room if room-id is not given.
+// @Tags settlement-requests
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param  request-id path int true "Request id"
+// @Param  params body models.ChangeSettlementRequestMessage true "Status is approved or rejected"
+// @Success 200 {object} models.ShortResponseMessage "Заявка на заселение обновлена!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!" | "Параметр обязательно должен быть числом!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Заявка на заселение не найдена!" | "Комната не найдена!"
+// @Failure 422 {object} models.ShortResponseMessage "Заявка на заселение уже рассмотрена!" | "Студент уже живёт в другой комнате!"
+// @Failure 422 {object} models.ShortResponseMessage "В комнате нет свободных мест!" | "В этой комнате нельзя проживать!"
+// @Failure 422 {object} models.ShortResponseMessage "Комната предназначена для проживания студентов другого пола!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/settlement-requests/{request-id} [PATCH]
+func (sh *SettlementHandler) ChangeSettlementRequest(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.ChangeSettlementRequestMessage
+
+	body, readBodyErr := io.ReadAll(r.Body)
+	if readBodyErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(sh.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString, readBodyErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err == nil && params.Status == nil && params.Priority == nil {
+		err = appErrors.WrongRequestParamsErr
+	}
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	requestIDString, _ := mux.Vars(r)["request-id"]
+	requestID, atoiErr := strconv.Atoi(requestIDString)
+	if atoiErr != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.MustBeIntErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, atoiErr)
+		return
+	}
+
+	identity := objects.IdentityFromContext(r.Context())
+	if params.Status == nil {
+		err = sh.manager.ChangeRequestPriority(requestID, *params.Priority)
+	} else {
+		switch *params.Status {
+		case objects.SettlementApproved:
+			err = sh.manager.ApproveRequest(requestID, params.RoomID, identity.GetUserID(), params.Comment)
+		case objects.SettlementRejected:
+			err = sh.manager.RejectRequest(requestID, identity.GetUserID(), params.Comment)
+		default:
+			err = appErrors.BadSettlementParamsErr
+		}
+	}
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.SettlementChangeOKString
+	case appErrors.BadSettlementParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+	case appErrors.SettlementNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.SettlementNotFoundErrorString
+	case appErrors.RoomNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.RoomNotFoundErrorString
+	case appErrors.SettlementClosedErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.SettlementClosedErrorString
+	case appErrors.StudentAlreadyLiveErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.SettleStudentErrorString
+	case appErrors.StudentNotActiveErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.StudentNotActiveErrorString
+	case appErrors.RoomIsFullErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.RoomIsFullErrorString
+	case appErrors.RoomNotForLivingErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.RoomNotForLivingErrorString
+	case appErrors.RoomGenderErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.RoomGenderErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+}
+
+// CancelSettlementRequest
+// @Summary Cancel settlement request
+// @Description Remove a pending request from the waiting list. Students may cancel only their own requests.
+// @Tags settlement-requests
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param  request-id path int true "Request id"
+// @Success 200 {object} models.ShortResponseMessage "Заявка на заселение отменена!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметр обязательно должен быть числом!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Заявка на заселение не найдена!"
+// @Failure 422 {object} models.ShortResponseMessage "Заявка на заселение уже рассмотрена!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/settlement-requests/{request-id} [DELETE]
+func (sh *SettlementHandler) CancelSettlementRequest(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	requestIDString, _ := mux.Vars(r)["request-id"]
+	requestID, atoiErr := strconv.Atoi(requestIDString)
+	if atoiErr != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.MustBeIntErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, atoiErr)
+		return
+	}
+
+	identity := objects.IdentityFromContext(r.Context())
+	requestInfo, err := sh.manager.GetRequestInfo(requestID)
+	if err == nil {
+		err = sh.studentManager.CheckStudentAccess(identity.GetLogin(), identity.GetRole(),
+			requestInfo.Request.GetStudentNumber())
+	}
+	if err == nil {
+		err = sh.manager.CancelRequest(requestID, identity.GetUserID(), objects.EmptyString)
+	}
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.SettlementCancelOKString
+	case appErrors.AccessDeniedErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.ForbiddenErrorString
+	case appErrors.SettlementNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.SettlementNotFoundErrorString
+	case appErrors.SettlementClosedErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.SettlementClosedErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+}
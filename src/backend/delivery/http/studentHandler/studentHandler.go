@@ -7,11 +7,19 @@ import (
 	"io"
 	"net/http"
 	"src/delivery/http/models"
+	models2 "src/logic/managers/models"
 	"src/logic/managers/studentManager"
 	"src/objects"
 	"src/utils"
 	appErrors "src/utils/error"
 	"src/utils/logger"
+	"src/utils/tableUtils"
+	"strconv"
+)
+
+const (
+	importFileKey     = "file"
+	maxImportFileSize = 10 << 20
 )
 
 type StudentHandler struct {
@@ -28,14 +36,25 @@ func CreateNewStudentHandler(logger *logrus.Entry, manager studentManager.Studen
 
 // GetAllStudents
 // @Summary Get all students in dormitory
-// @Description View full information about students have lived in dormitory.
+// @Description View full information about students have lived in dormitory. Students can be searched by surname
+// @Description prefix and filtered by group, faculty, room, living status, unreturned things and student status.
 // @Tags students
 // @Security JWT-Token
 // @param access-token header string true "JWT Token"
 // @Produce json
+// @Param surname query string false "Surname prefix"
+// @Param group query string false "Student group"
+// @Param faculty query string false "Faculty"
+// @Param room query int false "Room id"
+// @Param living query bool false "Students living (true) or not living (false) in dormitory"
+// @Param has-things query bool false "Students with (true) or without (false) unreturned things"
+// @Param status query string false "Student status: active, academic-leave, graduated or expelled"
+// @Param sort query string false "Sort by surname, group, number, room or course, by id if not set"
+// @Param order query string false "Sort order: asc (default) or desc"
 // @Param page query int false "Page param for pagination"
 // @Param size query int false "Size param for pagination"
-// @Success 200 {array} objects.StudentResponseDTO
+// @Success 200 {object} models.StudentsPageResponseMessage
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!"
 // @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
 // @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
 // @Router /api/v1/students [GET]
@@ -44,24 +63,40 @@ func (sh *StudentHandler) GetAllStudents(w http.ResponseWriter, r *http.Request)
 	var handleMessage string
 	var err error
 
+	query := r.URL.Query()
 	page, size := utils.GetPageAndSizeFromQuery(r)
-	if checkErr := utils.CheckPageAndSize(page, size); checkErr != nil {
+	roomID, roomErr := utils.GetOptionalIntParamByKey(r, "room")
+	living, livingErr := utils.GetOptionalBoolParamByKey(r, "living")
+	hasThings, hasThingsErr := utils.GetOptionalBoolParamByKey(r, "has-things")
+	status := objects.StudentStatus(query.Get("status"))
+	order := query.Get("order")
+	if checkErr := utils.CheckPageAndSize(page, size); checkErr != nil || roomErr != nil || livingErr != nil ||
+		hasThingsErr != nil || (status != objects.EmptyString && !status.IsValid()) ||
+		(order != objects.EmptyString && order != objects.SortAsc && order != objects.SortDesc) {
 		statusCode = http.StatusBadRequest
 		handleMessage = objects.WrongParamsErrorString
-		err = checkErr
+		err = appErrors.WrongRequestParamsErr
 		utils.SendShortResponse(w, statusCode, handleMessage)
 		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
 		return
 	}
 
-	allStudents, err := sh.manager.ViewAllStudents(page, size)
+	filter := objects.NewStudentFilter(query.Get("surname"), query.Get("group"), query.Get("faculty"), roomID,
+		living, hasThings, status)
+	filter.SetSort(query.Get("sort"), order == objects.SortDesc)
+
+	allStudents, total, err := sh.manager.ViewAllStudents(filter, page, size)
 	switch err {
 	case nil:
 		statusCode = http.StatusOK
 		handleMessage = objects.AddOK
-		resultStudents := objects.CreateStudentResponse(allStudents)
-		bytes, _ := json.Marshal(&resultStudents)
+		result := models.CreateStudentsPageResponseMessage(allStudents, total)
+		bytes, _ := json.Marshal(&result)
 		_, _ = w.Write(bytes)
+	case appErrors.WrongRequestParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
 	default:
 		statusCode = http.StatusInternalServerError
 		handleMessage = objects.InternalServerErrorString
@@ -134,20 +169,196 @@ func (sh *StudentHandler) ChangeStudentGroup(w http.ResponseWriter, r *http.Requ
 	logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
 }
 
+// ChangeStudent
+// @Summary Change student data
+// @Description Change any of the student fields. Only the given fields are changed.
+// @Description Student number must look like 19У609 and group like ИУ7-75Б, date of birth is in YYYY-MM-DD format.
+// @Produce json
+// @Tags students
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Param  stud-number path string true "Student Number"
+// @Param  params body models.ChangeStudentRequestMessage true "New student data"
+// @Success 200 {object} models.ShortResponseMessage "Данные о студенте успешно обновлены!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Студент не найден"
+// @Failure 422 {object} models.ValidationErrorResponseMessage "Некоторые поля указаны неверно!"
+// @Failure 422 {object} models.ShortResponseMessage "Студент с таким же студенческим билетом уже существует!"
+// @Failure 422 {object} models.ShortResponseMessage "Комната предназначена для проживания студентов другого пола!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/students/{stud-number} [PATCH]
+func (sh *StudentHandler) ChangeStudent(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.ChangeStudentRequestMessage
+
+	body, readBodyErr := io.ReadAll(r.Body)
+	if readBodyErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(sh.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString,
+			readBodyErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err == nil && params.Name == nil && params.Surname == nil && params.Patronymic == nil &&
+		params.Group == nil && params.StudentNumber == nil && params.Gender == nil && params.Faculty == nil &&
+		params.Course == nil && params.Phone == nil && params.Email == nil && params.DateOfBirth == nil {
+		err = appErrors.WrongRequestParamsErr
+	}
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	studentNumber, _ := mux.Vars(r)["stud-number"]
+
+	fieldErrors, err := sh.manager.ChangeStudent(studentNumber, models2.StudentChanges{
+		Name:          params.Name,
+		Surname:       params.Surname,
+		Patronymic:    params.Patronymic,
+		StudentGroup:  params.Group,
+		StudentNumber: params.StudentNumber,
+		Gender:        params.Gender,
+		Faculty:       params.Faculty,
+		Course:        params.Course,
+		Phone:         params.Phone,
+		Email:         params.Email,
+		DateOfBirth:   params.DateOfBirth,
+	})
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.StudentChangeOKString
+	case appErrors.BadStudentFieldsErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.StudentFieldsErrorString
+		result := models.CreateValidationErrorResponseMessage(handleMessage, fieldErrors)
+		bytes, _ := json.Marshal(&result)
+		w.WriteHeader(statusCode)
+		_, _ = w.Write(bytes)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+		return
+	case appErrors.BadStudentParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.EmptyParamsErrorString
+	case appErrors.StudentNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.StudentNotFoundErrorString
+	case appErrors.StudentAlreadyInBaseErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.StudentAlreadyExistErrorString
+	case appErrors.RoomGenderErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.RoomGenderErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+}
+
+// ChangeStudentStatus
+// @Summary Change student status
+// @Description Move student to academic leave, graduate or expel student, or return student from academic leave.
+// @Description To leave the active status the student must be evicted and return all things, then the user account
+// @Description is disabled. Graduated and expelled students are kept for history.
+// @Produce json
+// @Tags students
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Param  stud-number path string true "Student Number"
+// @Param  params body models.ChangeStudentStatusRequestMessage true "New student status"
+// @Success 200 {object} models.ShortResponseMessage "Статус студента изменён!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!" | "Параметр не должен быть пустой"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Студент не найден"
+// @Failure 422 {object} models.ShortResponseMessage "Студента нельзя перевести в этот статус!" | "Студент ещё живёт в общежитии!"
+// @Failure 422 {object} models.ShortResponseMessage "У студента есть невозвращённые вещи!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/students/{stud-number}/status [PUT]
+func (sh *StudentHandler) ChangeStudentStatus(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.ChangeStudentStatusRequestMessage
+
+	body, readBodyErr := io.ReadAll(r.Body)
+	if readBodyErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(sh.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString,
+			readBodyErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	studentNumber, _ := mux.Vars(r)["stud-number"]
+
+	err = sh.manager.ChangeStudentStatus(studentNumber, params.Status)
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.StudentStatusChangeOKString
+	case appErrors.BadStudentParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+	case appErrors.StudentNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.StudentNotFoundErrorString
+	case appErrors.StudentStatusErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.StudentStatusErrorString
+	case appErrors.StudentIsLivingErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.StudentIsLivingErrorString
+	case appErrors.StudentHasThingsErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.StudentHasThingsErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+}
+
 // TransferStudent
 // @Summary Settle/evic student in dormitory
-// @Description Settle/evic student in certain room.
+// @Description Settle/evic student in certain room. With relocate flag a living student is moved to
+// @Description the room at once: if the move fails, the student stays in the old room.
 // @Produce json
 // @Tags students-living-acts
 // @Security JWT-Token
 // @param access-token header string true "JWT Token"
 // @Param  stud-number path string true "Student Number"
-// @Param  requestParams body models.StudentLiveActsRequestMessage true "Параметры запроса. Если roomID == 0, то студент выселяется."
+// @Param  requestParams body models.StudentLiveActsRequestMessage true "Параметры запроса. Если roomID == 0, то студент выселяется. Если relocate == true, то студент переселяется в комнату roomID. Если final == true, то студент выезжает из общежития и не должен иметь задолженностей."
 // @Success 200 {object} models.ShortResponseMessage "Данные о студенте успешно обновлены!"
 // @Failure 400 {object} models.ShortResponseMessage "Параметр не должен быть пустой" | "Параметр должен быть числом!"
 // @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
 // @Failure 404 {object} models.ShortResponseMessage "Студент не найден" | "Комната не найдена"
 // @Failure 422 {object} models.ShortResponseMessage "Студент уже живёт в другой комнате!" | "Студент уже нигде не живёт!"
+// @Failure 422 {object} models.ShortResponseMessage "В комнате нет свободных мест!" | "В этой комнате нельзя проживать!"
+// @Failure 422 {object} models.ShortResponseMessage "Комната предназначена для проживания студентов другого пола!"
+// @Failure 422 {object} models.ShortResponseMessage "Студент уже живёт в этой комнате!"
+// @Failure 422 {object} models.ShortResponseMessage "Обходной лист не подписан: у студента есть задолженности!"
 // @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
 // @Router /api/v1/student-live-acts/{stud-number} [POST]
 func (sh *StudentHandler) TransferStudent(w http.ResponseWriter, r *http.Request) {
@@ -177,12 +388,15 @@ func (sh *StudentHandler) TransferStudent(w http.ResponseWriter, r *http.Request
 	}
 
 	studentNumber, _ := mux.Vars(r)["stud-number"]
+	identity := objects.IdentityFromContext(r.Context())
 
-	switch params.RoomID {
-	case objects.Null:
-		err = sh.manager.EvicStudent(studentNumber)
+	switch {
+	case params.RoomID == objects.Null:
+		err = sh.manager.EvicStudent(studentNumber, identity.GetUserID(), params.Final)
+	case params.Relocate:
+		err = sh.manager.RelocateStudent(studentNumber, params.RoomID, identity.GetUserID())
 	default:
-		err = sh.manager.SettleStudent(studentNumber, params.RoomID)
+		err = sh.manager.SettleStudent(studentNumber, params.RoomID, identity.GetUserID())
 	}
 
 	switch err {
@@ -204,9 +418,27 @@ func (sh *StudentHandler) TransferStudent(w http.ResponseWriter, r *http.Request
 	case appErrors.StudentAlreadyLiveErr:
 		statusCode = http.StatusUnprocessableEntity
 		handleMessage = objects.SettleStudentErrorString
+	case appErrors.StudentNotActiveErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.StudentNotActiveErrorString
+	case appErrors.StudentClearanceErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.StudentClearanceErrorString
 	case appErrors.StudentNotLivingErr:
 		statusCode = http.StatusUnprocessableEntity
 		handleMessage = objects.EvicStudentErrorString
+	case appErrors.RoomIsFullErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.RoomIsFullErrorString
+	case appErrors.RoomNotForLivingErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.RoomNotForLivingErrorString
+	case appErrors.RoomGenderErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.RoomGenderErrorString
+	case appErrors.BadDstRoomErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.SameRoomErrorString
 	default:
 		statusCode = http.StatusInternalServerError
 		handleMessage = objects.InternalServerErrorString
@@ -295,6 +527,9 @@ func (sh *StudentHandler) TransferThingFromToStudents(w http.ResponseWriter, r *
 	case appErrors.ThingHasOwnerErr:
 		statusCode = http.StatusUnprocessableEntity
 		handleMessage = objects.GiveThingErrorString
+	case appErrors.StudentNotActiveErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.StudentNotActiveErrorString
 	case appErrors.StudentIsNotOwnerErr:
 		statusCode = http.StatusUnprocessableEntity
 		handleMessage = objects.ReturnThingErrorString
@@ -303,8 +538,97 @@ func (sh *StudentHandler) TransferThingFromToStudents(w http.ResponseWriter, r *
 		handleMessage = objects.InternalServerErrorString
 	}
 	utils.SendShortResponse(w, statusCode, handleMessage)
-	sh.logger.Infof("Request: method - %s,  url - %s, Result: status_code = %d, text = %s, err = %v",
-		r.Method, r.URL.Path, statusCode, handleMessage, err)
+	logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+}
+
+// ImportStudents
+// @Summary Import students from CSV or XLSX file
+// @Description Add students in bulk. The first row of the file is the header with the fields of
+// @Description AddNewStudentRequestMessage: name, surname, group, studentNumber and optional gender, login, password.
+// @Description A missing login is made from the student number, a missing password is generated.
+// @Description With dry-run the file is only checked and the report with row errors is returned.
+// @Description Otherwise valid rows are added in one transaction and a CSV file with logins and initial passwords
+// @Description is returned, skipped rows are listed there with their errors and counted in the X-Import-Skipped-Rows
+// @Description header.
+// @Tags students
+// @Accept multipart/form-data
+// @Param  file formData file true "CSV or XLSX file"
+// @Param  dry-run query bool false "Only check the file"
+// @Success 200 {object} models.StudentImportResponseMessage "Файл проверен, студенты не добавлены."
+// @Success 200 {file} file "CSV file with logins and initial passwords"
+// @Failure 400 {object} models.ShortResponseMessage "Файл должен быть в формате CSV или XLSX и содержать заголовок!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json,text/csv
+// @Router /api/v1/students/import [POST]
+func (sh *StudentHandler) ImportStudents(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	dryRun, err := utils.GetOptionalBoolParamByKey(r, "dry-run")
+	var table []tableUtils.Row
+	if err == nil {
+		r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+		table, err = readImportFile(r)
+	}
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.ImportFileErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	isDryRun := dryRun != nil && *dryRun
+	result, err := sh.manager.ImportStudents(table, isDryRun)
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		if isDryRun {
+			handleMessage = objects.ImportDryRunOKString
+			message := models.CreateStudentImportResponseMessage(handleMessage, result)
+			bytes, _ := json.Marshal(&message)
+			_, _ = w.Write(bytes)
+		} else {
+			handleMessage = objects.ImportOKString
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Header().Set("Content-Disposition", `attachment; filename="students.csv"`)
+			w.Header().Set("X-Import-Skipped-Rows", strconv.Itoa(len(result.Errors)))
+			err = tableUtils.WriteCSV(w, models.CreateImportCredentialsTable(result))
+		}
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+		return
+	case appErrors.BadImportFileErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.ImportFileErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+}
+
+func readImportFile(r *http.Request) ([]tableUtils.Row, error) {
+	file, header, err := r.FormFile(importFileKey)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	format := tableUtils.FormatFromFileName(header.Filename)
+	if format == objects.EmptyString {
+		return nil, appErrors.BadImportFileErr
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	return tableUtils.ReadTable(data, format)
 }
 
 // AddNewStudent
@@ -348,7 +672,7 @@ func (sh *StudentHandler) AddNewStudent(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = sh.manager.AddNewStudent(params.Name, params.Surname, params.Group, params.StudentNumber,
+	err = sh.manager.AddNewStudent(params.Name, params.Surname, params.Group, params.StudentNumber, params.Gender,
 		params.Login, params.Password)
 
 	switch err {
@@ -395,9 +719,15 @@ func (sh *StudentHandler) ViewStudentInfo(w http.ResponseWriter, r *http.Request
 	var handleMessage string
 	var err error
 
+	var studentInfo models2.StudentFullInfo
+
 	studentNumber, _ := mux.Vars(r)["stud-number"]
 
-	studentInfo, err := sh.manager.ViewStudent(studentNumber)
+	identity := objects.IdentityFromContext(r.Context())
+	err = sh.manager.CheckStudentAccess(identity.GetLogin(), identity.GetRole(), studentNumber)
+	if err == nil {
+		studentInfo, err = sh.manager.ViewStudent(studentNumber)
+	}
 
 	switch err {
 	case nil:
@@ -407,6 +737,63 @@ func (sh *StudentHandler) ViewStudentInfo(w http.ResponseWriter, r *http.Request
 		bytes, _ := json.Marshal(&resultStudents)
 		_, _ = w.Write(bytes)
 		return
+	case appErrors.AccessDeniedErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.ForbiddenErrorString
+	case appErrors.BadStudentParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.EmptyParamsErrorString
+	case appErrors.StudentNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.StudentNotFoundErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+}
+
+// GetStudentClearance
+// @Summary Get student clearance
+// @Description Check that the student can leave the dormitory: the student is evicted, returned all things and
+// @Description has no unpaid fees. Then the clearance document signed by the server key is issued, it can be
+// @Description checked with the public keys from /.well-known/jwks.json. Otherwise all blockers are listed.
+// @Produce json
+// @Tags students
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Param  stud-number path string true "Student Number"
+// @Success 200 {object} models.ClearanceResponseMessage "Обходной лист подписан!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметр не должен быть пустой"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Студент не найден"
+// @Failure 422 {object} models.ClearanceResponseMessage "Обходной лист не подписан: у студента есть задолженности!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/students/{stud-number}/clearance [GET]
+func (sh *StudentHandler) GetStudentClearance(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	studentNumber, _ := mux.Vars(r)["stud-number"]
+
+	report, err := sh.manager.GetClearanceReport(studentNumber)
+
+	switch err {
+	case nil, appErrors.StudentClearanceErr:
+		statusCode = http.StatusOK
+		handleMessage = objects.StudentClearanceOKString
+		if err != nil {
+			statusCode = http.StatusUnprocessableEntity
+			handleMessage = objects.StudentClearanceErrorString
+		}
+		result := models.CreateClearanceResponseMessage(handleMessage, report)
+		bytes, _ := json.Marshal(&result)
+		w.WriteHeader(statusCode)
+		_, _ = w.Write(bytes)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+		return
 	case appErrors.BadStudentParamsErr:
 		statusCode = http.StatusBadRequest
 		handleMessage = objects.EmptyParamsErrorString
@@ -424,46 +811,76 @@ func (sh *StudentHandler) ViewStudentInfo(w http.ResponseWriter, r *http.Request
 
 // ViewStudentLivingHistory
 // @Summary View history of student living
-// @Description View history of student living.
+// @Description View current room of student (status=current) or the timeline of settle and evict acts (status=all).
+// @Description The timeline can be limited by dates in YYYY-MM-DD format.
 // @Tags students-living-acts
 // @Security JWT-Token
 // @param access-token header string true "JWT Token"
 // @Produce json
 // @Param  stud-number path string true "Студенческий билет"
 // @Param  status query string true "Параметр того, как выводить историю: текущую комнату(current) или общую историю перемещений (all)"
+// @Param  from query string false "Начало периода (YYYY-MM-DD)"
+// @Param  to query string false "Конец периода (YYYY-MM-DD)"
+// @Param  page query int false "Page param for pagination"
+// @Param  size query int false "Size param for pagination"
 // @Success 200 {object} models.StudentHistoryResponseMessage
-// @Failure 400 {object} models.ShortResponseMessage "Параметр не должен быть пустой" | "Параметр должен быть числом!"
+// @Success 200 {array} objects.LivingRecordResponseDTO
+// @Failure 400 {object} models.ShortResponseMessage "Параметр не должен быть пустой" | "Параметры указаны неверно!"
 // @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
 // @Failure 404 {object} models.ShortResponseMessage "Студент не найден"
 // @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
-// @Failure 501 {object} models.ShortResponseMessage "Пока функционал на стадии реализации"
 // @Router /api/v1/student-live-acts/{stud-number} [GET]
 func (sh *StudentHandler) ViewStudentLivingHistory(w http.ResponseWriter, r *http.Request) {
 	var statusCode int
 	var handleMessage string
 	var err error
 	var ID int
+	var records []objects.LivingRecord
+
+	studentNumber, _ := mux.Vars(r)["stud-number"]
+	identity := objects.IdentityFromContext(r.Context())
 
 	status := r.URL.Query().Get("status")
 	switch status {
 	case All:
-		err = appErrors.NotImplementedErr
+		page, size := utils.GetPageAndSizeFromQuery(r)
+		from, fromErr := utils.GetDateParamByKey(r, "from")
+		to, toErr := utils.GetDateParamByKey(r, "to")
+		if fromErr != nil || toErr != nil || utils.CheckPageAndSize(page, size) != nil {
+			err = appErrors.WrongRequestParamsErr
+		} else {
+			err = sh.manager.CheckStudentAccess(identity.GetLogin(), identity.GetRole(), studentNumber)
+			if err == nil {
+				records, err = sh.manager.ViewLivingHistory(studentNumber, from, to, page, size)
+			}
+		}
 	case Current:
-		studentNumber, _ := mux.Vars(r)["stud-number"]
-		ID, err = sh.manager.GetCurrentRoom(studentNumber)
+		err = sh.manager.CheckStudentAccess(identity.GetLogin(), identity.GetRole(), studentNumber)
+		if err == nil {
+			ID, err = sh.manager.GetCurrentRoom(studentNumber)
+		}
 	default:
 		err = appErrors.WrongRequestParamsErr
 	}
 
 	switch err {
 	case nil:
-		result := models.StudentHistoryResponseMessage{RoomID: ID}
-		bytes, _ := json.Marshal(&result)
+		var bytes []byte
+		if status == All {
+			result := objects.CreateLivingHistoryResponse(records)
+			bytes, _ = json.Marshal(&result)
+		} else {
+			result := models.StudentHistoryResponseMessage{RoomID: ID}
+			bytes, _ = json.Marshal(&result)
+		}
 		_, _ = w.Write(bytes)
 		return
-	case appErrors.NotImplementedErr:
-		statusCode = http.StatusNotImplemented
-		handleMessage = objects.NotImplementedErrorString
+	case appErrors.AccessDeniedErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.ForbiddenErrorString
+	case appErrors.BadStudentParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.EmptyParamsErrorString
 	case appErrors.WrongRequestParamsErr:
 		statusCode = http.StatusBadRequest
 		handleMessage = objects.WrongParamsErrorString
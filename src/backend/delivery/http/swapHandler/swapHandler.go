@@ -0,0 +1,360 @@
+package swapHandler
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"src/delivery/http/models"
+	"src/logic/managers/studentManager"
+	"src/logic/managers/swapManager"
+	"src/objects"
+	"src/utils"
+	appErrors "src/utils/error"
+	"src/utils/logger"
+	"strconv"
+)
+
+type SwapHandler struct {
+	logger         *logrus.Entry
+	manager        swapManager.SwapManager
+	studentManager studentManager.StudentManager
+}
+
+func CreateNewSwapHandler(logger *logrus.Entry, man swapManager.SwapManager,
+	studentMan studentManager.StudentManager) *SwapHandler {
+	return &SwapHandler{
+		logger:         logger,
+		manager:        man,
+		studentManager: studentMan,
+	}
+}
+
+// checkParticipant lets students see and reject only swaps they take part in.
+func (sh *SwapHandler) checkParticipant(identity objects.Identity, swap objects.RoomSwap) error {
+	initiator, partner := swap.GetInitiator(), swap.GetPartner()
+	err := sh.studentManager.CheckStudentAccess(identity.GetLogin(), identity.GetRole(), initiator.GetStudentNumber())
+	if err == appErrors.AccessDeniedErr {
+		err = sh.studentManager.CheckStudentAccess(identity.GetLogin(), identity.GetRole(), partner.GetStudentNumber())
+	}
+	return err
+}
+
+// ProposeSwap
+// @Summary Propose room swap
+// @Description Offer another student to swap rooms. Both students must live in the dormitory in different rooms
+// @Description and must not take part in other open swaps. Students may propose swaps only for themselves.
+// @Tags room-swaps
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param params body models.ProposeSwapRequestMessage true "Swap params"
+// @Success 200 {object} models.ProposeSwapResponseMessage
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!" | "Параметр не должен быть пустой"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Студент не найден"
+// @Failure 422 {object} models.ShortResponseMessage "Студент уже нигде не живёт!" | "Студент уже живёт в этой комнате!"
+// @Failure 422 {object} models.ShortResponseMessage "Студент уже участвует в незавершённом обмене комнатами!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/room-swaps [POST]
+func (sh *SwapHandler) ProposeSwap(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.ProposeSwapRequestMessage
+
+	body, readBodyErr := io.ReadAll(r.Body)
+	if readBodyErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(sh.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString, readBodyErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	var swapID int
+	identity := objects.IdentityFromContext(r.Context())
+	err = sh.studentManager.CheckStudentAccess(identity.GetLogin(), identity.GetRole(), params.StudentNumber)
+	if err == nil {
+		swapID, err = sh.manager.ProposeSwap(params.StudentNumber, params.PartnerStudentNumber)
+	}
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.AddOK
+		bytes, _ := json.Marshal(&models.ProposeSwapResponseMessage{SwapID: swapID})
+		_, _ = w.Write(bytes)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+		return
+	case appErrors.AccessDeniedErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.ForbiddenErrorString
+	case appErrors.BadStudentParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.EmptyParamsErrorString
+	case appErrors.BadSwapParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+	case appErrors.StudentNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.StudentNotFoundErrorString
+	case appErrors.StudentNotLivingErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.EvicStudentErrorString
+	case appErrors.BadDstRoomErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.SameRoomErrorString
+	case appErrors.SwapExistsErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.SwapExistsErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+}
+
+// GetSwaps
+// @Summary Get room swaps
+// @Description View room swaps with the status, pending ones by default. With stud-number all swaps
+// @Description of the student are returned, students may view only their own.
+// @Tags room-swaps
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param status query string false "Possible values: pending, accepted, rejected, confirmed"
+// @Param stud-number query string false "Student number"
+// @Param page query int false "Page param for pagination"
+// @Param size query int false "Size param for pagination"
+// @Success 200 {array} objects.RoomSwapResponseDTO
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Студент не найден"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/room-swaps [GET]
+func (sh *SwapHandler) GetSwaps(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+	var err error
+	var swaps []objects.RoomSwap
+
+	page, size := utils.GetPageAndSizeFromQuery(r)
+	status := objects.SwapStatus(r.URL.Query().Get("status"))
+	if status == objects.EmptyString {
+		status = objects.SwapPending
+	}
+	studentNumber := r.URL.Query().Get("stud-number")
+
+	if checkErr := utils.CheckPageAndSize(page, size); checkErr != nil {
+		err = appErrors.WrongRequestParamsErr
+	} else {
+		identity := objects.IdentityFromContext(r.Context())
+		err = sh.studentManager.CheckStudentAccess(identity.GetLogin(), identity.GetRole(), studentNumber)
+		if err == nil && studentNumber != objects.EmptyString {
+			swaps, err = sh.manager.GetStudentSwaps(studentNumber)
+		} else if err == nil {
+			swaps, err = sh.manager.GetSwaps(status, page, size)
+		}
+	}
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.AddOK
+		resultSwaps := objects.CreateRoomSwapResponseArr(swaps)
+		bytes, _ := json.Marshal(&resultSwaps)
+		_, _ = w.Write(bytes)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+		return
+	case appErrors.WrongRequestParamsErr, appErrors.BadSwapParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+	case appErrors.AccessDeniedErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.ForbiddenErrorString
+	case appErrors.StudentNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.StudentNotFoundErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+}
+
+// GetSwap
+// @Summary Get room swap
+// @Description View room swap with rooms of both students and its status. Students may view only their own swaps.
+// @Tags room-swaps
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param  swap-id path int true "Swap id"
+// @Success 200 {object} objects.RoomSwapResponseDTO
+// @Failure 400 {object} models.ShortResponseMessage "Параметр обязательно должен быть числом!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Обмен комнатами не найден!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/room-swaps/{swap-id} [GET]
+func (sh *SwapHandler) GetSwap(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	swapIDString, _ := mux.Vars(r)["swap-id"]
+	swapID, atoiErr := strconv.Atoi(swapIDString)
+	if atoiErr != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.MustBeIntErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, atoiErr)
+		return
+	}
+
+	swap, err := sh.manager.GetSwap(swapID)
+	if err == nil {
+		err = sh.checkParticipant(objects.IdentityFromContext(r.Context()), swap)
+	}
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.AddOK
+		resultSwap := objects.CreateRoomSwapResponse(swap)
+		bytes, _ := json.Marshal(&resultSwap)
+		_, _ = w.Write(bytes)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+		return
+	case appErrors.AccessDeniedErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.ForbiddenErrorString
+	case appErrors.SwapNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.SwapNotFoundErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+}
+
+// ChangeSwap
+// @Summary Move room swap to the next status
+// @Description The partner accepts a pending swap, any of the students or staff may reject an open swap.
+// @Description The commandant confirms an accepted swap, then both students are moved at once.
+// @Tags room-swaps
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param  swap-id path int true "Swap id"
+// @Param  params body models.ChangeSwapRequestMessage true "Status is accepted, rejected or confirmed"
+// @Success 200 {object} models.ShortResponseMessage "Обмен комнатами обновлён!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!" | "Параметр обязательно должен быть числом!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Обмен комнатами не найден!"
+// @Failure 422 {object} models.ShortResponseMessage "Обмен комнатами нельзя перевести в этот статус!" | "Студенты переселились после предложения обмена!"
+// @Failure 422 {object} models.ShortResponseMessage "Комната предназначена для проживания студентов другого пола!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/room-swaps/{swap-id} [PATCH]
+func (sh *SwapHandler) ChangeSwap(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.ChangeSwapRequestMessage
+
+	body, readBodyErr := io.ReadAll(r.Body)
+	if readBodyErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(sh.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString, readBodyErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	swapIDString, _ := mux.Vars(r)["swap-id"]
+	swapID, atoiErr := strconv.Atoi(swapIDString)
+	if atoiErr != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.MustBeIntErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, atoiErr)
+		return
+	}
+
+	identity := objects.IdentityFromContext(r.Context())
+	swap, err := sh.manager.GetSwap(swapID)
+	if err == nil {
+		switch params.Status {
+		case objects.SwapAccepted:
+			partner := swap.GetPartner()
+			err = appErrors.AccessDeniedErr
+			if identity.GetRole() == objects.StudentRole {
+				err = sh.studentManager.CheckStudentAccess(identity.GetLogin(), identity.GetRole(),
+					partner.GetStudentNumber())
+			}
+			if err == nil {
+				err = sh.manager.AcceptSwap(swapID)
+			}
+		case objects.SwapRejected:
+			err = sh.checkParticipant(identity, swap)
+			if err == nil {
+				err = sh.manager.RejectSwap(swapID)
+			}
+		case objects.SwapConfirmed:
+			if identity.GetRole() != objects.ComendRole {
+				err = appErrors.AccessDeniedErr
+			} else {
+				err = sh.manager.ConfirmSwap(swapID, identity.GetUserID())
+			}
+		default:
+			err = appErrors.BadSwapParamsErr
+		}
+	}
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.SwapChangeOKString
+	case appErrors.BadSwapParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+	case appErrors.AccessDeniedErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.ForbiddenErrorString
+	case appErrors.SwapNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.SwapNotFoundErrorString
+	case appErrors.SwapStatusErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.SwapStatusErrorString
+	case appErrors.SwapOutdatedErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.SwapOutdatedErrorString
+	case appErrors.RoomGenderErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.RoomGenderErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(sh.logger, r, statusCode, handleMessage, err)
+}
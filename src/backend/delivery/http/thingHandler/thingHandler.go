@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"src/delivery/http/models"
 	models2 "src/logic/managers/models"
+	"src/logic/managers/studentManager"
 	"src/logic/managers/thingManager"
 	"src/objects"
 	"src/utils"
@@ -17,14 +18,17 @@ import (
 )
 
 type ThingHandler struct {
-	logger  *logrus.Entry
-	manager thingManager.ThingManager
+	logger         *logrus.Entry
+	manager        thingManager.ThingManager
+	studentManager studentManager.StudentManager
 }
 
-func CreateNewThingHandler(logger *logrus.Entry, man thingManager.ThingManager) *ThingHandler {
+func CreateNewThingHandler(logger *logrus.Entry, man thingManager.ThingManager,
+	studentMan studentManager.StudentManager) *ThingHandler {
 	return &ThingHandler{
-		logger:  logger,
-		manager: man,
+		logger:         logger,
+		manager:        man,
+		studentManager: studentMan,
 	}
 }
 
@@ -62,15 +66,22 @@ func (th *ThingHandler) GetThings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	identity := objects.IdentityFromContext(r.Context())
 	status := r.URL.Query().Get("status")
-	switch status {
-	case All:
+	switch {
+	case identity.GetRole() == objects.StudentRole && status != OnStudent:
+		// Students see only the things issued to them.
+		err = appErrors.AccessDeniedErr
+	case status == All:
 		allThings, err = th.manager.GetFullThingInfo(page, size)
-	case Free:
+	case status == Free:
 		allThings, err = th.manager.GetFreeThings(page, size)
-	case OnStudent:
+	case status == OnStudent:
 		studentNumber := r.URL.Query().Get("stud-number")
-		allThings, err = th.manager.GetStudentThings(studentNumber, page, size)
+		err = th.studentManager.CheckStudentAccess(identity.GetLogin(), identity.GetRole(), studentNumber)
+		if err == nil {
+			allThings, err = th.manager.GetStudentThings(studentNumber, page, size)
+		}
 	default:
 		err = appErrors.WrongRequestParamsErr
 	}
@@ -81,6 +92,9 @@ func (th *ThingHandler) GetThings(w http.ResponseWriter, r *http.Request) {
 		bytes, _ := json.Marshal(&resultThings)
 		_, _ = w.Write(bytes)
 		return
+	case appErrors.AccessDeniedErr:
+		statusCode = http.StatusForbidden
+		handleMessage = objects.ForbiddenErrorString
 	case appErrors.BadThingParamsErr:
 		statusCode = http.StatusBadRequest
 		handleMessage = objects.EmptyParamsErrorString
@@ -302,7 +316,8 @@ func (th *ThingHandler) AddNewThing(w http.ResponseWriter, r *http.Request) {
 
 // ViewThingHistory
 // @Summary View history of thing owners
-// @Description View history of thing owners
+// @Description View current owner of thing (status=current) or its whole lifecycle (status=all):
+// @Description owner periods with days held and all give, return and room move acts in date order.
 // @Tags student-thing-transfer
 // @Security JWT-Token
 // @param access-token header string true "JWT Token"
@@ -310,42 +325,47 @@ func (th *ThingHandler) AddNewThing(w http.ResponseWriter, r *http.Request) {
 // @Param  mark-number path int true "Маркировочный номер"
 // @Param  status query string true "Параметр того, как выводить историю: текущего владельца(current) или общую историю (all)"
 // @Success 200 {object} models.ThingOwnerHistoryResponseMessage
+// @Success 200 {object} models.ThingHistoryResponse
 // @Failure 400 {object} models.ShortResponseMessage "Параметр не должен быть пустой" | "Параметр должен быть числом!"
 // @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
 // @Failure 404 {object} models.ShortResponseMessage "Вещь не найдена"
 // @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
-// @Failure 501 {object} models.ShortResponseMessage "Пока функционал на стадии реализации"
 // @Router /api/v1/student-things-acts/{mark-number} [GET]
 func (th *ThingHandler) ViewThingHistory(w http.ResponseWriter, r *http.Request) {
 	var statusCode int
 	var handleMessage string
 	var err error
 	var studentNumber string
+	var history models2.ThingHistory
 
+	markNumber, getMarkNumberErr := utils.GetMarkNumberFromPath(r)
 	status := r.URL.Query().Get("status")
-	switch status {
-	case All:
-		err = appErrors.NotImplementedErr
-	case Current:
-		markNumber, getMarkNumberErr := utils.GetMarkNumberFromPath(r)
-		if getMarkNumberErr == nil {
-			studentNumber, err = th.manager.GetOwner(markNumber)
-		} else {
-			err = appErrors.WrongRequestParamsErr
-		}
+	switch {
+	case getMarkNumberErr != nil:
+		err = appErrors.WrongRequestParamsErr
+	case status == All:
+		history, err = th.manager.GetThingHistory(markNumber)
+	case status == Current:
+		studentNumber, err = th.manager.GetOwner(markNumber)
 	default:
 		err = appErrors.WrongRequestParamsErr
 	}
 
 	switch err {
 	case nil:
-		result := models.ThingOwnerHistoryResponseMessage{OwnerStudentNumber: studentNumber}
-		bytes, _ := json.Marshal(&result)
+		var bytes []byte
+		if status == All {
+			result := models.CreateThingHistoryResponse(history)
+			bytes, _ = json.Marshal(&result)
+		} else {
+			result := models.ThingOwnerHistoryResponseMessage{OwnerStudentNumber: studentNumber}
+			bytes, _ = json.Marshal(&result)
+		}
 		_, _ = w.Write(bytes)
 		return
-	case appErrors.NotImplementedErr:
-		statusCode = http.StatusNotImplemented
-		handleMessage = objects.NotImplementedErrorString
+	case appErrors.BadThingParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
 	case appErrors.WrongRequestParamsErr:
 		statusCode = http.StatusBadRequest
 		handleMessage = objects.WrongParamsErrorString
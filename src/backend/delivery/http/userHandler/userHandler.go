@@ -0,0 +1,219 @@
+package userHandler
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"src/delivery/http/models"
+	"src/logic/managers/userManager"
+	"src/objects"
+	"src/utils"
+	appErrors "src/utils/error"
+	"src/utils/logger"
+	"strconv"
+)
+
+type UserHandler struct {
+	logger  *logrus.Entry
+	manager userManager.UserManager
+}
+
+func CreateNewUserHandler(logger *logrus.Entry, manager userManager.UserManager) *UserHandler {
+	return &UserHandler{
+		logger:  logger,
+		manager: manager,
+	}
+}
+
+// GetUsers
+// @Summary Get all user accounts
+// @Description View login, role and status of all user accounts. Passwords are never returned.
+// @Tags users
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param page query int false "Page param for pagination"
+// @Param size query int false "Size param for pagination"
+// @Success 200 {array} objects.UserResponseDTO
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/users [GET]
+func (uh *UserHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	page, size := utils.GetPageAndSizeFromQuery(r)
+	if checkErr := utils.CheckPageAndSize(page, size); checkErr != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(uh.logger, r, statusCode, handleMessage, checkErr)
+		return
+	}
+
+	users, err := uh.manager.GetUsers(page, size)
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.AddOK
+		result := objects.CreateUserResponse(users)
+		bytes, _ := json.Marshal(&result)
+		_, _ = w.Write(bytes)
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+		utils.SendResponseWithInternalErr(w)
+	}
+	logger.WriteInfoInLog(uh.logger, r, statusCode, handleMessage, err)
+}
+
+// AddNewUser
+// @Summary Add new staff account
+// @Description Add supply manager (role 2) or commandant (role 3) account.
+// @Tags users
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param  user-params body models.AddNewUserRequestMessage true "Account params"
+// @Success 200 {object} models.ShortResponseMessage "Операция успешно проведена!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметр не должен быть пустой" | "Указана неверная роль пользователя!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 422 {object} models.ShortResponseMessage "Пользователь с таким логином уже существует!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/users [POST]
+func (uh *UserHandler) AddNewUser(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.AddNewUserRequestMessage
+
+	body, readBodyErr := io.ReadAll(r.Body)
+	if readBodyErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(uh.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString, readBodyErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(uh.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	err = uh.manager.AddStaffUser(params.Login, params.Password, params.Role)
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.AddOK
+	case appErrors.BadUserParamsErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.EmptyParamsErrorString
+	case appErrors.BadRoleErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.BadRoleErrorString
+	case appErrors.LoginOccupedErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.UserAlreadyExistErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(uh.logger, r, statusCode, handleMessage, err)
+}
+
+// ChangeUser
+// @Summary Change role or status of staff account
+// @Description Disable, re-enable or change role of supply manager and commandant accounts.
+// @Description Disabled account can't log in, all its sessions are closed.
+// @Tags users
+// @Security JWT-Token
+// @param access-token header string true "JWT Token"
+// @Produce json
+// @Param  user-id path int true "User id"
+// @Param  user-params body models.ChangeUserRequestMessage true "New role and/or status"
+// @Success 200 {object} models.ShortResponseMessage "Данные пользователя успешно обновлены!"
+// @Failure 400 {object} models.ShortResponseMessage "Параметры указаны неверно!" | "Указана неверная роль пользователя!"
+// @Failure 403 {object} models.ShortResponseMessage "У вас нет достаточно прав!"
+// @Failure 404 {object} models.ShortResponseMessage "Пользователь не найден!"
+// @Failure 422 {object} models.ShortResponseMessage "Пользователь не является сотрудником!" | "Нельзя изменить собственную учётную запись!"
+// @Failure 500 {object} models.ShortResponseMessage "Проблемы на стороне сервера."
+// @Router /api/v1/users/{user-id} [PATCH]
+func (uh *UserHandler) ChangeUser(w http.ResponseWriter, r *http.Request) {
+	var statusCode int
+	var handleMessage string
+
+	var params models.ChangeUserRequestMessage
+
+	body, readBodyErr := io.ReadAll(r.Body)
+	if readBodyErr != nil {
+		utils.SendResponseWithInternalErr(w)
+		logger.WriteInfoInLog(uh.logger, r, http.StatusInternalServerError, objects.InternalServerErrorString, readBodyErr)
+		return
+	}
+
+	err := json.Unmarshal(body, &params)
+	if err == nil && params.Role == nil && params.Disabled == nil {
+		err = appErrors.WrongRequestParamsErr
+	}
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.WrongParamsErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(uh.logger, r, statusCode, handleMessage, err)
+		return
+	}
+
+	userIDString, _ := mux.Vars(r)["user-id"]
+	userID, atoiErr := strconv.Atoi(userIDString)
+	if atoiErr != nil {
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.MustBeIntErrorString
+		utils.SendShortResponse(w, statusCode, handleMessage)
+		logger.WriteInfoInLog(uh.logger, r, statusCode, handleMessage, atoiErr)
+		return
+	}
+
+	identity := objects.IdentityFromContext(r.Context())
+	if params.Role != nil {
+		err = uh.manager.ChangeUserRole(identity.GetUserID(), userID, *params.Role)
+	}
+	if err == nil && params.Disabled != nil {
+		if *params.Disabled {
+			err = uh.manager.DisableUser(identity.GetUserID(), userID)
+		} else {
+			err = uh.manager.EnableUser(identity.GetUserID(), userID)
+		}
+	}
+
+	switch err {
+	case nil:
+		statusCode = http.StatusOK
+		handleMessage = objects.UserChangeOKString
+	case appErrors.BadRoleErr:
+		statusCode = http.StatusBadRequest
+		handleMessage = objects.BadRoleErrorString
+	case appErrors.UserNotFoundErr:
+		statusCode = http.StatusNotFound
+		handleMessage = objects.UserNotFoundErrorString
+	case appErrors.NotStaffUserErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.NotStaffUserErrorString
+	case appErrors.SelfActionErr:
+		statusCode = http.StatusUnprocessableEntity
+		handleMessage = objects.SelfActionErrorString
+	default:
+		statusCode = http.StatusInternalServerError
+		handleMessage = objects.InternalServerErrorString
+	}
+
+	utils.SendShortResponse(w, statusCode, handleMessage)
+	logger.WriteInfoInLog(uh.logger, r, statusCode, handleMessage, err)
+}
@@ -0,0 +1,72 @@
+package apiKeyController
+
+import (
+	"database/sql"
+	"src/db/apiKeyRepo"
+	"src/objects"
+	appErrors "src/utils/error"
+	"src/utils/hashUtils"
+	"strings"
+	"time"
+)
+
+// lastUsedPrecision limits writes: last use of a busy key is updated once a minute.
+const lastUsedPrecision = time.Minute
+
+type APIKeyController struct {
+	Repo apiKeyRepo.APIKeyRepo
+}
+
+// CreateAPIKey returns the key itself, it can't be shown again.
+func (kc *APIKeyController) CreateAPIKey(name string, role objects.Levels, readOnly bool,
+	createdBy int) (int, string, error) {
+	if name == objects.EmptyString {
+		return objects.None, objects.EmptyString, appErrors.BadUserParamsErr
+	}
+	token, err := hashUtils.GenerateToken(hashUtils.DefaultTokenBytes)
+	if err != nil {
+		return objects.None, objects.EmptyString, err
+	}
+	key := objects.APIKeyPrefix + token
+	id, err := kc.Repo.AddAPIKey(name, hashUtils.HashToken(key), role, readOnly, createdBy)
+	return id, key, err
+}
+
+func (kc *APIKeyController) GetAPIKey(id int) (objects.APIKey, error) {
+	key, err := kc.Repo.GetAPIKey(id)
+	if err == sql.ErrNoRows {
+		err = appErrors.APIKeyNotFoundErr
+	}
+	return key, err
+}
+
+func (kc *APIKeyController) GetAPIKeys(page, size int) ([]objects.APIKey, error) {
+	return kc.Repo.GetAPIKeys(page, size)
+}
+
+func (kc *APIKeyController) RevokeAPIKey(id int) error {
+	_, err := kc.GetAPIKey(id)
+	if err == nil {
+		err = kc.Repo.RevokeAPIKey(id)
+	}
+	return err
+}
+
+// VerifyAPIKey returns BadAPIKeyErr for unknown and revoked keys and records the use of a valid one.
+func (kc *APIKeyController) VerifyAPIKey(key string) (objects.APIKey, error) {
+	if !strings.HasPrefix(key, objects.APIKeyPrefix) {
+		return objects.NewEmptyAPIKey(), appErrors.BadAPIKeyErr
+	}
+	apiKey, err := kc.Repo.GetAPIKeyByHash(hashUtils.HashToken(key))
+	if err == sql.ErrNoRows || (err == nil && apiKey.IsRevoked()) {
+		return objects.NewEmptyAPIKey(), appErrors.BadAPIKeyErr
+	} else if err != nil {
+		return objects.NewEmptyAPIKey(), err
+	}
+
+	if time.Since(apiKey.GetLastUsedAt()) > lastUsedPrecision {
+		// A failed update must not reject the request, the next one retries it.
+		_ = kc.Repo.TouchAPIKey(apiKey.GetID())
+	}
+	return apiKey, nil
+}
@@ -0,0 +1,173 @@
+package apiKeyController
+
+import (
+	"database/sql"
+	"github.com/bloomberg/go-testgroup"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/db/apiKeyRepo"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	appErrors "src/utils/error"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	InsertID     int64 = 5
+	RowsAffected int64 = 1
+)
+
+type TestAPIKeyController struct{}
+
+func Test_APIKeyController(t *testing.T) {
+	testgroup.RunSerially(t, &TestAPIKeyController{})
+}
+
+func (*TestAPIKeyController) TestAPIKeyController_CreateAPIKeyPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.APIKeyRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	rows := sqlmock.NewRows([]string{"id"}).AddRow(InsertID)
+	mock.ExpectQuery("INSERT INTO").
+		WithArgs(mother.DefaultAPIKeyName, sqlmock.AnyArg(), objects.SupplyRole, false, 1).
+		WillReturnError(nil).WillReturnRows(rows)
+
+	repo := apiKeyRepo.PgAPIKeyRepo{Conn: db}
+	controller := APIKeyController{Repo: &repo}
+
+	// Act
+	id, key, execErr := controller.CreateAPIKey(mother.DefaultAPIKeyName, objects.SupplyRole, false, 1)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, id, int(InsertID))
+	tests.AssertResult(t, strings.HasPrefix(key, objects.APIKeyPrefix), true)
+}
+
+func (*TestAPIKeyController) TestAPIKeyController_CreateAPIKeyNegativeEmptyName(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.APIKeyRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+
+	repo := apiKeyRepo.PgAPIKeyRepo{Conn: db}
+	controller := APIKeyController{Repo: &repo}
+
+	// Act
+	_, _, execErr := controller.CreateAPIKey(objects.EmptyString, objects.SupplyRole, false, 1)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadUserParamsErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAPIKeyController) TestAPIKeyController_VerifyAPIKeyPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.APIKeyRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	key := objectMother.CreateAPIKey(objects.SupplyRole, true, time.Now().Add(-time.Hour), false)
+	rows := objectMother.CreateRows([]objects.APIKey{key})
+	mock.ExpectQuery("SELECT").WithArgs(key.GetKeyHash()).WillReturnError(nil).WillReturnRows(rows)
+	mock.ExpectExec("UPDATE").WithArgs(key.GetID()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	repo := apiKeyRepo.PgAPIKeyRepo{Conn: db}
+	controller := APIKeyController{Repo: &repo}
+
+	// Act
+	result, execErr := controller.VerifyAPIKey(mother.DefaultAPIKey)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result.GetRole(), objects.SupplyRole)
+	tests.AssertResult(t, result.IsReadOnly(), true)
+}
+
+func (*TestAPIKeyController) TestAPIKeyController_VerifyAPIKeyPositiveRecentlyUsed(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.APIKeyRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	key := objectMother.CreateAPIKey(objects.ComendRole, false, time.Now(), false)
+	rows := objectMother.CreateRows([]objects.APIKey{key})
+	mock.ExpectQuery("SELECT").WithArgs(key.GetKeyHash()).WillReturnError(nil).WillReturnRows(rows)
+
+	repo := apiKeyRepo.PgAPIKeyRepo{Conn: db}
+	controller := APIKeyController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.VerifyAPIKey(mother.DefaultAPIKey)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAPIKeyController) TestAPIKeyController_VerifyAPIKeyNegativeRevoked(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.APIKeyRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	key := objectMother.CreateAPIKey(objects.ComendRole, false, time.Now(), true)
+	rows := objectMother.CreateRows([]objects.APIKey{key})
+	mock.ExpectQuery("SELECT").WithArgs(key.GetKeyHash()).WillReturnError(nil).WillReturnRows(rows)
+
+	repo := apiKeyRepo.PgAPIKeyRepo{Conn: db}
+	controller := APIKeyController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.VerifyAPIKey(mother.DefaultAPIKey)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadAPIKeyErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAPIKeyController) TestAPIKeyController_VerifyAPIKeyNegativeUnknown(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.APIKeyRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectQuery("SELECT").WithArgs(sqlmock.AnyArg()).WillReturnError(sql.ErrNoRows)
+
+	repo := apiKeyRepo.PgAPIKeyRepo{Conn: db}
+	controller := APIKeyController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.VerifyAPIKey(mother.DefaultAPIKey)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadAPIKeyErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAPIKeyController) TestAPIKeyController_RevokeAPIKeyNegativeNotFound(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.APIKeyRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectQuery("SELECT").WithArgs(int(InsertID)).WillReturnError(sql.ErrNoRows)
+
+	repo := apiKeyRepo.PgAPIKeyRepo{Conn: db}
+	controller := APIKeyController{Repo: &repo}
+
+	// Act
+	execErr := controller.RevokeAPIKey(int(InsertID))
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.APIKeyNotFoundErr)
+	tests.AssertMocks(t, mock)
+}
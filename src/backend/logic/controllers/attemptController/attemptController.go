@@ -0,0 +1,146 @@
+package attemptController
+
+import (
+	"expvar"
+	"src/configs/backend"
+	"src/db/attemptRepo"
+	"src/objects"
+	appErrors "src/utils/error"
+	"strings"
+	"time"
+)
+
+const (
+	defaultFreeAttempts   = 5
+	defaultIPFreeAttempts = 20
+	defaultBaseDelay      = time.Second
+	defaultMaxDelay       = 15 * time.Minute
+	defaultResetAfter     = time.Hour
+
+	loginKeyPrefix = "login:"
+	ipKeyPrefix    = "ip:"
+)
+
+// loginMetrics is published by expvar: "failed" counts wrong credentials, "throttled" counts rejected attempts.
+var loginMetrics = expvar.NewMap("login_attempts")
+
+// AttemptController delays logins after failures. Each failure over the free attempts doubles the delay
+// up to MaxDelay. Attempts are counted per login and per client address, the address has a bigger
+// allowance because students often share one NAT address.
+type AttemptController struct {
+	Repo   attemptRepo.AttemptRepo
+	Params configs.LoginThrottleParams
+}
+
+type attemptKey struct {
+	key          string
+	freeAttempts int
+}
+
+// TakeAttempt counts the attempt before the password is checked, the lock check and the increment are one
+// repo call, so parallel requests can't try more passwords than allowed. It returns TooManyAttemptsErr and
+// the time to wait if the login or the address is locked, the attempt is not counted then.
+func (ac *AttemptController) TakeAttempt(login, clientIP string) (time.Duration, error) {
+	var (
+		retryAfter time.Duration
+		taken      []attemptKey
+	)
+	for _, key := range ac.getKeys(login, clientIP) {
+		attempts, isTaken, err := ac.Repo.TakeAttempt(key.key, ac.getLimits(key.freeAttempts))
+		if err == nil && !isTaken {
+			retryAfter = time.Until(attempts.LockedUntil(ac.getLimits(key.freeAttempts)))
+			err = appErrors.TooManyAttemptsErr
+		}
+		if err != nil {
+			if err == appErrors.TooManyAttemptsErr {
+				loginMetrics.Add("throttled", 1)
+			}
+			ac.cancelKeys(taken)
+			return retryAfter, err
+		}
+		taken = append(taken, key)
+	}
+	return retryAfter, nil
+}
+
+// RegisterFailure only updates the metrics, the failure was counted by TakeAttempt.
+func (ac *AttemptController) RegisterFailure() {
+	loginMetrics.Add("failed", 1)
+}
+
+// RegisterSuccess resets the login counter and returns the attempt of the address: a valid account
+// must not clear the counter of its address.
+func (ac *AttemptController) RegisterSuccess(login, clientIP string) error {
+	keys := ac.getKeys(login, clientIP)
+	if err := ac.Repo.ResetAttempts(keys[0].key); err != nil {
+		return err
+	}
+	return ac.cancelKeys(keys[1:])
+}
+
+// CancelAttempt returns the attempt when the password was not checked, e.g. the provider is unavailable.
+func (ac *AttemptController) CancelAttempt(login, clientIP string) error {
+	return ac.cancelKeys(ac.getKeys(login, clientIP))
+}
+
+func (ac *AttemptController) cancelKeys(keys []attemptKey) error {
+	var result error
+	for _, key := range keys {
+		if err := ac.Repo.CancelAttempt(key.key); err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
+
+func (ac *AttemptController) getKeys(login, clientIP string) []attemptKey {
+	keys := []attemptKey{{key: loginKeyPrefix + strings.ToLower(login), freeAttempts: ac.getFreeAttempts()}}
+	if clientIP != objects.EmptyString {
+		keys = append(keys, attemptKey{key: ipKeyPrefix + clientIP, freeAttempts: ac.getIPFreeAttempts()})
+	}
+	return keys
+}
+
+func (ac *AttemptController) getLimits(freeAttempts int) objects.AttemptLimits {
+	return objects.AttemptLimits{
+		FreeAttempts: freeAttempts,
+		BaseDelay:    ac.getBaseDelay(),
+		MaxDelay:     ac.getMaxDelay(),
+		ResetAfter:   ac.getResetAfter(),
+	}
+}
+
+func (ac *AttemptController) getFreeAttempts() int {
+	if ac.Params.FreeAttempts <= objects.Null {
+		return defaultFreeAttempts
+	}
+	return ac.Params.FreeAttempts
+}
+
+func (ac *AttemptController) getIPFreeAttempts() int {
+	if ac.Params.IPFreeAttempts <= objects.Null {
+		return defaultIPFreeAttempts
+	}
+	return ac.Params.IPFreeAttempts
+}
+
+func (ac *AttemptController) getBaseDelay() time.Duration {
+	if ac.Params.BaseDelay <= 0 {
+		return defaultBaseDelay
+	}
+	return ac.Params.BaseDelay
+}
+
+func (ac *AttemptController) getMaxDelay() time.Duration {
+	if ac.Params.MaxDelay <= 0 {
+		return defaultMaxDelay
+	}
+	return ac.Params.MaxDelay
+}
+
+func (ac *AttemptController) getResetAfter() time.Duration {
+	if ac.Params.ResetAfter <= 0 {
+		return defaultResetAfter
+	}
+	return ac.Params.ResetAfter
+}
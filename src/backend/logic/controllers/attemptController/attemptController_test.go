@@ -0,0 +1,144 @@
+package attemptController
+
+import (
+	"github.com/bloomberg/go-testgroup"
+	"src/configs/backend"
+	"src/db/attemptRepo"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	appErrors "src/utils/error"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type TestAttemptController struct{}
+
+func Test_AttemptController(t *testing.T) {
+	testgroup.RunSerially(t, &TestAttemptController{})
+}
+
+func createController(freeAttempts int) AttemptController {
+	return AttemptController{
+		Repo: attemptRepo.NewMemoryAttemptRepo(),
+		Params: configs.LoginThrottleParams{
+			FreeAttempts:   freeAttempts,
+			IPFreeAttempts: freeAttempts * 2,
+			BaseDelay:      time.Minute,
+			MaxDelay:       time.Hour,
+			ResetAfter:     2 * time.Hour,
+		},
+	}
+}
+
+func (*TestAttemptController) TestAttemptController_TakeAttemptPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	controller := createController(2)
+	_, _ = controller.TakeAttempt(mother.DefaultLogin, mother.DefaultClientIP)
+	_, _ = controller.TakeAttempt(mother.DefaultLogin, mother.DefaultClientIP)
+
+	// Act
+	retryAfter, execErr := controller.TakeAttempt(mother.DefaultLogin, mother.DefaultClientIP)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertResult(t, retryAfter, time.Duration(objects.Null))
+}
+
+func (*TestAttemptController) TestAttemptController_TakeAttemptNegativeLocked(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	controller := createController(2)
+	for i := 0; i < 3; i++ {
+		_, _ = controller.TakeAttempt(mother.DefaultLogin, mother.DefaultClientIP)
+	}
+
+	// Act
+	retryAfter, execErr := controller.TakeAttempt(mother.DefaultLogin, mother.DefaultClientIP)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.TooManyAttemptsErr)
+	tests.AssertResult(t, retryAfter > 0 && retryAfter <= time.Minute, true)
+}
+
+func (*TestAttemptController) TestAttemptController_TakeAttemptNegativeLockedIP(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	controller := createController(1)
+	_, _ = controller.TakeAttempt("first", mother.DefaultClientIP)
+	_, _ = controller.TakeAttempt("second", mother.DefaultClientIP)
+	_, _ = controller.TakeAttempt("third", mother.DefaultClientIP)
+
+	// Act
+	_, execErr := controller.TakeAttempt(mother.DefaultLogin, mother.DefaultClientIP)
+	attempts, _ := controller.Repo.GetAttempts(mother.DefaultLoginKey)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.TooManyAttemptsErr)
+	tests.AssertResult(t, attempts.GetFailures(), objects.Null)
+}
+
+func (*TestAttemptController) TestAttemptController_TakeAttemptConcurrent(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	controller := createController(2)
+	var (
+		wait   sync.WaitGroup
+		passed int32
+	)
+
+	// Act
+	for i := 0; i < 10; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			if _, err := controller.TakeAttempt(mother.DefaultLogin, objects.EmptyString); err == nil {
+				atomic.AddInt32(&passed, 1)
+			}
+		}()
+	}
+	wait.Wait()
+
+	// Assert
+	tests.AssertResult(t, atomic.LoadInt32(&passed), int32(3))
+}
+
+func (*TestAttemptController) TestAttemptController_RegisterSuccess(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	controller := createController(1)
+	_, _ = controller.TakeAttempt(mother.DefaultLogin, mother.DefaultClientIP)
+	_, _ = controller.TakeAttempt(mother.DefaultLogin, mother.DefaultClientIP)
+
+	// Act
+	execErr := controller.RegisterSuccess(mother.DefaultLogin, mother.DefaultClientIP)
+	_, takeErr := controller.TakeAttempt(mother.DefaultLogin, objects.EmptyString)
+	attempts, _ := controller.Repo.GetAttempts(mother.DefaultIPKey)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertErrors(t, takeErr, nil)
+	tests.AssertResult(t, attempts.GetFailures(), 1)
+}
+
+func (*TestAttemptController) TestAttemptController_GetDelay(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	controller := createController(2)
+	limits := controller.getLimits(2)
+
+	// Act
+	delays := []time.Duration{limits.Delay(2), limits.Delay(3), limits.Delay(5), limits.Delay(20)}
+
+	// Assert
+	tests.AssertResult(t, delays, []time.Duration{0, time.Minute, 4 * time.Minute, time.Hour})
+}
@@ -0,0 +1,38 @@
+package buildingController
+
+import (
+	"database/sql"
+	"src/db/buildingRepo"
+	"src/objects"
+	appErrors "src/utils/error"
+)
+
+type BuildingController struct {
+	Repo buildingRepo.BuildingRepo
+}
+
+func (bc *BuildingController) AddBuilding(name, address string, floors int) error {
+	if len(name) < 1 || floors < objects.FirstFloor {
+		return appErrors.BadBuildingParamsErr
+	}
+	exist, err := bc.Repo.BuildingNameExists(name)
+	if err == nil && exist {
+		err = appErrors.BuildingAlreadyExistErr
+	}
+	if err == nil {
+		err = bc.Repo.AddBuilding(objects.NewBuildingDTO(name, address, floors))
+	}
+	return err
+}
+
+func (bc *BuildingController) GetBuildings(page, size int) ([]objects.Building, error) {
+	return bc.Repo.GetBuildings(page, size)
+}
+
+func (bc *BuildingController) GetBuilding(id int) (objects.Building, error) {
+	building, err := bc.Repo.GetBuilding(id)
+	if err == sql.ErrNoRows || (err == nil && building.GetID() == objects.None) {
+		err = appErrors.BuildingNotFoundErr
+	}
+	return building, err
+}
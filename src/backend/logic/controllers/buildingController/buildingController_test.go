@@ -0,0 +1,113 @@
+package buildingController
+
+import (
+	"database/sql"
+	"github.com/bloomberg/go-testgroup"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/db/buildingRepo"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	appErrors "src/utils/error"
+	"testing"
+)
+
+const (
+	InsertID     = 5
+	RowsAffected = 1
+)
+
+type TestBuildingController struct{}
+
+func Test_BuildingController(t *testing.T) {
+	testgroup.RunSerially(t, &TestBuildingController{})
+}
+
+func (*TestBuildingController) TestBuildingController_AddBuilding(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.BuildingRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	name := mother.DefaultBuildingName + "4"
+	mock.ExpectQuery("SELECT EXISTS").WithArgs(name).WillReturnError(nil).
+		WillReturnRows(objectMother.CreateExistsRows(false))
+	mock.ExpectExec("INSERT INTO").WithArgs(name, mother.DefaultBuildingAddress, mother.DefaultFloors).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	repo := buildingRepo.PgBuildingRepo{Conn: db}
+	controller := BuildingController{Repo: &repo}
+
+	// Act
+	execErr := controller.AddBuilding(name, mother.DefaultBuildingAddress, mother.DefaultFloors)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+// TestBuildingController_AddBuildingAlreadyExist проверяет, что нельзя добавить два корпуса с одним названием.
+func (*TestBuildingController) TestBuildingController_AddBuildingAlreadyExist(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.BuildingRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	name := mother.DefaultBuildingName + "2"
+	mock.ExpectQuery("SELECT EXISTS").WithArgs(name).WillReturnError(nil).
+		WillReturnRows(objectMother.CreateExistsRows(true))
+	repo := buildingRepo.PgBuildingRepo{Conn: db}
+	controller := BuildingController{Repo: &repo}
+
+	// Act
+	execErr := controller.AddBuilding(name, mother.DefaultBuildingAddress, mother.DefaultFloors)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BuildingAlreadyExistErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestBuildingController_AddBuildingNoFloors проверяет, что корпус без этажей не попадёт в базу.
+func (*TestBuildingController) TestBuildingController_AddBuildingNoFloors(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.BuildingRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	repo := buildingRepo.PgBuildingRepo{Conn: db}
+	controller := BuildingController{Repo: &repo}
+
+	// Act
+	execErr := controller.AddBuilding(mother.DefaultBuildingName, mother.DefaultBuildingAddress, objects.Null)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadBuildingParamsErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestBuildingController_GetBuildingNegative проверяет, что для несуществующего корпуса вернётся ошибка.
+func (*TestBuildingController) TestBuildingController_GetBuildingNegative(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.BuildingRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectQuery("SELECT").WithArgs(InsertID).WillReturnError(sql.ErrNoRows)
+	repo := buildingRepo.PgBuildingRepo{Conn: db}
+	controller := BuildingController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.GetBuilding(InsertID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BuildingNotFoundErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestBuildingController_GetBuildingDBError проверяет, что ошибка базы не выдаётся за отсутствие корпуса.
+func (*TestBuildingController) TestBuildingController_GetBuildingDBError(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.BuildingRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectQuery("SELECT").WithArgs(InsertID).WillReturnError(sql.ErrConnDone)
+	repo := buildingRepo.PgBuildingRepo{Conn: db}
+	controller := BuildingController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.GetBuilding(InsertID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrConnDone)
+	tests.AssertMocks(t, mock)
+}
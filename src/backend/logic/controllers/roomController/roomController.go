@@ -1,6 +1,7 @@
 package roomController
 
 import (
+	"database/sql"
 	"src/db/roomRepo"
 	"src/objects"
 	appErrors "src/utils/error"
@@ -10,14 +11,56 @@ type RoomController struct {
 	Repo roomRepo.RoomRepo
 }
 
-func (rc *RoomController) AddRoom(roomType string, number int) error {
-	roomDTO := objects.NewRoomDTO(roomType, number)
-	err := rc.Repo.AddRoom(roomDTO)
+// AddRoom expects that the building and floor of the room are checked by caller.
+func (rc *RoomController) AddRoom(roomDTO objects.RoomDTO) error {
+	err := rc.checkRoomParams(objects.None, roomDTO)
+	if err == nil {
+		err = rc.Repo.AddRoom(roomDTO)
+	}
+	return err
+}
+
+// ChangeRoom replaces the params of existing room. Capacity can't be less than number of students living there,
+// the new gender must allow all of them. Residents are read by caller when the gender of an occupied room changes.
+func (rc *RoomController) ChangeRoom(room objects.Room, roomDTO objects.RoomDTO, residents []objects.Student) error {
+	err := rc.checkRoomParams(room.GetID(), roomDTO)
+	if err == nil {
+		if roomDTO.GetCapacity() < room.GetOccupied() {
+			err = appErrors.RoomCapacityErr
+		} else if !allowsResidents(roomDTO.GetGender(), residents) {
+			err = appErrors.RoomGenderErr
+		} else {
+			err = rc.Repo.UpdateRoom(room.GetID(), roomDTO)
+		}
+	}
+	return err
+}
+
+func allowsResidents(gender objects.Gender, residents []objects.Student) bool {
+	room := objects.NewRoomWithParams(objects.None, objects.EmptyString, objects.Null, objects.Null, gender)
+	for _, resident := range residents {
+		if !room.AllowsGender(resident.GetGender()) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkRoomParams checks room params and that no other room in the building has the same number.
+func (rc *RoomController) checkRoomParams(id int, roomDTO objects.RoomDTO) error {
+	if len(roomDTO.GetRoomType()) < 1 || roomDTO.GetRoomNumber() < 0 || roomDTO.GetCapacity() < 0 ||
+		!roomDTO.GetGender().IsValid() {
+		return appErrors.BadRoomParamsErr
+	}
+	exist, err := rc.Repo.RoomNumberExists(roomDTO.GetBuildingID(), roomDTO.GetRoomNumber(), id)
+	if err == nil && exist {
+		err = appErrors.RoomAlreadyExistErr
+	}
 	return err
 }
 
-func (rc *RoomController) GetRooms(page, size int) ([]objects.Room, error) {
-	return rc.Repo.GetRooms(page, size)
+func (rc *RoomController) GetRooms(filter objects.RoomFilter, page, size int) ([]objects.Room, error) {
+	return rc.Repo.GetRooms(filter, page, size)
 }
 
 func (rc *RoomController) GetRoom(id int) (objects.Room, error) {
@@ -28,12 +71,31 @@ func (rc *RoomController) GetRoom(id int) (objects.Room, error) {
 	return tmpRoom, err
 }
 
+// DeleteRoom deletes only empty rooms which never took part in settling or moving things,
+// otherwise the history would lose its rooms.
 func (rc *RoomController) DeleteRoom(id int) error {
 	tmpRoom, err := rc.Repo.GetRoom(id)
-	if err == nil && tmpRoom.GetID() > objects.None {
+	if err == sql.ErrNoRows || (err == nil && tmpRoom.GetID() == objects.None) {
+		return appErrors.RoomNotFoundErr
+	} else if err != nil {
+		return err
+	}
+	if tmpRoom.GetOccupied() > objects.Null {
+		return appErrors.RoomHasStudentsErr
+	}
+	things, err := rc.Repo.GetRoomThings(id)
+	if err == nil && len(things) > objects.Empty {
+		err = appErrors.RoomHasThingsErr
+	}
+	if err == nil {
+		var historyCount int
+		historyCount, err = rc.Repo.GetRoomHistoryCount(id)
+		if err == nil && historyCount > objects.Null {
+			err = appErrors.RoomHasHistoryErr
+		}
+	}
+	if err == nil {
 		err = rc.Repo.DeleteRoom(id)
-	} else {
-		err = appErrors.RoomNotFoundErr
 	}
 	return err
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/bloomberg/go-testgroup"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
 	"src/db/roomRepo"
+	"src/objects"
 	"src/tests"
 	"src/tests/mother"
 	appErrors "src/utils/error"
@@ -28,19 +29,150 @@ func (*TestRoomController) TestRoomController_AddRoom(t *testgroup.T) {
 	// Arrange
 	objectMother := mother.RoomRepoObjectMother{}
 	db, mock := objectMother.CreateRepo()
-	mock.ExpectExec("INSERT INTO").WithArgs(DefaultRoomType, DefaultRoomNumber).
+	roomDTO := objects.NewRoomDTO(DefaultRoomType, DefaultRoomNumber, mother.DefaultCapacity, objects.GenderAny)
+	roomDTO.SetLocation(mother.DefaultBuildingID, mother.DefaultFloor, objects.EmptyString)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultBuildingID, DefaultRoomNumber, objects.None).
+		WillReturnError(nil).WillReturnRows(objectMother.CreateExistsRows(false))
+	mock.ExpectExec("INSERT INTO").WithArgs(DefaultRoomType, DefaultRoomNumber, mother.DefaultCapacity,
+		objects.GenderAny, mother.DefaultBuildingID, mother.DefaultFloor, objects.EmptyString).
 		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
 	repo := roomRepo.PgRoomRepo{Conn: db}
 	controller := RoomController{Repo: &repo}
 
 	// Act
-	execErr := controller.AddRoom(DefaultRoomType, DefaultRoomNumber)
+	execErr := controller.AddRoom(roomDTO)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
 	tests.AssertMocks(t, mock)
 }
 
+// TestRoomController_AddRoomAlreadyExist проверяет, что в корпусе нельзя добавить вторую комнату с тем же номером.
+func (*TestRoomController) TestRoomController_AddRoomAlreadyExist(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.RoomRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	roomDTO := objects.NewRoomDTO(DefaultRoomType, DefaultRoomNumber, mother.DefaultCapacity, objects.GenderAny)
+	roomDTO.SetLocation(mother.DefaultBuildingID, mother.DefaultFloor, objects.EmptyString)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultBuildingID, DefaultRoomNumber, objects.None).
+		WillReturnError(nil).WillReturnRows(objectMother.CreateExistsRows(true))
+	repo := roomRepo.PgRoomRepo{Conn: db}
+	controller := RoomController{Repo: &repo}
+
+	// Act
+	execErr := controller.AddRoom(roomDTO)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.RoomAlreadyExistErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestRoomController_AddRoomBadParams проверяет, что комната с неизвестным полом не попадёт в базу.
+func (*TestRoomController) TestRoomController_AddRoomBadParams(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.RoomRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	repo := roomRepo.PgRoomRepo{Conn: db}
+	controller := RoomController{Repo: &repo}
+
+	// Act
+	execErr := controller.AddRoom(objects.NewRoomDTO(DefaultRoomType, DefaultRoomNumber, mother.DefaultCapacity,
+		"unknown"))
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadRoomParamsErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestRoomController_ChangeRoomSameNumber проверяет, что комната может сохранить свой номер.
+func (*TestRoomController) TestRoomController_ChangeRoomSameNumber(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.RoomRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	room := objectMother.CreateRoom(DefaultRoomNumber, 2, objects.GenderAny)
+	roomDTO := objects.NewRoomDTO(mother.Type, DefaultRoomNumber, 2, objects.GenderMale)
+	roomDTO.SetLocation(mother.DefaultBuildingID, mother.DefaultFloor, objects.EmptyString)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultBuildingID, DefaultRoomNumber, room.GetID()).
+		WillReturnError(nil).WillReturnRows(objectMother.CreateExistsRows(false))
+	mock.ExpectExec("UPDATE").WithArgs(room.GetID(), mother.Type, DefaultRoomNumber, 2, objects.GenderMale,
+		mother.DefaultBuildingID, mother.DefaultFloor, objects.EmptyString).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	repo := roomRepo.PgRoomRepo{Conn: db}
+	controller := RoomController{Repo: &repo}
+
+	// Act
+	execErr := controller.ChangeRoom(room, roomDTO, nil)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+// TestRoomController_ChangeRoomAlreadyExist проверяет, что нельзя занять номер другой комнаты корпуса.
+func (*TestRoomController) TestRoomController_ChangeRoomAlreadyExist(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.RoomRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	room := objectMother.CreateRoom(DefaultRoomNumber, objects.Null, objects.GenderAny)
+	roomDTO := objects.NewRoomDTO(mother.Type, 2, mother.DefaultCapacity, objects.GenderAny)
+	roomDTO.SetLocation(mother.DefaultBuildingID, mother.DefaultFloor, objects.EmptyString)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultBuildingID, 2, room.GetID()).
+		WillReturnError(nil).WillReturnRows(objectMother.CreateExistsRows(true))
+	repo := roomRepo.PgRoomRepo{Conn: db}
+	controller := RoomController{Repo: &repo}
+
+	// Act
+	execErr := controller.ChangeRoom(room, roomDTO, nil)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.RoomAlreadyExistErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestRoomController_ChangeRoomCapacity проверяет, что вместимость нельзя сделать меньше числа проживающих.
+func (*TestRoomController) TestRoomController_ChangeRoomCapacity(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.RoomRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	room := objectMother.CreateRoom(DefaultRoomNumber, 2, objects.GenderAny)
+	roomDTO := objects.NewRoomDTO(mother.Type, DefaultRoomNumber, 1, objects.GenderAny)
+	roomDTO.SetLocation(mother.DefaultBuildingID, mother.DefaultFloor, objects.EmptyString)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultBuildingID, DefaultRoomNumber, room.GetID()).
+		WillReturnError(nil).WillReturnRows(objectMother.CreateExistsRows(false))
+	repo := roomRepo.PgRoomRepo{Conn: db}
+	controller := RoomController{Repo: &repo}
+
+	// Act
+	execErr := controller.ChangeRoom(room, roomDTO, nil)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.RoomCapacityErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestRoomController_ChangeRoomGender проверяет, что комнату нельзя закрепить за полом, не подходящим жильцам.
+func (*TestRoomController) TestRoomController_ChangeRoomGender(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.RoomRepoObjectMother{}
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	room := objectMother.CreateRoom(DefaultRoomNumber, 1, objects.GenderMale)
+	roomDTO := objects.NewRoomDTO(mother.Type, DefaultRoomNumber, mother.DefaultCapacity, objects.GenderFemale)
+	roomDTO.SetLocation(mother.DefaultBuildingID, mother.DefaultFloor, objects.EmptyString)
+	residents := studentObjectMother.CreateDefaultStudents(1)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultBuildingID, DefaultRoomNumber, room.GetID()).
+		WillReturnError(nil).WillReturnRows(objectMother.CreateExistsRows(false))
+	repo := roomRepo.PgRoomRepo{Conn: db}
+	controller := RoomController{Repo: &repo}
+
+	// Act
+	execErr := controller.ChangeRoom(room, roomDTO, residents)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.RoomGenderErr)
+	tests.AssertMocks(t, mock)
+}
+
 func (*TestRoomController) TestRoomController_DeleteRoomPositive(t *testgroup.T) {
 	// Arrange
 	objectMother := mother.RoomRepoObjectMother{}
@@ -49,7 +181,11 @@ func (*TestRoomController) TestRoomController_DeleteRoomPositive(t *testgroup.T)
 	N := 1
 	realRooms := objectMother.CreateDefaultRooms(N)
 	rows := objectMother.CreateRows(realRooms)
+	thingRows := mother.ThingRepoObjectMother{}.CreateRows(nil)
 	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(thingRows)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(objects.Null))
 	mock.ExpectExec("DELETE").WithArgs(ID).WillReturnError(nil).
 		WillReturnResult(sqlmock.NewResult(int64(ID), RowsAffected))
 	repo := roomRepo.PgRoomRepo{Conn: db}
@@ -62,6 +198,67 @@ func (*TestRoomController) TestRoomController_DeleteRoomPositive(t *testgroup.T)
 	tests.AssertMocks(t, mock)
 }
 
+// TestRoomController_DeleteRoomWithStudents проверяет, что комнату с проживающими удалить нельзя.
+func (*TestRoomController) TestRoomController_DeleteRoomWithStudents(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.RoomRepoObjectMother{}
+	ID := 1
+	db, mock := objectMother.CreateRepo()
+	rows := objectMother.CreateRows([]objects.Room{objectMother.CreateRoom(ID, 1, objects.GenderAny)})
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(rows)
+	repo := roomRepo.PgRoomRepo{Conn: db}
+	controller := RoomController{Repo: &repo}
+
+	// Act
+	execErr := controller.DeleteRoom(ID)
+
+	tests.AssertErrors(t, execErr, appErrors.RoomHasStudentsErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestRoomController_DeleteRoomWithThings проверяет, что комнату, где находятся вещи, удалить нельзя.
+func (*TestRoomController) TestRoomController_DeleteRoomWithThings(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.RoomRepoObjectMother{}
+	thingObjectMother := mother.ThingRepoObjectMother{}
+	ID := 1
+	db, mock := objectMother.CreateRepo()
+	rows := objectMother.CreateRows(objectMother.CreateDefaultRooms(1))
+	thingRows := thingObjectMother.CreateRows(thingObjectMother.CreateDefaultThings(1))
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(thingRows)
+	repo := roomRepo.PgRoomRepo{Conn: db}
+	controller := RoomController{Repo: &repo}
+
+	// Act
+	execErr := controller.DeleteRoom(ID)
+
+	tests.AssertErrors(t, execErr, appErrors.RoomHasThingsErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestRoomController_DeleteRoomWithHistory проверяет, что комнату из истории перемещений удалить нельзя.
+func (*TestRoomController) TestRoomController_DeleteRoomWithHistory(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.RoomRepoObjectMother{}
+	ID := 1
+	db, mock := objectMother.CreateRepo()
+	rows := objectMother.CreateRows(objectMother.CreateDefaultRooms(1))
+	thingRows := mother.ThingRepoObjectMother{}.CreateRows(nil)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(thingRows)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	repo := roomRepo.PgRoomRepo{Conn: db}
+	controller := RoomController{Repo: &repo}
+
+	// Act
+	execErr := controller.DeleteRoom(ID)
+
+	tests.AssertErrors(t, execErr, appErrors.RoomHasHistoryErr)
+	tests.AssertMocks(t, mock)
+}
+
 // TestRoomController_DeleteRoomNegative проверяет, что если комнаты не существует, то удаления не произойдет.
 func (*TestRoomController) TestRoomController_DeleteRoomNegative(t *testgroup.T) {
 	// Arrange
@@ -79,6 +276,23 @@ func (*TestRoomController) TestRoomController_DeleteRoomNegative(t *testgroup.T)
 	tests.AssertMocks(t, mock)
 }
 
+// TestRoomController_DeleteRoomDBError проверяет, что ошибка базы не выдаётся за отсутствие комнаты.
+func (*TestRoomController) TestRoomController_DeleteRoomDBError(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.RoomRepoObjectMother{}
+	ID := 1
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(sql.ErrConnDone)
+	repo := roomRepo.PgRoomRepo{Conn: db}
+	controller := RoomController{Repo: &repo}
+
+	// Act
+	execErr := controller.DeleteRoom(ID)
+
+	tests.AssertErrors(t, execErr, sql.ErrConnDone)
+	tests.AssertMocks(t, mock)
+}
+
 func (*TestRoomController) TestRoomController_GetRoomPositive(t *testgroup.T) {
 	// Arrange
 	objectMother := mother.RoomRepoObjectMother{}
@@ -127,7 +341,7 @@ func (*TestRoomController) TestRoomController_GetRooms(t *testgroup.T) {
 	controller := RoomController{Repo: &repo}
 
 	// Act
-	resultRooms, execErr := controller.GetRooms()
+	resultRooms, execErr := controller.GetRooms(objects.NewEmptyRoomFilter(), objects.Null, objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
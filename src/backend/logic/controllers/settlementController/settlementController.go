@@ -0,0 +1,114 @@
+package settlementController
+
+import (
+	"database/sql"
+	"src/db/settlementRepo"
+	"src/logic/controllers/studentController"
+	"src/objects"
+	appErrors "src/utils/error"
+)
+
+type SettlementController struct {
+	Repo settlementRepo.SettlementRepo
+}
+
+func checkPreferences(preferences objects.SettlementPreferences) error {
+	if preferences.GetBuildingID() < objects.None || preferences.GetRoomID() < objects.None ||
+		(preferences.GetFloor() != objects.None && preferences.GetFloor() < objects.FirstFloor) {
+		return appErrors.BadSettlementParamsErr
+	}
+	return nil
+}
+
+// AddRequest puts the student into the waiting list, a student may have only one pending request.
+func (sc *SettlementController) AddRequest(studentID int, preferences objects.SettlementPreferences,
+	priority, createdBy int) (int, error) {
+	if studentID < objects.Null || priority < objects.Null {
+		return objects.None, appErrors.BadSettlementParamsErr
+	}
+	if err := checkPreferences(preferences); err != nil {
+		return objects.None, err
+	}
+
+	requests, err := sc.Repo.GetStudentRequests(studentID)
+	if err != nil {
+		return objects.None, err
+	}
+	for _, request := range requests {
+		if request.IsPending() {
+			return objects.None, appErrors.SettlementExistsErr
+		}
+	}
+	return sc.Repo.AddRequest(studentID, preferences, priority, createdBy)
+}
+
+func (sc *SettlementController) GetRequest(id int) (objects.SettlementRequest, error) {
+	request, err := sc.Repo.GetRequest(id)
+	if err == sql.ErrNoRows || (err == nil && request.GetID() == objects.None) {
+		err = appErrors.SettlementNotFoundErr
+	}
+	return request, err
+}
+
+func (sc *SettlementController) GetRequests(status objects.SettlementStatus,
+	page, size int) ([]objects.SettlementRequest, error) {
+	if !status.IsValid() {
+		return make([]objects.SettlementRequest, objects.Empty), appErrors.BadSettlementParamsErr
+	}
+	return sc.Repo.GetRequests(status, page, size)
+}
+
+func (sc *SettlementController) GetStudentRequests(studentID int) ([]objects.SettlementRequest, error) {
+	return sc.Repo.GetStudentRequests(studentID)
+}
+
+func (sc *SettlementController) GetRequestHistory(id int) ([]objects.SettlementStatusChange, error) {
+	return sc.Repo.GetRequestHistory(id)
+}
+
+// CloseRequest moves a pending request to one of the final statuses.
+func (sc *SettlementController) CloseRequest(request objects.SettlementRequest, status objects.SettlementStatus,
+	changedBy int, comment string) error {
+	if !status.IsValid() || status == objects.SettlementPending {
+		return appErrors.BadSettlementParamsErr
+	}
+	if !request.IsPending() {
+		return appErrors.SettlementClosedErr
+	}
+
+	err := sc.Repo.ChangeRequestStatus(request.GetID(), status, changedBy, comment)
+	if err == sql.ErrNoRows {
+		err = appErrors.SettlementClosedErr
+	}
+	return err
+}
+
+// ApproveRequest settles the student into the room and closes the request at once, the room is checked
+// as for any settle act.
+func (sc *SettlementController) ApproveRequest(request objects.SettlementRequest, roomID, changedBy int,
+	comment string) error {
+	if !request.IsPending() {
+		return appErrors.SettlementClosedErr
+	}
+
+	err := sc.Repo.ApproveRequest(request.GetID(), roomID, changedBy, comment, studentController.CheckSettle)
+	if err == sql.ErrNoRows {
+		err = appErrors.SettlementClosedErr
+	}
+	return err
+}
+
+func (sc *SettlementController) ChangeRequestPriority(request objects.SettlementRequest, priority int) error {
+	if priority < objects.Null {
+		return appErrors.BadSettlementParamsErr
+	}
+	if !request.IsPending() {
+		return appErrors.SettlementClosedErr
+	}
+
+	err := sc.Repo.ChangeRequestPriority(request.GetID(), priority)
+	if err == sql.ErrNoRows {
+		err = appErrors.SettlementClosedErr
+	}
+	return err
+}
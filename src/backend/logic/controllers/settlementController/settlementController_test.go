@@ -0,0 +1,220 @@
+package settlementController
+
+import (
+	"database/sql"
+	"github.com/bloomberg/go-testgroup"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/db/settlementRepo"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	appErrors "src/utils/error"
+	"testing"
+)
+
+const (
+	InsertID     = 5
+	RowsAffected = 1
+)
+
+type TestSettlementController struct{}
+
+func Test_SettlementController(t *testing.T) {
+	testgroup.RunSerially(t, &TestSettlementController{})
+}
+
+func (*TestSettlementController) TestSettlementController_AddRequest(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	studentID := 1
+	preferences := objectMother.CreatePreferences()
+	oldRequests := objectMother.CreateDefaultRequests(1, objects.SettlementRejected)
+	mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnRows(objectMother.CreateRows(oldRequests))
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(InsertID))
+	mock.ExpectExec("INSERT INTO").WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
+	repo := settlementRepo.PgSettlementRepo{Conn: db}
+	controller := SettlementController{Repo: &repo}
+
+	// Act
+	id, execErr := controller.AddRequest(studentID, preferences, mother.DefaultPriority, mother.DefaultStaffID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, id, InsertID)
+}
+
+// TestSettlementController_AddRequestAlreadyPending проверяет, что у студента не может быть двух заявок в очереди.
+func (*TestSettlementController) TestSettlementController_AddRequestAlreadyPending(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	studentID := 1
+	oldRequests := objectMother.CreateDefaultRequests(1, objects.SettlementPending)
+	mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnRows(objectMother.CreateRows(oldRequests))
+	repo := settlementRepo.PgSettlementRepo{Conn: db}
+	controller := SettlementController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.AddRequest(studentID, objectMother.CreatePreferences(), mother.DefaultPriority,
+		mother.DefaultStaffID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.SettlementExistsErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestSettlementController_AddRequestBadFloor проверяет, что заявка с несуществующим номером этажа не попадёт в базу.
+func (*TestSettlementController) TestSettlementController_AddRequestBadFloor(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	preferences := objects.NewSettlementPreferences(mother.DefaultBuildingID, objects.Null, objects.None,
+		objects.EmptyString)
+	repo := settlementRepo.PgSettlementRepo{Conn: db}
+	controller := SettlementController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.AddRequest(1, preferences, mother.DefaultPriority, mother.DefaultStaffID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadSettlementParamsErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestSettlementController_GetRequestNotFound проверяет, что для несуществующей заявки вернётся ошибка.
+func (*TestSettlementController) TestSettlementController_GetRequestNotFound(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	id := 10
+	mock.ExpectQuery("SELECT").WithArgs(id).WillReturnRows(objectMother.CreateRows(nil))
+	repo := settlementRepo.PgSettlementRepo{Conn: db}
+	controller := SettlementController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.GetRequest(id)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.SettlementNotFoundErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestSettlementController_GetRequestsBadStatus проверяет, что неизвестный статус не уходит в базу.
+func (*TestSettlementController) TestSettlementController_GetRequestsBadStatus(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	repo := settlementRepo.PgSettlementRepo{Conn: db}
+	controller := SettlementController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.GetRequests("waiting", objects.Null, objects.Null)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadSettlementParamsErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestSettlementController) TestSettlementController_CloseRequest(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	request := objectMother.CreateDefaultRequests(1, objects.SettlementPending)[0]
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE").WithArgs(request.GetID(), objects.SettlementRejected).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
+	repo := settlementRepo.PgSettlementRepo{Conn: db}
+	controller := SettlementController{Repo: &repo}
+
+	// Act
+	execErr := controller.CloseRequest(request, objects.SettlementRejected, mother.DefaultStaffID,
+		objects.EmptyString)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+// TestSettlementController_CloseRequestAlreadyClosed проверяет, что рассмотренную заявку нельзя закрыть повторно.
+func (*TestSettlementController) TestSettlementController_CloseRequestAlreadyClosed(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	request := objectMother.CreateDefaultRequests(1, objects.SettlementApproved)[0]
+	repo := settlementRepo.PgSettlementRepo{Conn: db}
+	controller := SettlementController{Repo: &repo}
+
+	// Act
+	execErr := controller.CloseRequest(request, objects.SettlementCancelled, mother.DefaultStaffID,
+		objects.EmptyString)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.SettlementClosedErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestSettlementController_CloseRequestClosedConcurrently проверяет, что заявку, закрытую другим сотрудником
+// после чтения, нельзя закрыть ещё раз.
+func (*TestSettlementController) TestSettlementController_CloseRequestClosedConcurrently(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	request := objectMother.CreateDefaultRequests(1, objects.SettlementPending)[0]
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(InsertID, objects.Null))
+	mock.ExpectRollback()
+	repo := settlementRepo.PgSettlementRepo{Conn: db}
+	controller := SettlementController{Repo: &repo}
+
+	// Act
+	execErr := controller.CloseRequest(request, objects.SettlementRejected, mother.DefaultStaffID,
+		objects.EmptyString)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.SettlementClosedErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestSettlementController_ApproveRequestClosedConcurrently проверяет, что по заявке, закрытой после чтения,
+// студента не заселят.
+func (*TestSettlementController) TestSettlementController_ApproveRequestClosedConcurrently(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	request := objectMother.CreateDefaultRequests(1, objects.SettlementPending)[0]
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT").WithArgs(request.GetID()).WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+	repo := settlementRepo.PgSettlementRepo{Conn: db}
+	controller := SettlementController{Repo: &repo}
+
+	// Act
+	execErr := controller.ApproveRequest(request, 1, mother.DefaultStaffID, objects.EmptyString)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.SettlementClosedErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestSettlementController_ChangeRequestPriorityNegative проверяет, что приоритет не может быть отрицательным.
+func (*TestSettlementController) TestSettlementController_ChangeRequestPriorityNegative(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.SettlementRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	request := objectMother.CreateDefaultRequests(1, objects.SettlementPending)[0]
+	repo := settlementRepo.PgSettlementRepo{Conn: db}
+	controller := SettlementController{Repo: &repo}
+
+	// Act
+	execErr := controller.ChangeRequestPriority(request, objects.None)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadSettlementParamsErr)
+	tests.AssertMocks(t, mock)
+}
@@ -5,6 +5,7 @@ import (
 	"src/db/studentRepo"
 	"src/objects"
 	appErrors "src/utils/error"
+	"time"
 )
 
 type StudentController struct {
@@ -15,12 +16,15 @@ func CreateNewStudentController(Repo studentRepo.StudentRepo) *StudentController
 	return &StudentController{Repo: Repo}
 }
 
-func (sc *StudentController) AddStudent(name, surname, group, studentNumber string, accID int) error {
+func (sc *StudentController) AddStudent(name, surname, group, studentNumber string, gender objects.Gender,
+	accID int) error {
 	var err error
 	if accID < 0 {
 		err = appErrors.BadAccIDErr
 	} else if len(name) < 1 || len(surname) < 1 || len(group) < 1 || len(studentNumber) < 1 {
 		err = appErrors.BadStudentParamsErr
+	} else if !gender.IsValid() {
+		err = appErrors.BadStudentParamsErr
 	} else {
 		allStudents, getStudentErr := sc.Repo.GetAllStudents(objects.Null, objects.Null)
 		if getStudentErr == nil {
@@ -31,7 +35,7 @@ func (sc *StudentController) AddStudent(name, surname, group, studentNumber stri
 				}
 			}
 			if err == nil {
-				studentDTO := objects.NewStudentDTO(name, surname, group, studentNumber)
+				studentDTO := objects.NewStudentDTO(name, surname, group, studentNumber, gender)
 				err = sc.Repo.AddStudent(studentDTO, accID)
 			}
 		}
@@ -44,21 +48,21 @@ func (sc *StudentController) GetAllStudents(page, size int) ([]objects.Student,
 	return sc.Repo.GetAllStudents(page, size)
 }
 
-func (sc *StudentController) GetStudentIDByNumber(studentNumber string) (int, error) {
-	var result = objects.None
-	allStudents, err := sc.Repo.GetAllStudents(objects.Null, objects.Null)
-	if err == nil {
-		for _, tmpStudent := range allStudents {
-			if tmpStudent.GetStudentNumber() == studentNumber {
-				result = tmpStudent.GetID()
-				break
-			}
-		}
-	}
-	if result == objects.None {
-		err = appErrors.StudentNotFoundErr
+// FindStudents returns a page of students matching the filter and the number of them on all pages.
+func (sc *StudentController) FindStudents(filter objects.StudentFilter, page, size int) ([]objects.Student, int, error) {
+	students, err := sc.Repo.FindStudents(filter, page, size)
+	if err != nil {
+		return students, objects.Empty, err
 	}
+	total, err := sc.Repo.CountStudents(filter)
+	return students, total, err
+}
 
+func (sc *StudentController) GetStudentIDByNumber(studentNumber string) (int, error) {
+	result, err := sc.Repo.GetStudentID(studentNumber)
+	if err == sql.ErrNoRows {
+		result, err = objects.None, appErrors.StudentNotFoundErr
+	}
 	return result, err
 }
 
@@ -76,6 +80,10 @@ func (sc *StudentController) GetStudent(id int) (objects.Student, error) {
 	return student, err
 }
 
+func (sc *StudentController) GetRoomStudents(roomID int) ([]objects.Student, error) {
+	return sc.Repo.GetRoomStudents(roomID)
+}
+
 func (sc *StudentController) GetStudentRoom(id int) (int, error) {
 	var result = objects.None
 	allStudents, err := sc.Repo.GetAllStudents(objects.Null, objects.Null)
@@ -93,41 +101,96 @@ func (sc *StudentController) GetStudentRoom(id int) (int, error) {
 	return result, err
 }
 
-func (sc *StudentController) SettleStudent(studentID, roomID int) error {
-	student, err := sc.Repo.GetStudent(studentID)
-	if err == nil {
-		if student.GetID() == objects.None {
-			err = appErrors.StudentNotFoundErr
-		} else if student.GetRoomID() == objects.NotLiving {
-			err = sc.Repo.TransferStudent(studentID, roomID, objects.Get)
-		} else {
-			err = appErrors.StudentAlreadyLiveErr
-		}
-	} else if err == sql.ErrNoRows {
+// checkRoomForStudent checks that the room has a free bed and is not reserved for the other gender.
+// A student with unknown gender may be settled into any room.
+func checkRoomForStudent(student objects.Student, room objects.Room) error {
+	var err error
+	if room.GetID() == objects.None {
+		err = appErrors.RoomNotFoundErr
+	} else if room.GetCapacity() == objects.Null {
+		err = appErrors.RoomNotForLivingErr
+	} else if room.GetFreeBeds() == objects.Null {
+		err = appErrors.RoomIsFullErr
+	} else if !room.AllowsGender(student.GetGender()) {
+		err = appErrors.RoomGenderErr
+	}
+	return err
+}
+
+// CheckSettle is the check of a settle act, the repo calls it with the student and the room locked.
+func CheckSettle(student objects.Student, room objects.Room) error {
+	var err error
+	if student.GetID() == objects.None {
 		err = appErrors.StudentNotFoundErr
+	} else if !student.IsActive() {
+		err = appErrors.StudentNotActiveErr
+	} else if student.GetRoomID() != objects.NotLiving {
+		err = appErrors.StudentAlreadyLiveErr
+	} else {
+		err = checkRoomForStudent(student, room)
 	}
 	return err
 }
 
-func (sc *StudentController) EvicStudent(studentID int) error {
-	student, err := sc.Repo.GetStudent(studentID)
-	if err == nil {
-		if student.GetRoomID() != objects.NotLiving {
-			err = sc.Repo.TransferStudent(studentID, student.GetRoomID(), objects.Ret)
-		} else {
-			err = appErrors.StudentNotLivingErr
-		}
-	} else if err == sql.ErrNoRows {
+func checkRelocate(student objects.Student, room objects.Room) error {
+	var err error
+	if student.GetID() == objects.None {
 		err = appErrors.StudentNotFoundErr
+	} else if student.GetRoomID() == objects.NotLiving {
+		err = appErrors.StudentNotLivingErr
+	} else if student.GetRoomID() == room.GetID() {
+		err = appErrors.BadDstRoomErr
+	} else {
+		err = checkRoomForStudent(student, room)
 	}
 	return err
 }
 
+// SettleStudent checks the student and the room inside the settle transaction, so concurrent settlements
+// can't fill the room over its capacity.
+func (sc *StudentController) SettleStudent(studentID, roomID, performedBy int) error {
+	return sc.Repo.SettleStudent(studentID, roomID, performedBy, CheckSettle)
+}
+
+// RelocateStudent moves a living student to another room. Both acts are written at once,
+// so the student is never left without a room.
+func (sc *StudentController) RelocateStudent(studentID, roomID, performedBy int) error {
+	return sc.Repo.RelocateStudent(studentID, roomID, performedBy, checkRelocate)
+}
+
+// EvicStudent moves the student out of the room. The final departure is refused while the student
+// has unreturned things or unpaid fees.
+func (sc *StudentController) EvicStudent(studentID, performedBy int, final bool) error {
+	return sc.Repo.EvicStudent(studentID, performedBy, func(student objects.Student, things, fees int) error {
+		return checkEvict(student, things, fees, final)
+	})
+}
+
+func checkEvict(student objects.Student, things, fees int, final bool) error {
+	if student.GetID() == objects.None {
+		return appErrors.StudentNotFoundErr
+	} else if final && (things != objects.Empty || fees != objects.Empty) {
+		return appErrors.StudentClearanceErr
+	} else if student.GetRoomID() == objects.NotLiving {
+		return appErrors.StudentNotLivingErr
+	}
+	return nil
+}
+
+func (sc *StudentController) GetLivingHistory(studentID int, from, to time.Time,
+	page, size int) ([]objects.LivingRecord, error) {
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return make([]objects.LivingRecord, objects.Empty), appErrors.WrongRequestParamsErr
+	}
+	return sc.Repo.GetStudentLivingHistory(studentID, from, to, page, size)
+}
+
 func (sc *StudentController) ChangeStudentGroup(studentID int, newGroup string) error {
 	student, err := sc.Repo.GetStudent(studentID)
 	if err == nil {
 		studentDTO := objects.NewStudentDTO(student.GetName(), student.GetSurname(),
-			newGroup, student.GetStudentNumber())
+			newGroup, student.GetStudentNumber(), student.GetGender())
+		studentDTO.SetProfile(student.GetProfile())
 		err = sc.Repo.ChangeStudent(studentID, studentDTO)
 	} else if err == sql.ErrNoRows {
 		err = appErrors.StudentNotFoundErr
@@ -135,6 +198,56 @@ func (sc *StudentController) ChangeStudentGroup(studentID int, newGroup string)
 	return err
 }
 
+// ChangeStudent replaces the stored data of the student. The student number must stay unique.
+func (sc *StudentController) ChangeStudent(student objects.Student, studentInfo objects.StudentDTO) error {
+	var err error
+	if studentInfo.GetStudentNumber() != student.GetStudentNumber() {
+		allStudents, getStudentErr := sc.Repo.GetAllStudents(objects.Null, objects.Null)
+		if getStudentErr != nil {
+			return getStudentErr
+		}
+		for _, tmpStudent := range allStudents {
+			if tmpStudent.GetStudentNumber() == studentInfo.GetStudentNumber() {
+				err = appErrors.StudentAlreadyInBaseErr
+				break
+			}
+		}
+	}
+	if err == nil {
+		err = sc.Repo.ChangeStudent(student.GetID(), studentInfo)
+	}
+	return err
+}
+
+// ChangeStudentStatus moves the student to the status if the lifecycle allows it. To leave the active
+// status the student must be evicted and return all things. The user account is disabled for
+// a student who is not active.
+func (sc *StudentController) ChangeStudentStatus(studentID int, status objects.StudentStatus) error {
+	if !status.IsValid() {
+		return appErrors.BadStudentParamsErr
+	}
+	return sc.Repo.ChangeStudentStatus(studentID, status, func(student objects.Student, things int) error {
+		return checkStatusChange(student, things, status)
+	})
+}
+
+func checkStatusChange(student objects.Student, things int, status objects.StudentStatus) error {
+	currentStatus := student.GetStatus()
+	if student.GetID() == objects.None {
+		return appErrors.StudentNotFoundErr
+	} else if !currentStatus.CanChangeTo(status) {
+		return appErrors.StudentStatusErr
+	}
+	if student.IsActive() {
+		if student.GetRoomID() != objects.NotLiving {
+			return appErrors.StudentIsLivingErr
+		} else if things != objects.Empty {
+			return appErrors.StudentHasThingsErr
+		}
+	}
+	return nil
+}
+
 func (sc *StudentController) GetStudentThings(studentID int, page, size int) ([]objects.Thing, error) {
 	studentThings := make([]objects.Thing, 0)
 	_, err := sc.Repo.GetStudent(studentID)
@@ -146,14 +259,30 @@ func (sc *StudentController) GetStudentThings(studentID int, page, size int) ([]
 	return studentThings, err
 }
 
+// AddStudentAccounts adds students with their users at once, the rows must be checked beforehand.
+func (sc *StudentController) AddStudentAccounts(accounts []objects.StudentAccount) error {
+	if len(accounts) == objects.Empty {
+		return nil
+	}
+	return sc.Repo.AddStudentAccounts(accounts)
+}
+
+func (sc *StudentController) GetStudentFees(studentID int) ([]objects.Fee, error) {
+	return sc.Repo.GetStudentFees(studentID)
+}
+
 func (sc *StudentController) TransferThing(studentID, thingID int) error {
-	_, err := sc.Repo.GetStudent(studentID)
-	if err == nil {
-		err = sc.Repo.TransferThing(studentID, thingID, objects.Get)
-	} else if err == sql.ErrNoRows {
-		err = appErrors.StudentNotFoundErr
+	return sc.Repo.GiveThing(studentID, thingID, checkActive)
+}
+
+// checkActive is the check of giving a thing, only active students get things.
+func checkActive(student objects.Student) error {
+	if student.GetID() == objects.None {
+		return appErrors.StudentNotFoundErr
+	} else if !student.IsActive() {
+		return appErrors.StudentNotActiveErr
 	}
-	return err
+	return nil
 }
 
 func (sc *StudentController) ReturnThing(studentID, thingID int) error {
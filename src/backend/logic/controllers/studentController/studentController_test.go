@@ -11,6 +11,7 @@ import (
 	"src/tests/mother"
 	appErrors "src/utils/error"
 	"testing"
+	"time"
 )
 
 const (
@@ -38,14 +39,14 @@ func (*TestStudentController) TestStudentController_AddStudentPositive(t *testgr
 	realStudents := objectMother.CreateDefaultStudents(N)
 	rows := objectMother.CreateRows(realStudents)
 	mock.ExpectQuery("SELECT").WillReturnRows(rows).WillReturnError(nil)
-	mock.ExpectExec("INSERT INTO").WithArgs(Name, Surname, StudentGroup, StudentNumber, InsertID).
+	mock.ExpectExec("INSERT INTO").WithArgs(Name, Surname, StudentGroup, StudentNumber, InsertID, mother.DefaultGender).
 		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
 
 	Repo := studentRepo.PgStudentRepo{Conn: db}
 	controller := StudentController{Repo: &Repo}
 
 	// Act
-	execErr := controller.AddStudent(Name, Surname, StudentGroup, StudentNumber, InsertID)
+	execErr := controller.AddStudent(Name, Surname, StudentGroup, StudentNumber, mother.DefaultGender, InsertID)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
@@ -71,7 +72,7 @@ func (*TestStudentController) TestStudentController_AddStudentNegativeAlreadyLiv
 	controller := StudentController{Repo: &Repo}
 
 	// Act
-	execErr := controller.AddStudent(Name, Surname, StudentGroup, StudentNumber, InsertID)
+	execErr := controller.AddStudent(Name, Surname, StudentGroup, StudentNumber, mother.DefaultGender, InsertID)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.StudentAlreadyInBaseErr)
@@ -92,7 +93,7 @@ func (*TestStudentController) TestStudentController_AddStudentNegativeBadID(t *t
 	controller := StudentController{Repo: &Repo}
 
 	// Act
-	execErr := controller.AddStudent(Name, Surname, StudentGroup, StudentNumber, -InsertID)
+	execErr := controller.AddStudent(Name, Surname, StudentGroup, StudentNumber, mother.DefaultGender, -InsertID)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.BadAccIDErr)
@@ -113,7 +114,7 @@ func (*TestStudentController) TestStudentController_AddStudentNegativeBadStudent
 	controller := StudentController{Repo: &Repo}
 
 	// Act
-	execErr := controller.AddStudent(Name, Surname, StudentGroup, StudentNumber, InsertID)
+	execErr := controller.AddStudent(Name, Surname, StudentGroup, StudentNumber, mother.DefaultGender, InsertID)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.BadStudentParamsErr)
@@ -134,7 +135,7 @@ func (*TestStudentController) TestStudentController_GetAllStudents(t *testgroup.
 	controller := StudentController{Repo: &Repo}
 
 	// Act
-	resultStudents, execErr := controller.GetAllStudents()
+	resultStudents, execErr := controller.GetAllStudents(objects.Null, objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
@@ -205,11 +206,9 @@ func (*TestStudentController) TestStudentController_GetStudentIDByNumberPositive
 	objectMother := mother.StudentRepoObjectMother{}
 	db, mock := objectMother.CreateRepo()
 	ID := 1
-	N := 1
 	StudNumber := mother.DefaultStudentNumber + fmt.Sprintf("%d", 1)
-	realStudents := objectMother.CreateDefaultStudents(N)
-	rows := objectMother.CreateRows(realStudents)
-	mock.ExpectQuery("SELECT").WithArgs().WillReturnError(nil).WillReturnRows(rows)
+	rows := objectMother.CreateRowForID(ID)
+	mock.ExpectQuery("SELECT").WithArgs(StudNumber).WillReturnError(nil).WillReturnRows(rows)
 
 	Repo := studentRepo.PgStudentRepo{Conn: db}
 	controller := StudentController{Repo: &Repo}
@@ -227,11 +226,8 @@ func (*TestStudentController) TestStudentController_GetStudentIDByNumberNegative
 	// Arrange
 	objectMother := mother.StudentRepoObjectMother{}
 	db, mock := objectMother.CreateRepo()
-	N := 1
 	StudNumber := mother.DefaultStudentNumber + fmt.Sprintf("%d", 6)
-	realStudents := objectMother.CreateDefaultStudents(N)
-	rows := objectMother.CreateRows(realStudents)
-	mock.ExpectQuery("SELECT").WithArgs().WillReturnError(nil).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT").WithArgs(StudNumber).WillReturnError(sql.ErrNoRows)
 
 	Repo := studentRepo.PgStudentRepo{Conn: db}
 	controller := StudentController{Repo: &Repo}
@@ -306,7 +302,7 @@ func (*TestStudentController) TestStudentController_GetStudentThingsPositive(t *
 	controller := StudentController{Repo: &Repo}
 
 	// Act
-	things, execErr := controller.GetStudentThings(ID)
+	things, execErr := controller.GetStudentThings(ID, objects.Null, objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
@@ -325,7 +321,7 @@ func (*TestStudentController) TestStudentController_GetStudentThingsNegative(t *
 	controller := StudentController{Repo: &Repo}
 
 	// Act
-	_, execErr := controller.GetStudentThings(ID)
+	_, execErr := controller.GetStudentThings(ID, objects.Null, objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.StudentNotFoundErr)
@@ -335,24 +331,27 @@ func (*TestStudentController) TestStudentController_GetStudentThingsNegative(t *
 func (*TestStudentController) TestStudentController_SettleStudentPositive(t *testgroup.T) {
 	// Arrange
 	studentObjectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
 	db, mock := studentObjectMother.CreateRepo()
 	N := 1
 	studentID := 1
 	roomID := 2
+	room := roomObjectMother.CreateRoom(roomID, objects.Null, objects.GenderAny)
 
 	realStudents := studentObjectMother.CreateDefaultStudents(N)
 	realStudents[0].SetRoomID(objects.NotLiving)
-	studentRows := studentObjectMother.CreateRows(realStudents)
 
-	mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnError(nil).WillReturnRows(studentRows)
-	mock.ExpectExec("INSERT").WithArgs(studentID, roomID, objects.Get).
+	mock.ExpectBegin()
+	roomObjectMother.ExpectLocks(mock, studentID, realStudents, room)
+	mock.ExpectExec("INSERT").WithArgs(studentID, roomID, objects.Get, mother.DefaultStaffID).
 		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
 
 	Repo := studentRepo.PgStudentRepo{Conn: db}
 	controller := StudentController{Repo: &Repo}
 
 	// Act
-	execErr := controller.SettleStudent(studentID, roomID)
+	execErr := controller.SettleStudent(studentID, roomID, mother.DefaultStaffID)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
@@ -362,17 +361,21 @@ func (*TestStudentController) TestStudentController_SettleStudentPositive(t *tes
 func (*TestStudentController) TestStudentController_SettleStudentNegativeStudentNotFound(t *testgroup.T) {
 	// Arrange
 	studentObjectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
 	db, mock := studentObjectMother.CreateRepo()
 	studentID := 1
 	roomID := 2
+	room := roomObjectMother.CreateRoom(roomID, objects.Null, objects.GenderAny)
 
-	mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnError(sql.ErrNoRows)
+	mock.ExpectBegin()
+	roomObjectMother.ExpectLocks(mock, studentID, nil, room)
+	mock.ExpectRollback()
 
 	Repo := studentRepo.PgStudentRepo{Conn: db}
 	controller := StudentController{Repo: &Repo}
 
 	// Act
-	execErr := controller.SettleStudent(studentID, roomID)
+	execErr := controller.SettleStudent(studentID, roomID, mother.DefaultStaffID)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.StudentNotFoundErr)
@@ -382,27 +385,199 @@ func (*TestStudentController) TestStudentController_SettleStudentNegativeStudent
 func (*TestStudentController) TestStudentController_SettleStudentNegativeStudentLiveNow(t *testgroup.T) {
 	// Arrange
 	studentObjectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
 	db, mock := studentObjectMother.CreateRepo()
 	N := 1
 	studentID := 1
 	roomID := 2
+	room := roomObjectMother.CreateRoom(roomID, objects.Null, objects.GenderAny)
 
 	realStudents := studentObjectMother.CreateDefaultStudents(N)
-	studentRows := studentObjectMother.CreateRows(realStudents)
 
-	mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnError(nil).WillReturnRows(studentRows)
+	mock.ExpectBegin()
+	roomObjectMother.ExpectLocks(mock, studentID, realStudents, room)
+	mock.ExpectRollback()
 
 	Repo := studentRepo.PgStudentRepo{Conn: db}
 	controller := StudentController{Repo: &Repo}
 
 	// Act
-	execErr := controller.SettleStudent(studentID, roomID)
+	execErr := controller.SettleStudent(studentID, roomID, mother.DefaultStaffID)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.StudentAlreadyLiveErr)
 	tests.AssertMocks(t, mock)
 }
 
+func (*TestStudentController) TestStudentController_SettleStudentNegativeRoomIsFull(t *testgroup.T) {
+	// Arrange
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	db, mock := studentObjectMother.CreateRepo()
+	N := 1
+	studentID := 1
+	roomID := 2
+	room := roomObjectMother.CreateRoom(roomID, mother.DefaultCapacity, objects.GenderAny)
+
+	realStudents := studentObjectMother.CreateDefaultStudents(N)
+	realStudents[0].SetRoomID(objects.NotLiving)
+
+	mock.ExpectBegin()
+	roomObjectMother.ExpectLocks(mock, studentID, realStudents, room)
+	mock.ExpectRollback()
+
+	Repo := studentRepo.PgStudentRepo{Conn: db}
+	controller := StudentController{Repo: &Repo}
+
+	// Act
+	execErr := controller.SettleStudent(studentID, roomID, mother.DefaultStaffID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.RoomIsFullErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestStudentController) TestStudentController_SettleStudentNegativeNotForLiving(t *testgroup.T) {
+	// Arrange
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	db, mock := studentObjectMother.CreateRepo()
+	N := 1
+	studentID := 1
+	room := objects.NewRoomWithParams(1, mother.Type, objects.Null, objects.Null, objects.GenderAny)
+
+	realStudents := studentObjectMother.CreateDefaultStudents(N)
+	realStudents[0].SetRoomID(objects.NotLiving)
+
+	mock.ExpectBegin()
+	roomObjectMother.ExpectLocks(mock, studentID, realStudents, room)
+	mock.ExpectRollback()
+
+	Repo := studentRepo.PgStudentRepo{Conn: db}
+	controller := StudentController{Repo: &Repo}
+
+	// Act
+	execErr := controller.SettleStudent(studentID, room.GetID(), mother.DefaultStaffID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.RoomNotForLivingErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestStudentController) TestStudentController_SettleStudentNegativeOtherGender(t *testgroup.T) {
+	// Arrange
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	db, mock := studentObjectMother.CreateRepo()
+	N := 1
+	studentID := 1
+	roomID := 2
+	room := roomObjectMother.CreateRoom(roomID, objects.Null, objects.GenderFemale)
+
+	realStudents := studentObjectMother.CreateDefaultStudents(N)
+	realStudents[0].SetRoomID(objects.NotLiving)
+
+	mock.ExpectBegin()
+	roomObjectMother.ExpectLocks(mock, studentID, realStudents, room)
+	mock.ExpectRollback()
+
+	Repo := studentRepo.PgStudentRepo{Conn: db}
+	controller := StudentController{Repo: &Repo}
+
+	// Act
+	execErr := controller.SettleStudent(studentID, roomID, mother.DefaultStaffID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.RoomGenderErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestStudentController) TestStudentController_RelocateStudentPositive(t *testgroup.T) {
+	// Arrange
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	db, mock := studentObjectMother.CreateRepo()
+	N := 1
+	studentID := 1
+	roomID := 2
+	room := roomObjectMother.CreateRoom(roomID, objects.Null, objects.GenderAny)
+
+	realStudents := studentObjectMother.CreateDefaultStudents(N)
+
+	mock.ExpectBegin()
+	roomObjectMother.ExpectLocks(mock, studentID, realStudents, room)
+	mock.ExpectExec("INSERT").WithArgs(studentID, realStudents[0].GetRoomID(), objects.Ret, mother.DefaultStaffID).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT").WithArgs(studentID, roomID, objects.Get, mother.DefaultStaffID).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
+
+	Repo := studentRepo.PgStudentRepo{Conn: db}
+	controller := StudentController{Repo: &Repo}
+
+	// Act
+	execErr := controller.RelocateStudent(studentID, roomID, mother.DefaultStaffID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestStudentController) TestStudentController_RelocateStudentNegativeNotLiving(t *testgroup.T) {
+	// Arrange
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	db, mock := studentObjectMother.CreateRepo()
+	N := 1
+	studentID := 1
+	roomID := 2
+	room := roomObjectMother.CreateRoom(roomID, objects.Null, objects.GenderAny)
+
+	realStudents := studentObjectMother.CreateDefaultStudents(N)
+	realStudents[0].SetRoomID(objects.NotLiving)
+
+	mock.ExpectBegin()
+	roomObjectMother.ExpectLocks(mock, studentID, realStudents, room)
+	mock.ExpectRollback()
+
+	Repo := studentRepo.PgStudentRepo{Conn: db}
+	controller := StudentController{Repo: &Repo}
+
+	// Act
+	execErr := controller.RelocateStudent(studentID, roomID, mother.DefaultStaffID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.StudentNotLivingErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestStudentController) TestStudentController_RelocateStudentNegativeRoomIsFull(t *testgroup.T) {
+	// Arrange
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	db, mock := studentObjectMother.CreateRepo()
+	N := 1
+	studentID := 1
+	roomID := 2
+	room := roomObjectMother.CreateRoom(roomID, mother.DefaultCapacity, objects.GenderAny)
+
+	realStudents := studentObjectMother.CreateDefaultStudents(N)
+
+	mock.ExpectBegin()
+	roomObjectMother.ExpectLocks(mock, studentID, realStudents, room)
+	mock.ExpectRollback()
+
+	Repo := studentRepo.PgStudentRepo{Conn: db}
+	controller := StudentController{Repo: &Repo}
+
+	// Act
+	execErr := controller.RelocateStudent(studentID, roomID, mother.DefaultStaffID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.RoomIsFullErr)
+	tests.AssertMocks(t, mock)
+}
+
 func (*TestStudentController) TestStudentController_EvicStudentPositive(t *testgroup.T) {
 	// Arrange
 	studentObjectMother := mother.StudentRepoObjectMother{}
@@ -412,17 +587,19 @@ func (*TestStudentController) TestStudentController_EvicStudentPositive(t *testg
 	roomID := 1
 
 	realStudents := studentObjectMother.CreateDefaultStudents(N)
-	studentRows := studentObjectMother.CreateRows(realStudents)
 
-	mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnError(nil).WillReturnRows(studentRows)
-	mock.ExpectExec("INSERT").WithArgs(studentID, roomID, objects.Ret).
+	mock.ExpectBegin()
+	studentObjectMother.ExpectLock(mock, studentID, realStudents)
+	studentObjectMother.ExpectDebts(mock, studentID, objects.Null, objects.Null)
+	mock.ExpectExec("INSERT").WithArgs(studentID, roomID, objects.Ret, mother.DefaultStaffID).
 		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
 
 	Repo := studentRepo.PgStudentRepo{Conn: db}
 	controller := StudentController{Repo: &Repo}
 
 	// Act
-	execErr := controller.EvicStudent(studentID)
+	execErr := controller.EvicStudent(studentID, mother.DefaultStaffID, true)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
@@ -435,13 +612,15 @@ func (*TestStudentController) TestStudentController_EvicStudentNegativeStudentNo
 	db, mock := studentObjectMother.CreateRepo()
 	studentID := 1
 
-	mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnError(sql.ErrNoRows)
+	mock.ExpectBegin()
+	studentObjectMother.ExpectLock(mock, studentID, nil)
+	mock.ExpectRollback()
 
 	Repo := studentRepo.PgStudentRepo{Conn: db}
 	controller := StudentController{Repo: &Repo}
 
 	// Act
-	execErr := controller.EvicStudent(studentID)
+	execErr := controller.EvicStudent(studentID, mother.DefaultStaffID, false)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.StudentNotFoundErr)
@@ -457,15 +636,17 @@ func (*TestStudentController) TestStudentController_EvicStudentStudentDoesNotLiv
 
 	realStudents := studentObjectMother.CreateDefaultStudents(N)
 	realStudents[0].SetRoomID(objects.NotLiving)
-	studentRows := studentObjectMother.CreateRows(realStudents)
 
-	mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnError(nil).WillReturnRows(studentRows)
+	mock.ExpectBegin()
+	studentObjectMother.ExpectLock(mock, studentID, realStudents)
+	studentObjectMother.ExpectDebts(mock, studentID, objects.Null, objects.Null)
+	mock.ExpectRollback()
 
 	Repo := studentRepo.PgStudentRepo{Conn: db}
 	controller := StudentController{Repo: &Repo}
 
 	// Act
-	execErr := controller.EvicStudent(studentID)
+	execErr := controller.EvicStudent(studentID, mother.DefaultStaffID, false)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.StudentNotLivingErr)
@@ -486,7 +667,8 @@ func (*TestStudentController) TestStudentController_ChangeStudentGroupPositive(t
 
 	mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnError(nil).WillReturnRows(studentRows)
 	mock.ExpectExec("UPDATE").WithArgs(realStudents[0].GetName(), realStudents[0].GetSurname(),
-		newGroup, realStudents[0].GetStudentNumber(), realStudents[0].GetID()).
+		newGroup, realStudents[0].GetStudentNumber(), realStudents[0].GetID(), realStudents[0].GetGender(),
+		objects.EmptyString, objects.EmptyString, objects.Null, objects.EmptyString, objects.EmptyString, nil).
 		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
 
 	Repo := studentRepo.PgStudentRepo{Conn: db}
@@ -528,11 +710,12 @@ func (*TestStudentController) TestStudentController_TransferThingPositive(t *tes
 	thingID := 2
 
 	realStudents := studentObjectMother.CreateDefaultStudents(N)
-	studentRows := studentObjectMother.CreateRows(realStudents)
 
-	mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnError(nil).WillReturnRows(studentRows)
+	mock.ExpectBegin()
+	studentObjectMother.ExpectLock(mock, studentID, realStudents)
 	mock.ExpectExec("INSERT").WithArgs(studentID, thingID, objects.Get).
 		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
 
 	Repo := studentRepo.PgStudentRepo{Conn: db}
 	controller := StudentController{Repo: &Repo}
@@ -552,7 +735,9 @@ func (*TestStudentController) TestStudentController_TransferThingNegative(t *tes
 	studentID := 1
 	thingID := 2
 
-	mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnError(sql.ErrNoRows)
+	mock.ExpectBegin()
+	studentObjectMother.ExpectLock(mock, studentID, nil)
+	mock.ExpectRollback()
 
 	Repo := studentRepo.PgStudentRepo{Conn: db}
 	controller := StudentController{Repo: &Repo}
@@ -610,3 +795,22 @@ func (*TestStudentController) TestStudentController_ReturnThingNegative(t *testg
 	tests.AssertErrors(t, execErr, appErrors.StudentNotFoundErr)
 	tests.AssertMocks(t, mock)
 }
+
+func (*TestStudentController) TestStudentController_GetLivingHistoryNegativeBadRange(t *testgroup.T) {
+	// Arrange
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	db, mock := studentObjectMother.CreateRepo()
+	studentID := 1
+	from := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, -1, 0)
+
+	Repo := studentRepo.PgStudentRepo{Conn: db}
+	controller := StudentController{Repo: &Repo}
+
+	// Act
+	_, execErr := controller.GetLivingHistory(studentID, from, to, objects.Null, objects.Null)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.WrongRequestParamsErr)
+	tests.AssertMocks(t, mock)
+}
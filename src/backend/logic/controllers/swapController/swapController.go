@@ -0,0 +1,111 @@
+package swapController
+
+import (
+	"database/sql"
+	"src/db/swapRepo"
+	"src/objects"
+	appErrors "src/utils/error"
+)
+
+type SwapController struct {
+	Repo swapRepo.SwapRepo
+}
+
+// AddSwap records the rooms both students live in now. A student may take part in one open swap only.
+func (sc *SwapController) AddSwap(initiator, partner objects.Student) (int, error) {
+	if initiator.GetID() == partner.GetID() {
+		return objects.None, appErrors.BadSwapParamsErr
+	}
+	if initiator.GetRoomID() == objects.NotLiving || partner.GetRoomID() == objects.NotLiving {
+		return objects.None, appErrors.StudentNotLivingErr
+	}
+	if initiator.GetRoomID() == partner.GetRoomID() {
+		return objects.None, appErrors.BadDstRoomErr
+	}
+
+	for _, student := range []objects.Student{initiator, partner} {
+		swaps, err := sc.Repo.GetStudentSwaps(student.GetID())
+		if err != nil {
+			return objects.None, err
+		}
+		for _, swap := range swaps {
+			if swap.IsOpen() {
+				return objects.None, appErrors.SwapExistsErr
+			}
+		}
+	}
+	return sc.Repo.AddSwap(objects.NewSwapParty(initiator.GetID(), initiator.GetStudentNumber(), initiator.GetRoomID()),
+		objects.NewSwapParty(partner.GetID(), partner.GetStudentNumber(), partner.GetRoomID()))
+}
+
+func (sc *SwapController) GetSwap(id int) (objects.RoomSwap, error) {
+	swap, err := sc.Repo.GetSwap(id)
+	if err == sql.ErrNoRows || (err == nil && swap.GetID() == objects.None) {
+		err = appErrors.SwapNotFoundErr
+	}
+	return swap, err
+}
+
+func (sc *SwapController) GetSwaps(status objects.SwapStatus, page, size int) ([]objects.RoomSwap, error) {
+	if !status.IsValid() {
+		return make([]objects.RoomSwap, objects.Empty), appErrors.BadSwapParamsErr
+	}
+	return sc.Repo.GetSwaps(status, page, size)
+}
+
+func (sc *SwapController) GetStudentSwaps(studentID int) ([]objects.RoomSwap, error) {
+	return sc.Repo.GetStudentSwaps(studentID)
+}
+
+func (sc *SwapController) changeSwapStatus(swap objects.RoomSwap, to objects.SwapStatus) error {
+	err := sc.Repo.ChangeSwapStatus(swap.GetID(), swap.GetStatus(), to)
+	if err == sql.ErrNoRows {
+		err = appErrors.SwapStatusErr
+	}
+	return err
+}
+
+func (sc *SwapController) AcceptSwap(swap objects.RoomSwap) error {
+	if swap.GetStatus() != objects.SwapPending {
+		return appErrors.SwapStatusErr
+	}
+	return sc.changeSwapStatus(swap, objects.SwapAccepted)
+}
+
+func (sc *SwapController) RejectSwap(swap objects.RoomSwap) error {
+	if !swap.IsOpen() {
+		return appErrors.SwapStatusErr
+	}
+	return sc.changeSwapStatus(swap, objects.SwapRejected)
+}
+
+// ConfirmSwap carries out the accepted swap. The repo calls the check with both students and rooms locked,
+// so the swap is refused if a student has moved since it was proposed.
+func (sc *SwapController) ConfirmSwap(swap objects.RoomSwap, performedBy int) error {
+	if swap.GetStatus() != objects.SwapAccepted {
+		return appErrors.SwapStatusErr
+	}
+
+	err := sc.Repo.ConfirmSwap(swap, performedBy, func(initiator, partner objects.Student,
+		initiatorRoom, partnerRoom objects.Room) error {
+		return checkConfirm(swap, initiator, partner, initiatorRoom, partnerRoom)
+	})
+	if err == sql.ErrNoRows {
+		err = appErrors.SwapStatusErr
+	}
+	return err
+}
+
+// checkConfirm checks that both students still live in the rooms they had when the swap was proposed
+// and each room allows the gender of the student moving in.
+func checkConfirm(swap objects.RoomSwap, initiator, partner objects.Student,
+	initiatorRoom, partnerRoom objects.Room) error {
+	initiatorParty, partnerParty := swap.GetInitiator(), swap.GetPartner()
+	if initiator.GetRoomID() != initiatorParty.GetRoomID() || partner.GetRoomID() != partnerParty.GetRoomID() {
+		return appErrors.SwapOutdatedErr
+	}
+	if !partnerRoom.AllowsGender(initiator.GetGender()) || !initiatorRoom.AllowsGender(partner.GetGender()) {
+		return appErrors.RoomGenderErr
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package swapController
+
+import (
+	"github.com/bloomberg/go-testgroup"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/db/swapRepo"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	appErrors "src/utils/error"
+	"testing"
+)
+
+const (
+	InsertID     = 5
+	RowsAffected = 1
+)
+
+type TestSwapController struct{}
+
+func Test_SwapController(t *testing.T) {
+	testgroup.RunSerially(t, &TestSwapController{})
+}
+
+func (*TestSwapController) TestSwapController_AddSwap(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.SwapRepoObjectMother{}
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	students := studentObjectMother.CreateDefaultStudents(3)
+	closedSwaps := []objects.RoomSwap{objectMother.CreateSwap(1, 1, 3, objects.SwapRejected)}
+	mock.ExpectQuery("SELECT").WithArgs(students[0].GetID()).WillReturnRows(objectMother.CreateRows(closedSwaps))
+	mock.ExpectQuery("SELECT").WithArgs(students[1].GetID()).WillReturnRows(objectMother.CreateRows(nil))
+	mock.ExpectQuery("INSERT INTO").
+		WithArgs(students[0].GetID(), students[0].GetRoomID(), students[1].GetID(), students[1].GetRoomID()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(InsertID))
+	repo := swapRepo.PgSwapRepo{Conn: db}
+	controller := SwapController{Repo: &repo}
+
+	// Act
+	id, execErr := controller.AddSwap(students[0], students[1])
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, id, InsertID)
+}
+
+// TestSwapController_AddSwapPartnerBusy проверяет, что студент не может участвовать в двух обменах сразу.
+func (*TestSwapController) TestSwapController_AddSwapPartnerBusy(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.SwapRepoObjectMother{}
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	students := studentObjectMother.CreateDefaultStudents(3)
+	openSwaps := []objects.RoomSwap{objectMother.CreateSwap(1, 3, 2, objects.SwapAccepted)}
+	mock.ExpectQuery("SELECT").WithArgs(students[0].GetID()).WillReturnRows(objectMother.CreateRows(nil))
+	mock.ExpectQuery("SELECT").WithArgs(students[1].GetID()).WillReturnRows(objectMother.CreateRows(openSwaps))
+	repo := swapRepo.PgSwapRepo{Conn: db}
+	controller := SwapController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.AddSwap(students[0], students[1])
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.SwapExistsErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestSwapController_AddSwapSameRoom проверяет, что соседи по комнате не могут обменяться.
+func (*TestSwapController) TestSwapController_AddSwapSameRoom(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.SwapRepoObjectMother{}
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	students := studentObjectMother.CreateDefaultStudents(2)
+	students[1].SetRoomID(students[0].GetRoomID())
+	repo := swapRepo.PgSwapRepo{Conn: db}
+	controller := SwapController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.AddSwap(students[0], students[1])
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadDstRoomErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestSwapController_AcceptSwapNotPending проверяет, что принять можно только новый обмен.
+func (*TestSwapController) TestSwapController_AcceptSwapNotPending(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.SwapRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	swap := objectMother.CreateSwap(1, 1, 2, objects.SwapRejected)
+	repo := swapRepo.PgSwapRepo{Conn: db}
+	controller := SwapController{Repo: &repo}
+
+	// Act
+	execErr := controller.AcceptSwap(swap)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.SwapStatusErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestSwapController_ConfirmSwapOutdated проверяет, что обмен не проводится, если студент уже переселился.
+func (*TestSwapController) TestSwapController_ConfirmSwapOutdated(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.SwapRepoObjectMother{}
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	swap := objectMother.CreateSwap(1, 1, 2, objects.SwapAccepted)
+	students := studentObjectMother.CreateDefaultStudents(2)
+	students[1].SetRoomID(3)
+	rooms := roomObjectMother.CreateDefaultRooms(2)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE").WithArgs(swap.GetID(), objects.SwapAccepted, objects.SwapConfirmed).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	objectMother.ExpectLocks(mock, students, rooms)
+	mock.ExpectRollback()
+	repo := swapRepo.PgSwapRepo{Conn: db}
+	controller := SwapController{Repo: &repo}
+
+	// Act
+	execErr := controller.ConfirmSwap(swap, mother.DefaultStaffID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.SwapOutdatedErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestSwapController_ConfirmSwapGender проверяет, что студент не переедет в комнату для другого пола.
+func (*TestSwapController) TestSwapController_ConfirmSwapGender(t *testgroup.T) {
+	// Arrange
+	objectMother := mother.SwapRepoObjectMother{}
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	swap := objectMother.CreateSwap(1, 1, 2, objects.SwapAccepted)
+	students := studentObjectMother.CreateDefaultStudents(2)
+	rooms := []objects.Room{roomObjectMother.CreateRoom(1, 1, objects.GenderAny),
+		roomObjectMother.CreateRoom(2, 1, objects.GenderFemale)}
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE").WithArgs(swap.GetID(), objects.SwapAccepted, objects.SwapConfirmed).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	objectMother.ExpectLocks(mock, students, rooms)
+	mock.ExpectRollback()
+	repo := swapRepo.PgSwapRepo{Conn: db}
+	controller := SwapController{Repo: &repo}
+
+	// Act
+	execErr := controller.ConfirmSwap(swap, mother.DefaultStaffID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.RoomGenderErr)
+	tests.AssertMocks(t, mock)
+}
@@ -103,6 +103,14 @@ func (tc *ThingController) GetCurrentOwner(thingID int) (int, error) {
 	return result, err
 }
 
+func (tc *ThingController) GetOwnerHistory(thingID int) ([]objects.ThingEvent, error) {
+	return tc.Repo.GetThingOwnerHistory(thingID)
+}
+
+func (tc *ThingController) GetRoomHistory(thingID int) ([]objects.ThingEvent, error) {
+	return tc.Repo.GetThingRoomHistory(thingID)
+}
+
 func (tc *ThingController) GetThingIDByMarkNumber(markNumber int) (int, error) {
 	id, err := tc.Repo.GetThingIDByMarkNumber(markNumber)
 	if err == sql.ErrNoRows {
@@ -142,7 +142,7 @@ func (*TestThingController) TestThingController_GetThings(t *testgroup.T) {
 	controller := ThingController{Repo: &repo}
 
 	// Act
-	things, execErr := controller.GetThings()
+	things, execErr := controller.GetThings(objects.Null, objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
@@ -359,7 +359,7 @@ func (*TestThingController) TestThingController_GetFreeThings(t *testgroup.T) {
 	controller := ThingController{Repo: &repo}
 
 	// Act
-	things, execErr := controller.GetFreeThings()
+	things, execErr := controller.GetFreeThings(objects.Null, objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
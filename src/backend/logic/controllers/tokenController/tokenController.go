@@ -0,0 +1,144 @@
+package tokenController
+
+import (
+	"database/sql"
+	"src/db/tokenRepo"
+	"src/objects"
+	appErrors "src/utils/error"
+	"src/utils/hashUtils"
+	"time"
+)
+
+type TokenController struct {
+	Repo tokenRepo.TokenRepo
+}
+
+func (tc *TokenController) CreateRefreshToken(userID int, ttl time.Duration) (string, error) {
+	if userID <= objects.None {
+		return objects.EmptyString, appErrors.BadUserParamsErr
+	}
+	token, err := hashUtils.GenerateToken(hashUtils.DefaultTokenBytes)
+	if err == nil {
+		err = tc.Repo.AddRefreshToken(userID, hashUtils.HashToken(token), time.Now().Add(ttl))
+	}
+	return token, err
+}
+
+// UseRefreshToken revokes the presented token and returns its owner, so every token works only once.
+// Presenting an already revoked token means it was stolen or replayed: all tokens of the user are revoked then.
+func (tc *TokenController) UseRefreshToken(token string) (int, error) {
+	var userID = objects.None
+	if token == objects.EmptyString {
+		return userID, appErrors.BadRefreshTokenErr
+	}
+
+	refreshToken, err := tc.Repo.GetRefreshToken(hashUtils.HashToken(token))
+	if err == sql.ErrNoRows {
+		return userID, appErrors.BadRefreshTokenErr
+	} else if err != nil {
+		return userID, err
+	}
+
+	if refreshToken.IsRevoked() {
+		err = tc.Repo.RevokeUserRefreshTokens(refreshToken.GetUserID())
+		if err == nil {
+			err = appErrors.RefreshTokenReusedErr
+		}
+	} else if refreshToken.IsExpired(time.Now()) {
+		err = appErrors.RefreshTokenExpiredErr
+	} else {
+		isRevoked, revokeErr := tc.Repo.RevokeRefreshToken(refreshToken.GetID())
+		if revokeErr != nil {
+			err = revokeErr
+		} else if !isRevoked {
+			err = appErrors.RefreshTokenReusedErr
+		} else {
+			userID = refreshToken.GetUserID()
+		}
+	}
+	return userID, err
+}
+
+func (tc *TokenController) RevokeRefreshToken(token string) error {
+	refreshToken, err := tc.Repo.GetRefreshToken(hashUtils.HashToken(token))
+	if err == nil {
+		_, err = tc.Repo.RevokeRefreshToken(refreshToken.GetID())
+	} else if err == sql.ErrNoRows {
+		err = appErrors.BadRefreshTokenErr
+	}
+	return err
+}
+
+func (tc *TokenController) RevokeUserTokens(userID int) error {
+	return tc.Repo.RevokeUserRefreshTokens(userID)
+}
+
+// RevokeUserSessions logs the user out everywhere: refresh tokens are revoked
+// and access tokens issued before this moment are rejected.
+func (tc *TokenController) RevokeUserSessions(userID int) error {
+	err := tc.Repo.RevokeUserRefreshTokens(userID)
+	if err == nil {
+		err = tc.Repo.RevokeUserAccessTokens(userID)
+	}
+	return err
+}
+
+func (tc *TokenController) RevokeAccessToken(tokenID string, expiresAt time.Time) error {
+	if tokenID == objects.EmptyString {
+		return appErrors.BadAccessTokenErr
+	}
+	return tc.Repo.RevokeAccessToken(tokenID, expiresAt)
+}
+
+// IsAccessTokenRevoked fails closed: if the store can't be read the token is treated as revoked.
+func (tc *TokenController) IsAccessTokenRevoked(tokenID string, userID int, issuedAt time.Time) bool {
+	isRevoked, err := tc.Repo.IsAccessTokenRevoked(tokenID, userID, issuedAt)
+	return err != nil || isRevoked
+}
+
+// CreatePasswordResetToken cancels previous unused reset tokens of the user, so only the last one works.
+func (tc *TokenController) CreatePasswordResetToken(userID int, ttl time.Duration) (string, time.Time, error) {
+	expiresAt := time.Now().Add(ttl)
+	if userID <= objects.None {
+		return objects.EmptyString, expiresAt, appErrors.BadUserParamsErr
+	}
+	token, err := hashUtils.GenerateToken(hashUtils.DefaultTokenBytes)
+	if err == nil {
+		err = tc.Repo.CancelUserPasswordResetTokens(userID)
+	}
+	if err == nil {
+		err = tc.Repo.AddPasswordResetToken(userID, hashUtils.HashToken(token), expiresAt)
+	}
+	return token, expiresAt, err
+}
+
+// UsePasswordResetToken marks the token as used and returns its owner.
+func (tc *TokenController) UsePasswordResetToken(token string) (int, error) {
+	var userID = objects.None
+	if token == objects.EmptyString {
+		return userID, appErrors.BadResetTokenErr
+	}
+
+	resetToken, err := tc.Repo.GetPasswordResetToken(hashUtils.HashToken(token))
+	if err == sql.ErrNoRows {
+		return userID, appErrors.BadResetTokenErr
+	} else if err != nil {
+		return userID, err
+	}
+
+	if resetToken.IsUsed() {
+		err = appErrors.BadResetTokenErr
+	} else if resetToken.IsExpired(time.Now()) {
+		err = appErrors.ResetTokenExpiredErr
+	} else {
+		isUsed, useErr := tc.Repo.UsePasswordResetToken(resetToken.GetID())
+		if useErr != nil {
+			err = useErr
+		} else if !isUsed {
+			err = appErrors.BadResetTokenErr
+		} else {
+			userID = resetToken.GetUserID()
+		}
+	}
+	return userID, err
+}
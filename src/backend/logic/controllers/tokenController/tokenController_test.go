@@ -0,0 +1,346 @@
+package tokenController
+
+import (
+	"database/sql"
+	"github.com/bloomberg/go-testgroup"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/db/tokenRepo"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	appErrors "src/utils/error"
+	"src/utils/hashUtils"
+	"testing"
+	"time"
+)
+
+var (
+	InsertID     int64 = 5
+	RowsAffected int64 = 1
+)
+
+type TestTokenController struct{}
+
+func Test_TokenController(t *testing.T) {
+	testgroup.RunSerially(t, &TestTokenController{})
+}
+
+func (*TestTokenController) TestTokenController_CreateRefreshTokenPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	userID := 1
+	mock.ExpectExec("INSERT INTO").WithArgs(userID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	repo := tokenRepo.PgTokenRepo{Conn: db}
+	controller := TokenController{Repo: &repo}
+
+	// Act
+	token, execErr := controller.CreateRefreshToken(userID, time.Hour)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, token != objects.EmptyString, true)
+}
+
+func (*TestTokenController) TestTokenController_CreateRefreshTokenNegativeBadUser(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+
+	repo := tokenRepo.PgTokenRepo{Conn: db}
+	controller := TokenController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.CreateRefreshToken(objects.None, time.Hour)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadUserParamsErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestTokenController) TestTokenController_UseRefreshTokenPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	userID := 1
+	token := objectMother.CreateRefreshToken(userID, time.Now().Add(time.Hour), false)
+	rows := objectMother.CreateRows([]objects.RefreshToken{token})
+	mock.ExpectQuery("SELECT").WithArgs(hashUtils.HashToken(mother.DefaultRefreshToken)).
+		WillReturnError(nil).WillReturnRows(rows)
+	mock.ExpectExec("UPDATE").WithArgs(token.GetID()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	repo := tokenRepo.PgTokenRepo{Conn: db}
+	controller := TokenController{Repo: &repo}
+
+	// Act
+	resultUserID, execErr := controller.UseRefreshToken(mother.DefaultRefreshToken)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, resultUserID, userID)
+}
+
+func (*TestTokenController) TestTokenController_UseRefreshTokenNegativeNotFound(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectQuery("SELECT").WithArgs(hashUtils.HashToken(mother.DefaultRefreshToken)).
+		WillReturnError(sql.ErrNoRows)
+
+	repo := tokenRepo.PgTokenRepo{Conn: db}
+	controller := TokenController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.UseRefreshToken(mother.DefaultRefreshToken)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadRefreshTokenErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestTokenController) TestTokenController_UseRefreshTokenNegativeExpired(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	userID := 1
+	token := objectMother.CreateRefreshToken(userID, time.Now().Add(-time.Hour), false)
+	rows := objectMother.CreateRows([]objects.RefreshToken{token})
+	mock.ExpectQuery("SELECT").WithArgs(hashUtils.HashToken(mother.DefaultRefreshToken)).
+		WillReturnError(nil).WillReturnRows(rows)
+
+	repo := tokenRepo.PgTokenRepo{Conn: db}
+	controller := TokenController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.UseRefreshToken(mother.DefaultRefreshToken)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.RefreshTokenExpiredErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestTokenController) TestTokenController_UseRefreshTokenNegativeReused(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	userID := 1
+	token := objectMother.CreateRefreshToken(userID, time.Now().Add(time.Hour), true)
+	rows := objectMother.CreateRows([]objects.RefreshToken{token})
+	mock.ExpectQuery("SELECT").WithArgs(hashUtils.HashToken(mother.DefaultRefreshToken)).
+		WillReturnError(nil).WillReturnRows(rows)
+	mock.ExpectExec("UPDATE").WithArgs(userID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	repo := tokenRepo.PgTokenRepo{Conn: db}
+	controller := TokenController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.UseRefreshToken(mother.DefaultRefreshToken)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.RefreshTokenReusedErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestTokenController) TestTokenController_UseRefreshTokenNegativeConcurrentUse(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	userID := 1
+	token := objectMother.CreateRefreshToken(userID, time.Now().Add(time.Hour), false)
+	rows := objectMother.CreateRows([]objects.RefreshToken{token})
+	mock.ExpectQuery("SELECT").WithArgs(hashUtils.HashToken(mother.DefaultRefreshToken)).
+		WillReturnError(nil).WillReturnRows(rows)
+	mock.ExpectExec("UPDATE").WithArgs(token.GetID()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, objects.Null))
+
+	repo := tokenRepo.PgTokenRepo{Conn: db}
+	controller := TokenController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.UseRefreshToken(mother.DefaultRefreshToken)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.RefreshTokenReusedErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestTokenController) TestTokenController_IsAccessTokenRevoked(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	userID := 1
+	issuedAt := time.Now()
+	rows := objectMother.CreateCountRows(objects.Null)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultTokenID, userID, issuedAt).
+		WillReturnError(nil).WillReturnRows(rows)
+
+	repo := tokenRepo.PgTokenRepo{Conn: db}
+	controller := TokenController{Repo: &repo}
+
+	// Act
+	isRevoked := controller.IsAccessTokenRevoked(mother.DefaultTokenID, userID, issuedAt)
+
+	// Assert
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, isRevoked, false)
+}
+
+func (*TestTokenController) TestTokenController_IsAccessTokenRevokedStoreError(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	userID := 1
+	issuedAt := time.Now()
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultTokenID, userID, issuedAt).WillReturnError(tests.TestErr)
+
+	repo := tokenRepo.PgTokenRepo{Conn: db}
+	controller := TokenController{Repo: &repo}
+
+	// Act
+	isRevoked := controller.IsAccessTokenRevoked(mother.DefaultTokenID, userID, issuedAt)
+
+	// Assert
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, isRevoked, true)
+}
+
+func (*TestTokenController) TestTokenController_RevokeUserSessions(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	userID := 1
+	mock.ExpectExec("UPDATE").WithArgs(userID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").WithArgs(userID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	repo := tokenRepo.PgTokenRepo{Conn: db}
+	controller := TokenController{Repo: &repo}
+
+	// Act
+	execErr := controller.RevokeUserSessions(userID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestTokenController) TestTokenController_CreatePasswordResetToken(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	userID := 1
+	mock.ExpectExec("UPDATE").WithArgs(userID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").WithArgs(userID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	repo := tokenRepo.PgTokenRepo{Conn: db}
+	controller := TokenController{Repo: &repo}
+
+	// Act
+	token, _, execErr := controller.CreatePasswordResetToken(userID, time.Hour)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, token != objects.EmptyString, true)
+}
+
+func (*TestTokenController) TestTokenController_UsePasswordResetTokenPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	userID := 1
+	token := objectMother.CreatePasswordResetToken(userID, time.Now().Add(time.Hour), false)
+	rows := objectMother.CreatePasswordResetRows([]objects.PasswordResetToken{token})
+	mock.ExpectQuery("SELECT").WithArgs(hashUtils.HashToken(mother.DefaultResetToken)).
+		WillReturnError(nil).WillReturnRows(rows)
+	mock.ExpectExec("UPDATE").WithArgs(token.GetID()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	repo := tokenRepo.PgTokenRepo{Conn: db}
+	controller := TokenController{Repo: &repo}
+
+	// Act
+	result, execErr := controller.UsePasswordResetToken(mother.DefaultResetToken)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result, userID)
+}
+
+func (*TestTokenController) TestTokenController_UsePasswordResetTokenNegativeUsed(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	token := objectMother.CreatePasswordResetToken(1, time.Now().Add(time.Hour), true)
+	rows := objectMother.CreatePasswordResetRows([]objects.PasswordResetToken{token})
+	mock.ExpectQuery("SELECT").WithArgs(hashUtils.HashToken(mother.DefaultResetToken)).
+		WillReturnError(nil).WillReturnRows(rows)
+
+	repo := tokenRepo.PgTokenRepo{Conn: db}
+	controller := TokenController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.UsePasswordResetToken(mother.DefaultResetToken)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadResetTokenErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestTokenController) TestTokenController_UsePasswordResetTokenNegativeExpired(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	token := objectMother.CreatePasswordResetToken(1, time.Now().Add(-time.Hour), false)
+	rows := objectMother.CreatePasswordResetRows([]objects.PasswordResetToken{token})
+	mock.ExpectQuery("SELECT").WithArgs(hashUtils.HashToken(mother.DefaultResetToken)).
+		WillReturnError(nil).WillReturnRows(rows)
+
+	repo := tokenRepo.PgTokenRepo{Conn: db}
+	controller := TokenController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.UsePasswordResetToken(mother.DefaultResetToken)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.ResetTokenExpiredErr)
+	tests.AssertMocks(t, mock)
+}
@@ -5,10 +5,12 @@ import (
 	"src/db/userRepo"
 	"src/objects"
 	appErrors "src/utils/error"
+	"src/utils/hashUtils"
 )
 
 type UserController struct {
-	Repo userRepo.UserRepo
+	Repo   userRepo.UserRepo
+	Hasher hashUtils.PasswordHasher
 }
 
 func (uc *UserController) GetUserID(login string) (int, error) {
@@ -21,23 +23,61 @@ func (uc *UserController) GetUserID(login string) (int, error) {
 
 func (uc *UserController) GetUser(id int) (objects.User, error) {
 	user, err := uc.Repo.GetUser(id)
-	if err == sql.ErrNoRows {
+	if err == sql.ErrNoRows || (err == nil && user.GetID() == objects.None) {
 		err = appErrors.UserNotFoundErr
 	}
 	return user, err
 }
+
+func (uc *UserController) GetUsers(page, size int) ([]objects.User, error) {
+	return uc.Repo.GetUsers(page, size)
+}
+
 func (uc *UserController) AddUser(login, password string, privelegeLevel objects.Levels) error {
 	if login == objects.EmptyString || password == objects.EmptyString {
 		return appErrors.BadUserParamsErr
 	}
+	if !IsValidLevel(privelegeLevel) {
+		return appErrors.BadRoleErr
+	}
 	_, err := uc.Repo.GetUserID(login)
 	if err == sql.ErrNoRows {
-		return uc.Repo.AddUser(login, password, privelegeLevel)
+		passwordHash, hashErr := uc.getHasher().Hash(password)
+		if hashErr != nil {
+			return hashErr
+		}
+		return uc.Repo.AddUser(login, passwordHash, privelegeLevel)
 	} else {
 		return appErrors.LoginOccupedErr
 	}
 }
 
+// HashPassword hashes the password the same way as AddUser, for accounts added in bulk.
+func (uc *UserController) HashPassword(password string) (string, error) {
+	if password == objects.EmptyString {
+		return objects.EmptyString, appErrors.BadUserParamsErr
+	}
+	return uc.getHasher().Hash(password)
+}
+
+// GetUserByExternalIdentity returns UserNotFoundErr if the account of the provider isn't linked yet.
+func (uc *UserController) GetUserByExternalIdentity(provider, subject string) (objects.User, error) {
+	id, err := uc.Repo.GetUserIDByExternalIdentity(provider, subject)
+	if err == sql.ErrNoRows {
+		return objects.NewEmptyUser(), appErrors.UserNotFoundErr
+	} else if err != nil {
+		return objects.NewEmptyUser(), err
+	}
+	return uc.GetUser(id)
+}
+
+func (uc *UserController) LinkExternalIdentity(provider, subject string, userID int) error {
+	if provider == objects.EmptyString || subject == objects.EmptyString {
+		return appErrors.BadUserParamsErr
+	}
+	return uc.Repo.AddExternalIdentity(provider, subject, userID)
+}
+
 func (uc *UserController) UserExist(login string) bool {
 	var result bool
 	id, err := uc.Repo.GetUserID(login)
@@ -46,3 +86,54 @@ func (uc *UserController) UserExist(login string) bool {
 	}
 	return result
 }
+
+func (uc *UserController) CheckPassword(user objects.User, password string) (isEqual bool, needRehash bool) {
+	return uc.getHasher().Verify(user.GetPassword(), password)
+}
+
+// CheckPasswordOfUnknownUser takes as long as CheckPassword and always fails.
+func (uc *UserController) CheckPasswordOfUnknownUser(password string) {
+	_, _ = uc.getHasher().Verify(hashUtils.DummyHash, password)
+}
+
+func (uc *UserController) ChangePassword(id int, password string) error {
+	if password == objects.EmptyString {
+		return appErrors.BadUserParamsErr
+	}
+	passwordHash, err := uc.getHasher().Hash(password)
+	if err == nil {
+		err = uc.Repo.ChangePassword(id, passwordHash)
+	}
+	return err
+}
+
+func (uc *UserController) SetUserDisabled(id int, disabled bool) error {
+	_, err := uc.GetUser(id)
+	if err == nil {
+		err = uc.Repo.SetUserDisabled(id, disabled)
+	}
+	return err
+}
+
+func (uc *UserController) ChangeUserRole(id int, privelegeLevel objects.Levels) error {
+	if !IsValidLevel(privelegeLevel) {
+		return appErrors.BadRoleErr
+	}
+	_, err := uc.GetUser(id)
+	if err == nil {
+		err = uc.Repo.ChangeUserRole(id, privelegeLevel)
+	}
+	return err
+}
+
+// IsValidLevel accepts only the roles that can be stored, NonAuth is not a role of an account.
+func IsValidLevel(privelegeLevel objects.Levels) bool {
+	return privelegeLevel >= objects.StudentRole && privelegeLevel <= objects.ComendRole
+}
+
+func (uc *UserController) getHasher() hashUtils.PasswordHasher {
+	if uc.Hasher == nil {
+		return hashUtils.DefaultHasher
+	}
+	return uc.Hasher
+}
@@ -31,7 +31,8 @@ func (*TestUserController) TestUserController_AddUserPositive(t *testgroup.T) {
 	objectMother := mother.UserRepoObjectMother{}
 	db, mock := objectMother.CreateRepo()
 	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(sql.ErrNoRows)
-	mock.ExpectExec("INSERT INTO").WithArgs(mother.DefaultLogin, mother.DefaultPassword, objects.StudentRole).
+	mock.ExpectExec("INSERT INTO").WithArgs(mother.DefaultLogin,
+		tests.HashedPasswordArg{Password: mother.DefaultPassword}, objects.StudentRole).
 		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
 
 	repo := userRepo.PgUserRepo{Conn: db}
@@ -210,3 +211,255 @@ func (*TestUserController) TestUserController_GetUserIDNegative(t *testgroup.T)
 	tests.AssertErrors(t, execErr, appErrors.UserNotFoundErr)
 	tests.AssertMocks(t, mock)
 }
+
+func (*TestUserController) TestUserController_CheckPasswordHashed(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	user := objectMother.CreateDefaultUsersWithHash(1)[0]
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	controller := UserController{Repo: &repo}
+
+	// Act
+	isEqual, needRehash := controller.CheckPassword(user, mother.DefaultPassword)
+
+	// Assert
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, isEqual, true)
+	tests.AssertResult(t, needRehash, false)
+}
+
+func (*TestUserController) TestUserController_CheckPasswordLegacyPlaintext(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	user := objectMother.CreateUser()
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	controller := UserController{Repo: &repo}
+
+	// Act
+	isEqual, needRehash := controller.CheckPassword(user, mother.DefaultPassword)
+
+	// Assert
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, isEqual, true)
+	tests.AssertResult(t, needRehash, true)
+}
+
+func (*TestUserController) TestUserController_CheckPasswordNegative(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	user := objectMother.CreateDefaultUsersWithHash(1)[0]
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	controller := UserController{Repo: &repo}
+
+	// Act
+	isEqual, _ := controller.CheckPassword(user, mother.DefaultPassword+"1")
+
+	// Assert
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, isEqual, false)
+}
+
+func (*TestUserController) TestUserController_ChangePasswordPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	mock.ExpectExec("UPDATE").WithArgs(tests.HashedPasswordArg{Password: mother.DefaultPassword}, ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	controller := UserController{Repo: &repo}
+
+	// Act
+	execErr := controller.ChangePassword(ID, mother.DefaultPassword)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestUserController) TestUserController_ChangePasswordNegativeEmpty(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	controller := UserController{Repo: &repo}
+
+	// Act
+	execErr := controller.ChangePassword(ID, objects.EmptyString)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadUserParamsErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestUserController) TestUserController_AddUserNegativeBadRole(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	controller := UserController{Repo: &repo}
+
+	// Act
+	execErr := controller.AddUser(mother.DefaultLogin, mother.DefaultPassword, objects.NonAuth)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadRoleErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestUserController) TestUserController_SetUserDisabledPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	N := 1
+	ID := 1
+	rows := objectMother.CreateRows(objectMother.CreateDefaultUsers(N))
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(rows)
+	mock.ExpectExec("UPDATE").WithArgs(true, ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	controller := UserController{Repo: &repo}
+
+	// Act
+	execErr := controller.SetUserDisabled(ID, true)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestUserController) TestUserController_SetUserDisabledNegativeNotFound(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	rows := objectMother.CreateRows([]objects.User{})
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(rows)
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	controller := UserController{Repo: &repo}
+
+	// Act
+	execErr := controller.SetUserDisabled(ID, true)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.UserNotFoundErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestUserController) TestUserController_ChangeUserRolePositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	N := 1
+	ID := 1
+	rows := objectMother.CreateRows(objectMother.CreateDefaultUsers(N))
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(rows)
+	mock.ExpectExec("UPDATE").WithArgs(objects.SupplyRole, ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	controller := UserController{Repo: &repo}
+
+	// Act
+	execErr := controller.ChangeUserRole(ID, objects.SupplyRole)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestUserController) TestUserController_ChangeUserRoleNegativeBadRole(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	var badRole objects.Levels = 7
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	controller := UserController{Repo: &repo}
+
+	// Act
+	execErr := controller.ChangeUserRole(ID, badRole)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadRoleErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestUserController) TestUserController_GetUserByExternalIdentityPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	user := objectMother.CreateUserWithRole(ID, objects.StudentRole)
+	idRows := objectMother.CreateRowForID(ID)
+	userRows := objectMother.CreateRows([]objects.User{user})
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultProvider, mother.DefaultSubject).
+		WillReturnError(nil).WillReturnRows(idRows)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(userRows)
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	controller := UserController{Repo: &repo}
+
+	// Act
+	result, execErr := controller.GetUserByExternalIdentity(mother.DefaultProvider, mother.DefaultSubject)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result, user)
+}
+
+func (*TestUserController) TestUserController_GetUserByExternalIdentityNegativeNotLinked(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultProvider, mother.DefaultSubject).
+		WillReturnError(sql.ErrNoRows)
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	controller := UserController{Repo: &repo}
+
+	// Act
+	_, execErr := controller.GetUserByExternalIdentity(mother.DefaultProvider, mother.DefaultSubject)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.UserNotFoundErr)
+	tests.AssertMocks(t, mock)
+}
@@ -0,0 +1,40 @@
+package apiKeyManager
+
+import (
+	"src/logic/controllers/apiKeyController"
+	"src/objects"
+	appErrors "src/utils/error"
+)
+
+type APIKeyManager struct {
+	apiKeyController apiKeyController.APIKeyController
+}
+
+func CreateNewAPIKeyManager(kc apiKeyController.APIKeyController) *APIKeyManager {
+	return &APIKeyManager{apiKeyController: kc}
+}
+
+// CreateAPIKey mints a key for a script. Keys get staff roles only and never more than the caller has:
+// student endpoints check the owner of the data, which a key doesn't have. Keys can't mint other keys.
+func (km *APIKeyManager) CreateAPIKey(caller objects.Identity, name string, role objects.Levels,
+	readOnly bool) (int, string, error) {
+	if caller.GetUserID() == objects.None {
+		return objects.None, objects.EmptyString, appErrors.AccessDeniedErr
+	}
+	if role != objects.SupplyRole && role != objects.ComendRole || role > caller.GetRole() {
+		return objects.None, objects.EmptyString, appErrors.BadRoleErr
+	}
+	return km.apiKeyController.CreateAPIKey(name, role, readOnly, caller.GetUserID())
+}
+
+func (km *APIKeyManager) GetAPIKeys(page, size int) ([]objects.APIKey, error) {
+	return km.apiKeyController.GetAPIKeys(page, size)
+}
+
+func (km *APIKeyManager) RevokeAPIKey(id int) error {
+	return km.apiKeyController.RevokeAPIKey(id)
+}
+
+func (km *APIKeyManager) VerifyAPIKey(key string) (objects.APIKey, error) {
+	return km.apiKeyController.VerifyAPIKey(key)
+}
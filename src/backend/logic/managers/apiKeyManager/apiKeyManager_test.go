@@ -0,0 +1,88 @@
+package apiKeyManager
+
+import (
+	"database/sql"
+	"github.com/bloomberg/go-testgroup"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/db/apiKeyRepo"
+	"src/logic/controllers/apiKeyController"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	appErrors "src/utils/error"
+	"testing"
+	"time"
+)
+
+const InsertID int64 = 5
+
+type TestAPIKeyManager struct{}
+
+func Test_APIKeyManager(t *testing.T) {
+	testgroup.RunSerially(t, &TestAPIKeyManager{})
+}
+
+func createManager(db *sql.DB) *APIKeyManager {
+	repo := apiKeyRepo.PgAPIKeyRepo{Conn: db}
+	return CreateNewAPIKeyManager(apiKeyController.APIKeyController{Repo: &repo})
+}
+
+func (*TestAPIKeyManager) TestAPIKeyManager_CreateAPIKeyPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.APIKeyRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	callerID := 1
+	caller := objects.NewIdentityWithParams(callerID, mother.DefaultLogin, objects.ComendRole, objects.EmptyString)
+	rows := sqlmock.NewRows([]string{"id"}).AddRow(InsertID)
+	mock.ExpectQuery("INSERT INTO").
+		WithArgs(mother.DefaultAPIKeyName, sqlmock.AnyArg(), objects.ComendRole, false, callerID).
+		WillReturnError(nil).WillReturnRows(rows)
+
+	manager := createManager(db)
+
+	// Act
+	id, _, execErr := manager.CreateAPIKey(caller, mother.DefaultAPIKeyName, objects.ComendRole, false)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, id, int(InsertID))
+}
+
+func (*TestAPIKeyManager) TestAPIKeyManager_CreateAPIKeyNegativeStudentRole(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.APIKeyRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	caller := objects.NewIdentityWithParams(1, mother.DefaultLogin, objects.ComendRole, objects.EmptyString)
+
+	manager := createManager(db)
+
+	// Act
+	_, _, execErr := manager.CreateAPIKey(caller, mother.DefaultAPIKeyName, objects.StudentRole, false)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadRoleErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAPIKeyManager) TestAPIKeyManager_CreateAPIKeyNegativeKeyCaller(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.APIKeyRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	key := objectMother.CreateAPIKey(objects.ComendRole, false, time.Now(), false)
+
+	manager := createManager(db)
+
+	// Act
+	_, _, execErr := manager.CreateAPIKey(key.GetIdentity(), mother.DefaultAPIKeyName, objects.SupplyRole, false)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.AccessDeniedErr)
+	tests.AssertMocks(t, mock)
+}
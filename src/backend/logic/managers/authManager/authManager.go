@@ -1,48 +1,215 @@
 package authManager
 
 import (
+	"context"
+	"src/logic/controllers/attemptController"
+	"src/logic/controllers/tokenController"
 	"src/logic/controllers/userController"
+	"src/logic/managers/models"
 	"src/objects"
 	appErrors "src/utils/error"
+	"src/utils/hashUtils"
+	"src/utils/jwtUtils"
+	"time"
 )
 
+const PasswordResetTTL = 24 * time.Hour
+
 type AuthManager struct {
-	currentLogin   string
-	userController userController.UserController
+	userController    userController.UserController
+	tokenController   tokenController.TokenController
+	attemptController attemptController.AttemptController
+	keySet            *jwtUtils.KeySet
+	providers         map[string]AuthProvider
 }
 
-func CreateNewAuthManager(uc userController.UserController) *AuthManager {
-	return &AuthManager{
-		userController: uc,
+// CreateNewAuthManager registers external providers, the local one is always available.
+func CreateNewAuthManager(uc userController.UserController, tc tokenController.TokenController,
+	ac attemptController.AttemptController, keySet *jwtUtils.KeySet, providers ...AuthProvider) *AuthManager {
+	manager := &AuthManager{
+		userController:    uc,
+		tokenController:   tc,
+		attemptController: ac,
+		keySet:            keySet,
+		providers:         make(map[string]AuthProvider),
+	}
+	for _, provider := range providers {
+		manager.providers[provider.GetName()] = provider
 	}
+	return manager
 }
 
+// TryToAuth checks the password stored in the users table.
 func (am *AuthManager) TryToAuth(login, password string) (result objects.Levels, err error) {
-	isUserExist := am.userController.UserExist(login)
-	if isUserExist {
-		tmpID, getUserIDErr := am.userController.GetUserID(login)
-		if getUserIDErr == nil {
-			if tmpID != objects.None {
-				tmpUser, getUserErr := am.userController.GetUser(tmpID)
-				if getUserErr == nil {
-					if tmpUser.GetPassword() == password {
-						result = tmpUser.GetPrivelegeLevel()
-					} else {
-						err = appErrors.PasswordNotEqualErr
-					}
-				} else {
-					err = getUserErr
-				}
-			}
-		} else {
-			err = getUserIDErr
-		}
-	} else {
-		err = appErrors.UserNotFoundErr
+	identity, err := NewDBProvider(am.userController).Authenticate(login, password)
+	if err == nil {
+		result = identity.Role
 	}
 	return result, err
 }
 
+// Login checks the password with the provider, the local one is used if the provider is empty.
+// retryAfter is set with TooManyAttemptsErr.
+func (am *AuthManager) Login(providerName, login, password, clientIP string) (user objects.User,
+	retryAfter time.Duration, err error) {
+	user = objects.NewEmptyUser()
+	identity, retryAfter, err := am.authenticate(providerName, login, password, clientIP)
+	if err == nil {
+		user, err = am.resolveUser(identity)
+	}
+	return user, retryAfter, err
+}
+
+// LinkAccount links the account of the provider to the logged in local user. It is the only way to use
+// a provider for an existing local user: accounts are never linked by the same login, otherwise anybody
+// with the login in the directory would take the local account. Password providers check login and
+// password, redirect providers exchange the code.
+func (am *AuthManager) LinkAccount(ctx context.Context, userID int, providerName, login, password, code,
+	clientIP string) (retryAfter time.Duration, err error) {
+	if userID == objects.None {
+		return retryAfter, appErrors.AccessDeniedErr
+	}
+	if providerName == objects.EmptyString || providerName == LocalProvider {
+		return retryAfter, appErrors.UnknownProviderErr
+	}
+
+	var identity models.ExternalIdentity
+	if provider, isFound := am.providers[providerName].(RedirectAuthProvider); isFound {
+		identity, err = provider.Exchange(ctx, code)
+	} else {
+		identity, retryAfter, err = am.authenticate(providerName, login, password, clientIP)
+	}
+	if err != nil {
+		return retryAfter, err
+	}
+
+	_, err = am.userController.GetUserByExternalIdentity(identity.Provider, identity.Subject)
+	switch err {
+	case nil:
+		err = appErrors.ExternalLinkedErr
+	case appErrors.UserNotFoundErr:
+		err = am.userController.LinkExternalIdentity(identity.Provider, identity.Subject, userID)
+	}
+	return retryAfter, err
+}
+
+// authenticate is guarded by the failed attempts counters of the login and of the client address:
+// the attempt is counted before the check and returned if it is not a failure.
+func (am *AuthManager) authenticate(providerName, login, password, clientIP string) (
+	identity models.ExternalIdentity, retryAfter time.Duration, err error) {
+	provider, err := am.getPasswordProvider(providerName)
+	if err != nil {
+		return identity, retryAfter, err
+	}
+
+	retryAfter, err = am.attemptController.TakeAttempt(login, clientIP)
+	if err != nil {
+		return identity, retryAfter, err
+	}
+
+	identity, err = provider.Authenticate(login, password)
+	switch err {
+	case nil:
+		err = am.attemptController.RegisterSuccess(login, clientIP)
+	case appErrors.UserNotFoundErr, appErrors.PasswordNotEqualErr:
+		am.attemptController.RegisterFailure()
+	default:
+		_ = am.attemptController.CancelAttempt(login, clientIP)
+	}
+	return identity, retryAfter, err
+}
+
+// GetAuthURL returns the login page of the redirect provider, state is returned to the callback unchanged.
+func (am *AuthManager) GetAuthURL(providerName, state string) (string, error) {
+	provider, err := am.getRedirectProvider(providerName)
+	if err != nil {
+		return objects.EmptyString, err
+	}
+	return provider.GetAuthURL(state)
+}
+
+// LoginWithCode finishes the login with the code sent by the redirect provider to the callback.
+func (am *AuthManager) LoginWithCode(ctx context.Context, providerName, code string) (objects.User, error) {
+	provider, err := am.getRedirectProvider(providerName)
+	if err != nil {
+		return objects.NewEmptyUser(), err
+	}
+	identity, err := provider.Exchange(ctx, code)
+	if err != nil {
+		return objects.NewEmptyUser(), err
+	}
+	return am.resolveUser(identity)
+}
+
+func (am *AuthManager) getPasswordProvider(providerName string) (PasswordAuthProvider, error) {
+	if providerName == objects.EmptyString || providerName == LocalProvider {
+		return NewDBProvider(am.userController), nil
+	}
+	provider, isFound := am.providers[providerName].(PasswordAuthProvider)
+	if !isFound {
+		return nil, appErrors.UnknownProviderErr
+	}
+	return provider, nil
+}
+
+func (am *AuthManager) getRedirectProvider(providerName string) (RedirectAuthProvider, error) {
+	provider, isFound := am.providers[providerName].(RedirectAuthProvider)
+	if !isFound {
+		return nil, appErrors.UnknownProviderErr
+	}
+	return provider, nil
+}
+
+// resolveUser finds the local user of the account. The role of a linked user is managed locally,
+// the groups of the account only matter when the local user is created.
+func (am *AuthManager) resolveUser(identity models.ExternalIdentity) (objects.User, error) {
+	var user objects.User
+	var err error
+	if identity.Provider == LocalProvider {
+		user, err = am.userController.GetUser(identity.UserID)
+	} else {
+		user, err = am.userController.GetUserByExternalIdentity(identity.Provider, identity.Subject)
+		if err == appErrors.UserNotFoundErr {
+			user, err = am.linkUser(identity)
+		}
+	}
+	if err == nil && user.IsDisabled() {
+		err = appErrors.UserDisabledErr
+	}
+	return user, err
+}
+
+// linkUser creates a local user for an unknown account if the groups give a role. An existing local user
+// with the same login is not linked: its owner links the account with LinkAccount.
+func (am *AuthManager) linkUser(identity models.ExternalIdentity) (objects.User, error) {
+	userID, err := am.userController.GetUserID(identity.Login)
+	switch {
+	case err == nil:
+		err = appErrors.ExternalNotLinkedErr
+	case err == appErrors.UserNotFoundErr && identity.Role != objects.NonAuth:
+		userID, err = am.createExternalUser(identity)
+	}
+	if err == nil {
+		err = am.userController.LinkExternalIdentity(identity.Provider, identity.Subject, userID)
+	}
+	if err != nil {
+		return objects.NewEmptyUser(), err
+	}
+	return am.userController.GetUser(userID)
+}
+
+// createExternalUser sets a random password nobody knows, the user logs in only with the provider.
+func (am *AuthManager) createExternalUser(identity models.ExternalIdentity) (int, error) {
+	password, err := hashUtils.GenerateToken(hashUtils.DefaultTokenBytes)
+	if err == nil {
+		err = am.userController.AddUser(identity.Login, password, identity.Role)
+	}
+	if err != nil {
+		return objects.None, err
+	}
+	return am.userController.GetUserID(identity.Login)
+}
+
 func (am *AuthManager) GetUserID(login string) (int, error) {
 	var result = objects.None
 	var err error
@@ -55,6 +222,113 @@ func (am *AuthManager) GetUserID(login string) (int, error) {
 	return result, err
 }
 
-func (am *AuthManager) GetLogin() string {
-	return am.currentLogin
+func (am *AuthManager) CreateSession(login string, role objects.Levels) (session models.Session, err error) {
+	userID, err := am.GetUserID(login)
+	if err != nil {
+		return session, err
+	}
+
+	accessToken, claims, err := am.keySet.CreateJWTToken(userID, login, role)
+	if err == nil {
+		refreshToken, createErr := am.tokenController.CreateRefreshToken(userID, am.keySet.RefreshTokenTTL())
+		if createErr == nil {
+			session = models.Session{
+				AccessToken:  accessToken,
+				RefreshToken: refreshToken,
+				ExpiresAt:    claims.ExpiresAt,
+			}
+		} else {
+			err = createErr
+		}
+	}
+	return session, err
+}
+
+// RefreshSession exchanges a refresh token for a new token pair. The role is read from the base again,
+// so changes of user rights apply from the next refresh.
+func (am *AuthManager) RefreshSession(refreshToken string) (session models.Session, err error) {
+	userID, err := am.tokenController.UseRefreshToken(refreshToken)
+	if err == nil {
+		user, getUserErr := am.userController.GetUser(userID)
+		if getUserErr == nil && user.IsDisabled() {
+			err = appErrors.UserDisabledErr
+		} else if getUserErr == nil {
+			session, err = am.CreateSession(user.GetLogin(), user.GetPrivelegeLevel())
+		} else {
+			err = getUserErr
+		}
+	}
+	return session, err
+}
+
+func (am *AuthManager) Logout(accessToken, refreshToken string) error {
+	var err error
+	if accessToken != objects.EmptyString {
+		claims, parseErr := am.keySet.ParseJWT(accessToken)
+		if parseErr == nil {
+			err = am.tokenController.RevokeAccessToken(claims.TokenID, claims.ExpiresAt)
+		}
+	}
+	if err == nil && refreshToken != objects.EmptyString {
+		err = am.tokenController.RevokeRefreshToken(refreshToken)
+	}
+	return err
+}
+
+func (am *AuthManager) IsTokenRevoked(claims jwtUtils.Claims) bool {
+	return am.tokenController.IsAccessTokenRevoked(claims.TokenID, claims.UserID, claims.IssuedAt)
+}
+
+// VerifyAccessToken checks the signature of the token and that it was not revoked on logout.
+func (am *AuthManager) VerifyAccessToken(accessToken string) (jwtUtils.Claims, error) {
+	claims, err := am.keySet.ParseJWT(accessToken)
+	if err == nil && am.IsTokenRevoked(claims) {
+		err = appErrors.BadAccessTokenErr
+	}
+	return claims, err
+}
+
+func (am *AuthManager) GetJWKS() jwtUtils.JWKS {
+	return am.keySet.GetJWKS()
+}
+
+// ChangePassword requires the current password. All sessions of the user are closed afterwards.
+func (am *AuthManager) ChangePassword(userID int, oldPassword, newPassword string) error {
+	user, err := am.userController.GetUser(userID)
+	if err != nil {
+		return err
+	}
+
+	isEqual, _ := am.userController.CheckPassword(user, oldPassword)
+	if !isEqual {
+		return appErrors.PasswordNotEqualErr
+	}
+	err = am.userController.ChangePassword(userID, newPassword)
+	if err == nil {
+		err = am.tokenController.RevokeUserSessions(userID)
+	}
+	return err
+}
+
+// CreatePasswordReset issues a one-time token, which the commandant passes to the user.
+func (am *AuthManager) CreatePasswordReset(userID int) (string, time.Time, error) {
+	_, err := am.userController.GetUser(userID)
+	if err != nil {
+		return objects.EmptyString, time.Time{}, err
+	}
+	return am.tokenController.CreatePasswordResetToken(userID, PasswordResetTTL)
+}
+
+func (am *AuthManager) ResetPassword(resetToken, newPassword string) error {
+	if newPassword == objects.EmptyString {
+		return appErrors.BadUserParamsErr
+	}
+	userID, err := am.tokenController.UsePasswordResetToken(resetToken)
+	if err == nil {
+		err = am.userController.ChangePassword(userID, newPassword)
+	}
+	if err == nil {
+		err = am.tokenController.RevokeUserSessions(userID)
+	}
+	return err
 }
@@ -1,20 +1,67 @@
 package authManager
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"github.com/bloomberg/go-testgroup"
+	"github.com/go-ldap/ldap/v3"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/configs/backend"
+	"src/db/attemptRepo"
+	"src/db/tokenRepo"
 	"src/db/userRepo"
+	"src/logic/controllers/attemptController"
+	"src/logic/controllers/tokenController"
 	"src/logic/controllers/userController"
 	"src/objects"
 	"src/tests"
 	"src/tests/mother"
 	appErrors "src/utils/error"
+	"src/utils/hashUtils"
 	"testing"
 	"time"
 )
 
+const (
+	InsertID     = 5
+	RowsAffected = 1
+)
+
+var defaultUserDN = "uid=Ivan,ou=students,dc=bmstu,dc=ru"
+
 type TestAuthManager struct{}
 
+// stubLDAPConn accepts any service bind and the given password for the user entry.
+type stubLDAPConn struct {
+	password string
+	entry    *ldap.Entry
+}
+
+func (c *stubLDAPConn) Bind(username, password string) error {
+	if username == c.entry.DN && password != c.password {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+	}
+	return nil
+}
+
+func (c *stubLDAPConn) Search(_ *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	return &ldap.SearchResult{Entries: []*ldap.Entry{c.entry}}, nil
+}
+
+func (c *stubLDAPConn) Close() {}
+
+func createLDAPProvider() *LDAPAuthProvider {
+	provider := NewLDAPAuthProvider(configs.LDAPParams{BaseDN: "dc=bmstu,dc=ru"})
+	provider.dial = func() (ldapConn, error) {
+		return &stubLDAPConn{
+			password: mother.DefaultPassword,
+			entry:    ldap.NewEntry(defaultUserDN, map[string][]string{"uid": {mother.DefaultLogin}}),
+		}, nil
+	}
+	return provider
+}
+
 func Test_AuthManager(t *testing.T) {
 	testgroup.RunSerially(t, &TestAuthManager{})
 }
@@ -34,7 +81,6 @@ func (*TestAuthManager) TestAuthManager_GetUserIDPositive(t *testgroup.T) {
 	repo := userRepo.PgUserRepo{Conn: db}
 	controller := userController.UserController{Repo: &repo}
 	manager := AuthManager{
-		currentLogin:   mother.DefaultLogin,
 		userController: controller,
 	}
 
@@ -58,7 +104,6 @@ func (*TestAuthManager) TestAuthManager_GetUserIDNegative(t *testgroup.T) {
 	repo := userRepo.PgUserRepo{Conn: db}
 	controller := userController.UserController{Repo: &repo}
 	manager := AuthManager{
-		currentLogin:   mother.DefaultLogin,
 		userController: controller,
 	}
 
@@ -70,7 +115,7 @@ func (*TestAuthManager) TestAuthManager_GetUserIDNegative(t *testgroup.T) {
 	tests.AssertMocks(t, mock)
 }
 
-func (*TestAuthManager) TestAuthManager_TryToAuthPositive(t *testgroup.T) {
+func (*TestAuthManager) TestAuthManager_TryToAuthPositiveLegacyPassword(t *testgroup.T) {
 	defer tests.TimeTrack(time.Now())
 	// Arrange
 
@@ -86,11 +131,12 @@ func (*TestAuthManager) TestAuthManager_TryToAuthPositive(t *testgroup.T) {
 	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(nil).WillReturnRows(firstRows)
 	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(nil).WillReturnRows(secondRows)
 	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(thirdRows)
+	mock.ExpectExec("UPDATE").WithArgs(tests.HashedPasswordArg{Password: mother.DefaultPassword}, ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(int64(ID), int64(N)))
 
 	repo := userRepo.PgUserRepo{Conn: db}
 	controller := userController.UserController{Repo: &repo}
 	manager := AuthManager{
-		currentLogin:   mother.DefaultLogin,
 		userController: controller,
 	}
 
@@ -115,7 +161,6 @@ func (*TestAuthManager) TestAuthManager_TryToAuthNegativeUserNotFound(t *testgro
 	repo := userRepo.PgUserRepo{Conn: db}
 	controller := userController.UserController{Repo: &repo}
 	manager := AuthManager{
-		currentLogin:   mother.DefaultLogin,
 		userController: controller,
 	}
 
@@ -147,7 +192,6 @@ func (*TestAuthManager) TestAuthManager_TryToAuthNegativeBadPassword(t *testgrou
 	repo := userRepo.PgUserRepo{Conn: db}
 	controller := userController.UserController{Repo: &repo}
 	manager := AuthManager{
-		currentLogin:   mother.DefaultLogin,
 		userController: controller,
 	}
 
@@ -158,3 +202,748 @@ func (*TestAuthManager) TestAuthManager_TryToAuthNegativeBadPassword(t *testgrou
 	tests.AssertErrors(t, execErr, appErrors.PasswordNotEqualErr)
 	tests.AssertMocks(t, mock)
 }
+
+func (*TestAuthManager) TestAuthManager_TryToAuthPositiveHashedPassword(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	N := 1
+	firstRows := objectMother.CreateRowForID(ID)
+	secondRows := objectMother.CreateRowForID(ID)
+	users := objectMother.CreateDefaultUsersWithHash(N)
+	thirdRows := objectMother.CreateRows(users)
+
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(nil).WillReturnRows(firstRows)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(nil).WillReturnRows(secondRows)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(thirdRows)
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	controller := userController.UserController{Repo: &repo}
+	manager := AuthManager{
+		userController: controller,
+	}
+
+	// Act
+	role, execErr := manager.TryToAuth(mother.DefaultLogin, mother.DefaultPassword)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, role, objects.Levels(objects.StudentRole))
+}
+
+func (*TestAuthManager) TestAuthManager_TryToAuthNegativeBadHashedPassword(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	N := 1
+	firstRows := objectMother.CreateRowForID(ID)
+	secondRows := objectMother.CreateRowForID(ID)
+	users := objectMother.CreateDefaultUsersWithHash(N)
+	thirdRows := objectMother.CreateRows(users)
+
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(nil).WillReturnRows(firstRows)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(nil).WillReturnRows(secondRows)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(thirdRows)
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	controller := userController.UserController{Repo: &repo}
+	manager := AuthManager{
+		userController: controller,
+	}
+
+	// Act
+	_, execErr := manager.TryToAuth(mother.DefaultLogin, users[0].GetPassword())
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.PasswordNotEqualErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAuthManager) TestAuthManager_TryToAuthNegativeDisabled(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	N := 1
+	firstRows := objectMother.CreateRowForID(ID)
+	secondRows := objectMother.CreateRowForID(ID)
+	users := objectMother.CreateDefaultUsersWithHash(N)
+	users[0].SetDisabled(true)
+	thirdRows := objectMother.CreateRows(users)
+
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(nil).WillReturnRows(firstRows)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(nil).WillReturnRows(secondRows)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(thirdRows)
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	controller := userController.UserController{Repo: &repo}
+	manager := AuthManager{
+		userController: controller,
+	}
+
+	// Act
+	_, execErr := manager.TryToAuth(mother.DefaultLogin, mother.DefaultPassword)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.UserDisabledErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAuthManager) TestAuthManager_CreateSessionPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	tokenObjectMother := mother.TokenRepoObjectMother{}
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	firstRows := objectMother.CreateRowForID(ID)
+	secondRows := objectMother.CreateRowForID(ID)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(nil).WillReturnRows(firstRows)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(nil).WillReturnRows(secondRows)
+	mock.ExpectExec("INSERT INTO").WithArgs(ID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(int64(ID), int64(ID)))
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	tokenRepository := tokenRepo.PgTokenRepo{Conn: db}
+	keySet := tokenObjectMother.CreateKeySet(mother.DefaultKeyID)
+	manager := CreateNewAuthManager(userController.UserController{Repo: &repo},
+		tokenController.TokenController{Repo: &tokenRepository}, attemptController.AttemptController{}, keySet)
+
+	// Act
+	session, execErr := manager.CreateSession(mother.DefaultLogin, mother.DefaultRole)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	claims, parseErr := keySet.ParseJWT(session.AccessToken)
+	tests.AssertErrors(t, parseErr, nil)
+	tests.AssertResult(t, claims.UserID, ID)
+	tests.AssertResult(t, claims.Login, mother.DefaultLogin)
+	tests.AssertResult(t, claims.Role, mother.DefaultRole)
+	tests.AssertResult(t, session.RefreshToken != objects.EmptyString, true)
+}
+
+func (*TestAuthManager) TestAuthManager_RefreshSessionPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	tokenObjectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	N := 1
+	refreshToken := tokenObjectMother.CreateRefreshToken(ID, time.Now().Add(time.Hour), false)
+	tokenRows := tokenObjectMother.CreateRows([]objects.RefreshToken{refreshToken})
+	userRows := objectMother.CreateRows(objectMother.CreateDefaultUsers(N))
+	firstRows := objectMother.CreateRowForID(ID)
+	secondRows := objectMother.CreateRowForID(ID)
+
+	mock.ExpectQuery("SELECT").WithArgs(hashUtils.HashToken(mother.DefaultRefreshToken)).
+		WillReturnError(nil).WillReturnRows(tokenRows)
+	mock.ExpectExec("UPDATE").WithArgs(refreshToken.GetID()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(int64(ID), int64(N)))
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(userRows)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(nil).WillReturnRows(firstRows)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(nil).WillReturnRows(secondRows)
+	mock.ExpectExec("INSERT INTO").WithArgs(ID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(int64(ID), int64(N)))
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	tokenRepository := tokenRepo.PgTokenRepo{Conn: db}
+	keySet := tokenObjectMother.CreateKeySet(mother.DefaultKeyID)
+	manager := CreateNewAuthManager(userController.UserController{Repo: &repo},
+		tokenController.TokenController{Repo: &tokenRepository}, attemptController.AttemptController{}, keySet)
+
+	// Act
+	session, execErr := manager.RefreshSession(mother.DefaultRefreshToken)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, session.RefreshToken != mother.DefaultRefreshToken, true)
+}
+
+func (*TestAuthManager) TestAuthManager_RefreshSessionNegativeReused(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	tokenObjectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	refreshToken := tokenObjectMother.CreateRefreshToken(ID, time.Now().Add(time.Hour), true)
+	tokenRows := tokenObjectMother.CreateRows([]objects.RefreshToken{refreshToken})
+
+	mock.ExpectQuery("SELECT").WithArgs(hashUtils.HashToken(mother.DefaultRefreshToken)).
+		WillReturnError(nil).WillReturnRows(tokenRows)
+	mock.ExpectExec("UPDATE").WithArgs(ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(int64(ID), int64(ID)))
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	tokenRepository := tokenRepo.PgTokenRepo{Conn: db}
+	keySet := tokenObjectMother.CreateKeySet(mother.DefaultKeyID)
+	manager := CreateNewAuthManager(userController.UserController{Repo: &repo},
+		tokenController.TokenController{Repo: &tokenRepository}, attemptController.AttemptController{}, keySet)
+
+	// Act
+	_, execErr := manager.RefreshSession(mother.DefaultRefreshToken)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.RefreshTokenReusedErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAuthManager) TestAuthManager_LogoutPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	tokenObjectMother := mother.TokenRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	keySet := tokenObjectMother.CreateKeySet(mother.DefaultKeyID)
+	accessToken, claims, _ := keySet.CreateJWTToken(ID, mother.DefaultLogin, mother.DefaultRole)
+	refreshToken := tokenObjectMother.CreateRefreshToken(ID, time.Now().Add(time.Hour), false)
+	tokenRows := tokenObjectMother.CreateRows([]objects.RefreshToken{refreshToken})
+
+	mock.ExpectExec("INSERT INTO").WithArgs(claims.TokenID, sqlmock.AnyArg()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(int64(ID), int64(ID)))
+	mock.ExpectQuery("SELECT").WithArgs(hashUtils.HashToken(mother.DefaultRefreshToken)).
+		WillReturnError(nil).WillReturnRows(tokenRows)
+	mock.ExpectExec("UPDATE").WithArgs(refreshToken.GetID()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(int64(ID), int64(ID)))
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	tokenRepository := tokenRepo.PgTokenRepo{Conn: db}
+	manager := CreateNewAuthManager(userController.UserController{Repo: &repo},
+		tokenController.TokenController{Repo: &tokenRepository}, attemptController.AttemptController{}, keySet)
+
+	// Act
+	execErr := manager.Logout(accessToken, mother.DefaultRefreshToken)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAuthManager) TestAuthManager_VerifyAccessTokenPositiveRotatedKey(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	tokenObjectMother := mother.TokenRepoObjectMother{}
+	db, mock := tokenObjectMother.CreateRepo()
+	ID := 1
+	oldKeyID := "old-key"
+	oldKeySet := tokenObjectMother.CreateKeySet(oldKeyID)
+	accessToken, claims, _ := oldKeySet.CreateJWTToken(ID, mother.DefaultLogin, mother.DefaultRole)
+	countRows := tokenObjectMother.CreateCountRows(objects.Null)
+	mock.ExpectQuery("SELECT").WithArgs(claims.TokenID, ID, claims.IssuedAt).WillReturnError(nil).WillReturnRows(countRows)
+
+	tokenRepository := tokenRepo.PgTokenRepo{Conn: db}
+	manager := CreateNewAuthManager(userController.UserController{},
+		tokenController.TokenController{Repo: &tokenRepository},
+		attemptController.AttemptController{}, tokenObjectMother.CreateKeySet(mother.DefaultKeyID, oldKeyID))
+
+	// Act
+	result, execErr := manager.VerifyAccessToken(accessToken)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result.Login, mother.DefaultLogin)
+	tests.AssertResult(t, result.UserID, ID)
+}
+
+func (*TestAuthManager) TestAuthManager_VerifyAccessTokenNegativeUnknownKey(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	tokenObjectMother := mother.TokenRepoObjectMother{}
+	db, mock := tokenObjectMother.CreateRepo()
+	ID := 1
+	accessToken, _, _ := tokenObjectMother.CreateKeySet("old-key").
+		CreateJWTToken(ID, mother.DefaultLogin, mother.DefaultRole)
+
+	tokenRepository := tokenRepo.PgTokenRepo{Conn: db}
+	manager := CreateNewAuthManager(userController.UserController{},
+		tokenController.TokenController{Repo: &tokenRepository},
+		attemptController.AttemptController{}, tokenObjectMother.CreateKeySet(mother.DefaultKeyID))
+
+	// Act
+	_, execErr := manager.VerifyAccessToken(accessToken)
+
+	// Assert
+	tests.AssertResult(t, execErr != nil, true)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAuthManager) TestAuthManager_ChangePasswordPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	newPassword := "new-password"
+	userRows := objectMother.CreateRows(objectMother.CreateDefaultUsersWithHash(ID))
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(userRows)
+	mock.ExpectExec("UPDATE").WithArgs(tests.HashedPasswordArg{Password: newPassword}, ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("UPDATE").WithArgs(ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").WithArgs(ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	userRepository := userRepo.PgUserRepo{Conn: db}
+	tokenRepository := tokenRepo.PgTokenRepo{Conn: db}
+	manager := AuthManager{
+		userController:  userController.UserController{Repo: &userRepository},
+		tokenController: tokenController.TokenController{Repo: &tokenRepository},
+	}
+
+	// Act
+	execErr := manager.ChangePassword(ID, mother.DefaultPassword, newPassword)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAuthManager) TestAuthManager_ChangePasswordNegativeWrongOld(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	userRows := objectMother.CreateRows(objectMother.CreateDefaultUsersWithHash(ID))
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(userRows)
+
+	userRepository := userRepo.PgUserRepo{Conn: db}
+	tokenRepository := tokenRepo.PgTokenRepo{Conn: db}
+	manager := AuthManager{
+		userController:  userController.UserController{Repo: &userRepository},
+		tokenController: tokenController.TokenController{Repo: &tokenRepository},
+	}
+
+	// Act
+	execErr := manager.ChangePassword(ID, "wrong-password", "new-password")
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.PasswordNotEqualErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAuthManager) TestAuthManager_CreatePasswordResetPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	userRows := objectMother.CreateRows(objectMother.CreateDefaultUsers(ID))
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(userRows)
+	mock.ExpectExec("UPDATE").WithArgs(ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").WithArgs(ID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	userRepository := userRepo.PgUserRepo{Conn: db}
+	tokenRepository := tokenRepo.PgTokenRepo{Conn: db}
+	manager := AuthManager{
+		userController:  userController.UserController{Repo: &userRepository},
+		tokenController: tokenController.TokenController{Repo: &tokenRepository},
+	}
+
+	// Act
+	resetToken, expiresAt, execErr := manager.CreatePasswordReset(ID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, resetToken != objects.EmptyString, true)
+	tests.AssertResult(t, expiresAt.After(time.Now()), true)
+}
+
+func (*TestAuthManager) TestAuthManager_CreatePasswordResetNegativeNoUser(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(sql.ErrNoRows)
+
+	userRepository := userRepo.PgUserRepo{Conn: db}
+	manager := AuthManager{
+		userController: userController.UserController{Repo: &userRepository},
+	}
+
+	// Act
+	_, _, execErr := manager.CreatePasswordReset(ID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.UserNotFoundErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAuthManager) TestAuthManager_ResetPasswordPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	tokenObjectMother := mother.TokenRepoObjectMother{}
+	db, mock := tokenObjectMother.CreateRepo()
+	ID := 1
+	newPassword := "new-password"
+	resetToken := tokenObjectMother.CreatePasswordResetToken(ID, time.Now().Add(time.Hour), false)
+	resetRows := tokenObjectMother.CreatePasswordResetRows([]objects.PasswordResetToken{resetToken})
+	mock.ExpectQuery("SELECT").WithArgs(hashUtils.HashToken(mother.DefaultResetToken)).
+		WillReturnError(nil).WillReturnRows(resetRows)
+	mock.ExpectExec("UPDATE").WithArgs(resetToken.GetID()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("UPDATE").WithArgs(tests.HashedPasswordArg{Password: newPassword}, ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("UPDATE").WithArgs(ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").WithArgs(ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	userRepository := userRepo.PgUserRepo{Conn: db}
+	tokenRepository := tokenRepo.PgTokenRepo{Conn: db}
+	manager := AuthManager{
+		userController:  userController.UserController{Repo: &userRepository},
+		tokenController: tokenController.TokenController{Repo: &tokenRepository},
+	}
+
+	// Act
+	execErr := manager.ResetPassword(mother.DefaultResetToken, newPassword)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAuthManager) TestAuthManager_ResetPasswordNegativeUsedToken(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	tokenObjectMother := mother.TokenRepoObjectMother{}
+	db, mock := tokenObjectMother.CreateRepo()
+	ID := 1
+	resetToken := tokenObjectMother.CreatePasswordResetToken(ID, time.Now().Add(time.Hour), false)
+	resetRows := tokenObjectMother.CreatePasswordResetRows([]objects.PasswordResetToken{resetToken})
+	mock.ExpectQuery("SELECT").WithArgs(hashUtils.HashToken(mother.DefaultResetToken)).
+		WillReturnError(nil).WillReturnRows(resetRows)
+	mock.ExpectExec("UPDATE").WithArgs(resetToken.GetID()).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, objects.Null))
+
+	tokenRepository := tokenRepo.PgTokenRepo{Conn: db}
+	manager := AuthManager{
+		tokenController: tokenController.TokenController{Repo: &tokenRepository},
+	}
+
+	// Act
+	execErr := manager.ResetPassword(mother.DefaultResetToken, "new-password")
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadResetTokenErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAuthManager) TestAuthManager_LoginNegativeUserNotFound(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(sql.ErrNoRows)
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	attemptRepository := attemptRepo.NewMemoryAttemptRepo()
+	manager := AuthManager{
+		userController:    userController.UserController{Repo: &repo},
+		attemptController: attemptController.AttemptController{Repo: attemptRepository},
+	}
+
+	// Act
+	_, _, execErr := manager.Login(LocalProvider, mother.DefaultLogin, mother.DefaultPassword, mother.DefaultClientIP)
+	attempts, _ := attemptRepository.GetAttempts(mother.DefaultIPKey)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.UserNotFoundErr)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, attempts.GetFailures(), 1)
+}
+
+func (*TestAuthManager) TestAuthManager_LoginNegativeTooManyAttempts(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	attemptRepository := attemptRepo.NewMemoryAttemptRepo()
+	for i := 0; i < 3; i++ {
+		_, _, _ = attemptRepository.TakeAttempt(mother.DefaultLoginKey, mother.DefaultAttemptLimits)
+	}
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	manager := AuthManager{
+		userController: userController.UserController{Repo: &repo},
+		attemptController: attemptController.AttemptController{Repo: attemptRepository,
+			Params: configs.LoginThrottleParams{FreeAttempts: 2}},
+	}
+
+	// Act
+	_, retryAfter, execErr := manager.Login(LocalProvider, mother.DefaultLogin, mother.DefaultPassword, mother.DefaultClientIP)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.TooManyAttemptsErr)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, retryAfter > 0, true)
+}
+
+func (*TestAuthManager) TestAuthManager_LoginNegativeLDAPNotLinked(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	idRows := objectMother.CreateRowForID(ID)
+	mock.ExpectQuery("SELECT").WithArgs(LDAPProvider, defaultUserDN).WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(nil).WillReturnRows(idRows)
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	manager := CreateNewAuthManager(userController.UserController{Repo: &repo}, tokenController.TokenController{},
+		attemptController.AttemptController{Repo: attemptRepo.NewMemoryAttemptRepo()}, nil, createLDAPProvider())
+
+	// Act
+	_, _, execErr := manager.Login(LDAPProvider, mother.DefaultLogin, mother.DefaultPassword,
+		mother.DefaultClientIP)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.ExternalNotLinkedErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAuthManager) TestAuthManager_LoginPositiveLDAPLinked(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	user := objectMother.CreateUserWithRole(ID, objects.SupplyRole)
+	idRows := objectMother.CreateRowForID(ID)
+	userRows := objectMother.CreateRows([]objects.User{user})
+	mock.ExpectQuery("SELECT").WithArgs(LDAPProvider, defaultUserDN).WillReturnError(nil).WillReturnRows(idRows)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(userRows)
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	manager := CreateNewAuthManager(userController.UserController{Repo: &repo}, tokenController.TokenController{},
+		attemptController.AttemptController{Repo: attemptRepo.NewMemoryAttemptRepo()}, nil, createLDAPProvider())
+
+	// Act
+	result, _, execErr := manager.Login(LDAPProvider, mother.DefaultLogin, mother.DefaultPassword,
+		mother.DefaultClientIP)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result, user)
+}
+
+func (*TestAuthManager) TestAuthManager_LinkAccountPositiveLDAP(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	mock.ExpectQuery("SELECT").WithArgs(LDAPProvider, defaultUserDN).WillReturnError(sql.ErrNoRows)
+	mock.ExpectExec("INSERT INTO").WithArgs(LDAPProvider, defaultUserDN, ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	manager := CreateNewAuthManager(userController.UserController{Repo: &repo}, tokenController.TokenController{},
+		attemptController.AttemptController{Repo: attemptRepo.NewMemoryAttemptRepo()}, nil, createLDAPProvider())
+
+	// Act
+	_, execErr := manager.LinkAccount(context.Background(), ID, LDAPProvider, mother.DefaultLogin,
+		mother.DefaultPassword, objects.EmptyString, mother.DefaultClientIP)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAuthManager) TestAuthManager_LinkAccountNegativeLinked(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	user := objectMother.CreateUserWithRole(2, objects.StudentRole)
+	idRows := objectMother.CreateRowForID(2)
+	userRows := objectMother.CreateRows([]objects.User{user})
+	mock.ExpectQuery("SELECT").WithArgs(LDAPProvider, defaultUserDN).WillReturnError(nil).WillReturnRows(idRows)
+	mock.ExpectQuery("SELECT").WithArgs(2).WillReturnError(nil).WillReturnRows(userRows)
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	manager := CreateNewAuthManager(userController.UserController{Repo: &repo}, tokenController.TokenController{},
+		attemptController.AttemptController{Repo: attemptRepo.NewMemoryAttemptRepo()}, nil, createLDAPProvider())
+
+	// Act
+	_, execErr := manager.LinkAccount(context.Background(), ID, LDAPProvider, mother.DefaultLogin,
+		mother.DefaultPassword, objects.EmptyString, mother.DefaultClientIP)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.ExternalLinkedErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAuthManager) TestAuthManager_LoginNegativeLDAPWrongPassword(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	manager := CreateNewAuthManager(userController.UserController{Repo: &repo}, tokenController.TokenController{},
+		attemptController.AttemptController{Repo: attemptRepo.NewMemoryAttemptRepo()}, nil, createLDAPProvider())
+
+	// Act
+	_, _, execErr := manager.Login(LDAPProvider, mother.DefaultLogin, "wrong-password", mother.DefaultClientIP)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.PasswordNotEqualErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestAuthManager) TestAuthManager_LoginNegativeUnknownProvider(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	manager := CreateNewAuthManager(userController.UserController{}, tokenController.TokenController{},
+		attemptController.AttemptController{}, nil)
+
+	// Act
+	_, _, execErr := manager.Login(LDAPProvider, mother.DefaultLogin, mother.DefaultPassword, mother.DefaultClientIP)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.UnknownProviderErr)
+}
+
+func (*TestAuthManager) TestAuthManager_LoginWithCodePositiveLinked(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	idp := tests.NewStubIdP(map[string]interface{}{
+		"sub":                mother.DefaultSubject,
+		"preferred_username": mother.DefaultLogin,
+	})
+	defer idp.Close()
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	user := objectMother.CreateUserWithRole(ID, objects.StudentRole)
+	idRows := objectMother.CreateRowForID(ID)
+	userRows := objectMother.CreateRows([]objects.User{user})
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultProvider, mother.DefaultSubject).
+		WillReturnError(nil).WillReturnRows(idRows)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(userRows)
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	provider := NewOIDCAuthProvider(configs.OIDCParams{Name: mother.DefaultProvider, Issuer: idp.URL,
+		ClientID: tests.StubIdPClientID})
+	manager := CreateNewAuthManager(userController.UserController{Repo: &repo}, tokenController.TokenController{},
+		attemptController.AttemptController{}, nil, provider)
+
+	// Act
+	result, execErr := manager.LoginWithCode(context.Background(), mother.DefaultProvider, tests.StubIdPCode)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result, user)
+}
+
+func (*TestAuthManager) TestAuthManager_LoginWithCodePositiveNewUser(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	idp := tests.NewStubIdP(map[string]interface{}{
+		"sub":                mother.DefaultSubject,
+		"preferred_username": mother.DefaultLogin,
+		"groups":             []string{"students", "staff"},
+	})
+	defer idp.Close()
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+	user := objectMother.CreateUserWithRole(ID, objects.SupplyRole)
+	idRows := objectMother.CreateRowForID(ID)
+	userRows := objectMother.CreateRows([]objects.User{user})
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultProvider, mother.DefaultSubject).WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(sql.ErrNoRows)
+	mock.ExpectExec("INSERT INTO").WithArgs(mother.DefaultLogin, sqlmock.AnyArg(), objects.SupplyRole).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(nil).WillReturnRows(idRows)
+	mock.ExpectExec("INSERT INTO").WithArgs(mother.DefaultProvider, mother.DefaultSubject, ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(userRows)
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	provider := NewOIDCAuthProvider(configs.OIDCParams{Name: mother.DefaultProvider, Issuer: idp.URL,
+		ClientID:   tests.StubIdPClientID,
+		RoleGroups: map[string]int{"students": objects.StudentRole, "staff": objects.SupplyRole}})
+	manager := CreateNewAuthManager(userController.UserController{Repo: &repo}, tokenController.TokenController{},
+		attemptController.AttemptController{}, nil, provider)
+
+	// Act
+	result, execErr := manager.LoginWithCode(context.Background(), mother.DefaultProvider, tests.StubIdPCode)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result, user)
+}
+
+func (*TestAuthManager) TestAuthManager_LoginWithCodeNegativeBadCode(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	idp := tests.NewStubIdP(map[string]interface{}{"sub": mother.DefaultSubject})
+	defer idp.Close()
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+
+	repo := userRepo.PgUserRepo{Conn: db}
+	provider := NewOIDCAuthProvider(configs.OIDCParams{Name: mother.DefaultProvider, Issuer: idp.URL,
+		ClientID: tests.StubIdPClientID})
+	manager := CreateNewAuthManager(userController.UserController{Repo: &repo}, tokenController.TokenController{},
+		attemptController.AttemptController{}, nil, provider)
+
+	// Act
+	_, execErr := manager.LoginWithCode(context.Background(), mother.DefaultProvider, "wrong-code")
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.ExternalAuthErr)
+	tests.AssertMocks(t, mock)
+}
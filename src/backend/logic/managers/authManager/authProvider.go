@@ -0,0 +1,94 @@
+package authManager
+
+import (
+	"context"
+	"src/logic/controllers/userController"
+	"src/logic/managers/models"
+	"src/objects"
+	appErrors "src/utils/error"
+)
+
+const LocalProvider = "local"
+
+// AuthProvider is a source of accounts the users can log in with.
+type AuthProvider interface {
+	GetName() string
+}
+
+// PasswordAuthProvider checks login and password itself, e.g. the users table or LDAP bind.
+type PasswordAuthProvider interface {
+	AuthProvider
+	Authenticate(login, password string) (models.ExternalIdentity, error)
+}
+
+// RedirectAuthProvider sends the user to the login page of the provider and gets
+// the account by the code returned to the callback, e.g. OIDC authorization code flow.
+type RedirectAuthProvider interface {
+	AuthProvider
+	GetAuthURL(state string) (string, error)
+	Exchange(ctx context.Context, code string) (models.ExternalIdentity, error)
+}
+
+// DBProvider checks passwords stored in the users table.
+type DBProvider struct {
+	userController userController.UserController
+}
+
+func NewDBProvider(uc userController.UserController) *DBProvider {
+	return &DBProvider{userController: uc}
+}
+
+func (p *DBProvider) GetName() string {
+	return LocalProvider
+}
+
+func (p *DBProvider) Authenticate(login, password string) (result models.ExternalIdentity, err error) {
+	isUserExist := p.userController.UserExist(login)
+	if isUserExist {
+		tmpID, getUserIDErr := p.userController.GetUserID(login)
+		if getUserIDErr == nil {
+			if tmpID != objects.None {
+				tmpUser, getUserErr := p.userController.GetUser(tmpID)
+				if getUserErr == nil {
+					isEqual, needRehash := p.userController.CheckPassword(tmpUser, password)
+					if isEqual && tmpUser.IsDisabled() {
+						err = appErrors.UserDisabledErr
+					} else if isEqual {
+						result = models.ExternalIdentity{
+							Provider: LocalProvider,
+							Login:    tmpUser.GetLogin(),
+							Role:     tmpUser.GetPrivelegeLevel(),
+							UserID:   tmpID,
+						}
+						if needRehash {
+							// Legacy plaintext rows are upgraded here; on failure the upgrade is retried next login.
+							_ = p.userController.ChangePassword(tmpID, password)
+						}
+					} else {
+						err = appErrors.PasswordNotEqualErr
+					}
+				} else {
+					err = getUserErr
+				}
+			}
+		} else {
+			err = getUserIDErr
+		}
+	} else {
+		p.userController.CheckPasswordOfUnknownUser(password)
+		err = appErrors.UserNotFoundErr
+	}
+	return result, err
+}
+
+// mapRole chooses the highest role of the groups, NonAuth means that the groups give no role.
+func mapRole(groups []string, roleGroups map[string]int) objects.Levels {
+	var result = objects.Levels(objects.NonAuth)
+	for _, group := range groups {
+		role, isFound := roleGroups[group]
+		if isFound && objects.Levels(role) > result && userController.IsValidLevel(objects.Levels(role)) {
+			result = objects.Levels(role)
+		}
+	}
+	return result
+}
@@ -0,0 +1,127 @@
+package authManager
+
+import (
+	"fmt"
+	"github.com/go-ldap/ldap/v3"
+	"src/configs/backend"
+	"src/logic/managers/models"
+	"src/objects"
+	appErrors "src/utils/error"
+)
+
+const (
+	LDAPProvider = "ldap"
+
+	defaultUserFilter     = "(uid=%s)"
+	defaultLoginAttribute = "uid"
+	defaultGroupAttribute = "memberOf"
+)
+
+// ldapConn is the part of *ldap.Conn used by the provider, tests replace it with a stub.
+type ldapConn interface {
+	Bind(username, password string) error
+	Search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error)
+	Close()
+}
+
+// LDAPAuthProvider finds the entry of the user with the service account and checks
+// the password by binding as this entry.
+type LDAPAuthProvider struct {
+	params configs.LDAPParams
+	dial   func() (ldapConn, error)
+}
+
+func NewLDAPAuthProvider(params configs.LDAPParams) *LDAPAuthProvider {
+	return &LDAPAuthProvider{
+		params: params,
+		dial: func() (ldapConn, error) {
+			return ldap.DialURL(params.URL)
+		},
+	}
+}
+
+func (p *LDAPAuthProvider) GetName() string {
+	return LDAPProvider
+}
+
+func (p *LDAPAuthProvider) Authenticate(login, password string) (models.ExternalIdentity, error) {
+	var result models.ExternalIdentity
+	// LDAP servers treat a bind with an empty password as an anonymous bind, which always succeeds.
+	if login == objects.EmptyString || password == objects.EmptyString {
+		return result, appErrors.PasswordNotEqualErr
+	}
+
+	conn, err := p.dial()
+	if err != nil {
+		return result, err
+	}
+	defer conn.Close()
+
+	if p.params.BindDN != objects.EmptyString {
+		err = conn.Bind(p.params.BindDN, p.params.BindPassword)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	entry, err := p.findUser(conn, login)
+	if err != nil {
+		return result, err
+	}
+
+	err = conn.Bind(entry.DN, password)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return result, appErrors.PasswordNotEqualErr
+	} else if err != nil {
+		return result, err
+	}
+
+	result = models.ExternalIdentity{
+		Provider: LDAPProvider,
+		Subject:  entry.DN,
+		Login:    entry.GetAttributeValue(p.getLoginAttribute()),
+		Role:     mapRole(entry.GetAttributeValues(p.getGroupAttribute()), p.params.RoleGroups),
+		UserID:   objects.None,
+	}
+	if result.Login == objects.EmptyString {
+		result.Login = login
+	}
+	return result, nil
+}
+
+func (p *LDAPAuthProvider) findUser(conn ldapConn, login string) (*ldap.Entry, error) {
+	request := ldap.NewSearchRequest(p.params.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		2, 0, false, fmt.Sprintf(p.getUserFilter(), ldap.EscapeFilter(login)),
+		[]string{p.getLoginAttribute(), p.getGroupAttribute()}, nil)
+	result, err := conn.Search(request)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, appErrors.UserNotFoundErr
+	} else if err != nil {
+		return nil, err
+	}
+	if len(result.Entries) != 1 {
+		return nil, appErrors.UserNotFoundErr
+	}
+	return result.Entries[objects.Null], nil
+}
+
+func (p *LDAPAuthProvider) getUserFilter() string {
+	if p.params.UserFilter == objects.EmptyString {
+		return defaultUserFilter
+	}
+	return p.params.UserFilter
+}
+
+func (p *LDAPAuthProvider) getLoginAttribute() string {
+	if p.params.LoginAttribute == objects.EmptyString {
+		return defaultLoginAttribute
+	}
+	return p.params.LoginAttribute
+}
+
+func (p *LDAPAuthProvider) getGroupAttribute() string {
+	if p.params.GroupAttribute == objects.EmptyString {
+		return defaultGroupAttribute
+	}
+	return p.params.GroupAttribute
+}
@@ -0,0 +1,138 @@
+package authManager
+
+import (
+	"context"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+	"src/configs/backend"
+	"src/logic/managers/models"
+	"src/objects"
+	appErrors "src/utils/error"
+	"sync"
+)
+
+const (
+	defaultOIDCProvider = "sso"
+	defaultLoginClaim   = "preferred_username"
+	defaultGroupsClaim  = "groups"
+)
+
+// OIDCAuthProvider implements authorization code flow. The discovery document of the issuer
+// is loaded on the first use, so the server starts even if the provider is unavailable.
+type OIDCAuthProvider struct {
+	params      configs.OIDCParams
+	mutex       sync.Mutex
+	oauthConfig *oauth2.Config
+	verifier    *oidc.IDTokenVerifier
+}
+
+func NewOIDCAuthProvider(params configs.OIDCParams) *OIDCAuthProvider {
+	return &OIDCAuthProvider{params: params}
+}
+
+func (p *OIDCAuthProvider) GetName() string {
+	if p.params.Name == objects.EmptyString {
+		return defaultOIDCProvider
+	}
+	return p.params.Name
+}
+
+func (p *OIDCAuthProvider) GetAuthURL(state string) (string, error) {
+	oauthConfig, _, err := p.getClients(context.Background())
+	if err != nil {
+		return objects.EmptyString, err
+	}
+	return oauthConfig.AuthCodeURL(state), nil
+}
+
+func (p *OIDCAuthProvider) Exchange(ctx context.Context, code string) (models.ExternalIdentity, error) {
+	var result models.ExternalIdentity
+	oauthConfig, verifier, err := p.getClients(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	token, err := oauthConfig.Exchange(ctx, code)
+	if err != nil {
+		return result, appErrors.ExternalAuthErr
+	}
+	rawIDToken, isFound := token.Extra("id_token").(string)
+	if !isFound {
+		return result, appErrors.ExternalAuthErr
+	}
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return result, appErrors.ExternalAuthErr
+	}
+
+	var claims map[string]interface{}
+	err = idToken.Claims(&claims)
+	if err != nil {
+		return result, err
+	}
+	login, _ := claims[p.getLoginClaim()].(string)
+	if login == objects.EmptyString {
+		return result, appErrors.ExternalAuthErr
+	}
+
+	result = models.ExternalIdentity{
+		Provider: p.GetName(),
+		Subject:  idToken.Subject,
+		Login:    login,
+		Role:     mapRole(getStringsClaim(claims[p.getGroupsClaim()]), p.params.RoleGroups),
+		UserID:   objects.None,
+	}
+	return result, nil
+}
+
+func (p *OIDCAuthProvider) getClients(ctx context.Context) (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.verifier == nil {
+		provider, err := oidc.NewProvider(ctx, p.params.Issuer)
+		if err != nil {
+			return nil, nil, err
+		}
+		scopes := append([]string{oidc.ScopeOpenID}, p.params.Scopes...)
+		p.oauthConfig = &oauth2.Config{
+			ClientID:     p.params.ClientID,
+			ClientSecret: p.params.ClientSecret,
+			RedirectURL:  p.params.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       scopes,
+		}
+		p.verifier = provider.Verifier(&oidc.Config{ClientID: p.params.ClientID})
+	}
+	return p.oauthConfig, p.verifier, nil
+}
+
+func (p *OIDCAuthProvider) getLoginClaim() string {
+	if p.params.LoginClaim == objects.EmptyString {
+		return defaultLoginClaim
+	}
+	return p.params.LoginClaim
+}
+
+func (p *OIDCAuthProvider) getGroupsClaim() string {
+	if p.params.GroupsClaim == objects.EmptyString {
+		return defaultGroupsClaim
+	}
+	return p.params.GroupsClaim
+}
+
+// getStringsClaim accepts both a list of groups and a single group.
+func getStringsClaim(claim interface{}) []string {
+	var result []string
+	switch value := claim.(type) {
+	case string:
+		result = append(result, value)
+	case []interface{}:
+		for _, item := range value {
+			if str, isString := item.(string); isString {
+				result = append(result, str)
+			}
+		}
+	}
+	return result
+}
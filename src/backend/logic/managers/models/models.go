@@ -1,11 +1,136 @@
 package models
 
-import "src/objects"
+import (
+	"src/objects"
+	"time"
+)
 
 type StudentFullInfo struct {
 	Student objects.Student `json:"student"`
 }
 
+// StudentChanges holds new student data, nil fields are left unchanged. DateOfBirth is in DateFormat.
+type StudentChanges struct {
+	Name          *string
+	Surname       *string
+	Patronymic    *string
+	StudentGroup  *string
+	StudentNumber *string
+	Gender        *objects.Gender
+	Faculty       *string
+	Course        *int
+	Phone         *string
+	Email         *string
+	DateOfBirth   *string
+}
+
+// FieldError names an invalid field of the request and the reason it is rejected.
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+// ClearanceReport lists what keeps the student from leaving the dormitory: the room, unreturned things
+// and unpaid fees. Document is the signed clearance, it is issued only when there are no blockers.
+type ClearanceReport struct {
+	Student  objects.Student
+	RoomID   int
+	Things   []objects.Thing
+	Fees     []objects.Fee
+	Document string
+	IssuedAt time.Time
+}
+
+func (cr ClearanceReport) HasBlockers() bool {
+	return cr.RoomID != objects.NotLiving || len(cr.Things) != objects.Empty || len(cr.Fees) != objects.Empty
+}
+
+// StudentImportRow is a row of the import file. Line counts the header, so it matches the line in the editor.
+type StudentImportRow struct {
+	Line          int
+	Login         string
+	Password      string
+	Name          string
+	Surname       string
+	Group         string
+	StudentNumber string
+	Gender        objects.Gender
+}
+
+// ImportRowError lists all invalid fields of a row of the import file.
+type ImportRowError struct {
+	Line   int
+	Fields []FieldError
+}
+
+// ImportedStudent is the account of an imported student. Password is empty in dry-run.
+type ImportedStudent struct {
+	Line          int
+	StudentNumber string
+	Login         string
+	Password      string
+}
+
+// StudentImportResult lists the students that are (or in dry-run would be) added and the rows that are skipped.
+type StudentImportResult struct {
+	Total    int
+	Students []ImportedStudent
+	Errors   []ImportRowError
+}
+
+// RoomChanges holds new room params, nil fields are left unchanged.
+type RoomChanges struct {
+	RoomType   *string
+	RoomNumber *int
+	Capacity   *int
+	Gender     *objects.Gender
+	BuildingID *int
+	Floor      *int
+	Block      *string
+}
+
+// RoomFullInfo is a room with its current residents and things located there, for room inspections.
+type RoomFullInfo struct {
+	Room      objects.Room
+	Residents []objects.Student
+	Things    []RoomThing
+}
+
+// RoomThing is a thing with its owner, Owner is an empty student if the thing is not given to anyone.
+type RoomThing struct {
+	Thing objects.Thing
+	Owner objects.Student
+}
+
+// SettlementRequestInfo is a settlement request with all its status transitions.
+type SettlementRequestInfo struct {
+	Request objects.SettlementRequest
+	History []objects.SettlementStatusChange
+}
+
 type ThingFullInfo struct {
 	Thing objects.Thing `json:"thing"`
 }
+
+// ThingHistory is the whole lifecycle of a thing: owner periods and all acts in date order.
+type ThingHistory struct {
+	Thing  objects.Thing
+	Owners []objects.OwnershipPeriod
+	Events []objects.ThingEvent
+}
+
+// ExternalIdentity is an account confirmed by an authentication provider.
+// UserID is set only by the local provider, other accounts are linked to local users by AuthManager.
+type ExternalIdentity struct {
+	Provider string
+	Subject  string
+	Login    string
+	Role     objects.Levels
+	UserID   int
+}
+
+type Session struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
@@ -1,23 +1,155 @@
 package roomManager
 
 import (
+	"src/logic/controllers/buildingController"
 	"src/logic/controllers/roomController"
+	"src/logic/controllers/studentController"
+	"src/logic/managers/models"
 	"src/objects"
+	appErrors "src/utils/error"
 )
 
 type RoomManager struct {
-	roomController roomController.RoomController
+	roomController     roomController.RoomController
+	studentController  studentController.StudentController
+	buildingController buildingController.BuildingController
 }
 
-func CreateNewRoomManager(rc roomController.RoomController) *RoomManager {
+func CreateNewRoomManager(rc roomController.RoomController, sc studentController.StudentController,
+	bc buildingController.BuildingController) *RoomManager {
 	return &RoomManager{
-		roomController: rc,
+		roomController:     rc,
+		studentController:  sc,
+		buildingController: bc,
 	}
 }
-func (rm *RoomManager) GetAllRooms(page, size int) ([]objects.Room, error) {
-	return rm.roomController.GetRooms(page, size)
+
+// GetAllRooms returns rooms of the building and floor from filter, the building must exist.
+func (rm *RoomManager) GetAllRooms(filter objects.RoomFilter, page, size int) ([]objects.Room, error) {
+	if filter.GetBuildingID() != objects.None {
+		if _, err := rm.buildingController.GetBuilding(filter.GetBuildingID()); err != nil {
+			return nil, err
+		}
+	}
+	return rm.roomController.GetRooms(filter, page, size)
 }
 
 func (rm *RoomManager) GetRoom(id int) (objects.Room, error) {
 	return rm.roomController.GetRoom(id)
 }
+
+// GetRoomFullInfo collects the room, students living there and things located there with their owners.
+func (rm *RoomManager) GetRoomFullInfo(id int) (models.RoomFullInfo, error) {
+	var roomInfo models.RoomFullInfo
+	room, err := rm.roomController.GetRoom(id)
+	if err != nil {
+		return roomInfo, err
+	}
+	roomInfo.Room = room
+	roomInfo.Residents, err = rm.studentController.GetRoomStudents(id)
+	if err != nil {
+		return roomInfo, err
+	}
+	things, err := rm.roomController.GetRoomThings(id)
+	if err != nil {
+		return roomInfo, err
+	}
+	owners := make(map[int]objects.Student)
+	for _, resident := range roomInfo.Residents {
+		owners[resident.GetID()] = resident
+	}
+	roomInfo.Things = make([]models.RoomThing, objects.Empty)
+	for _, thing := range things {
+		owner := objects.NewEmptyStudent()
+		if thing.GetOwnerID() != objects.None {
+			var found bool
+			if owner, found = owners[thing.GetOwnerID()]; !found {
+				owner, err = rm.studentController.GetStudent(thing.GetOwnerID())
+				if err != nil {
+					return roomInfo, err
+				}
+				owners[thing.GetOwnerID()] = owner
+			}
+		}
+		roomInfo.Things = append(roomInfo.Things, models.RoomThing{Thing: thing, Owner: owner})
+	}
+	return roomInfo, nil
+}
+
+func (rm *RoomManager) AddRoom(roomDTO objects.RoomDTO) error {
+	err := rm.checkRoomLocation(roomDTO.GetBuildingID(), roomDTO.GetFloor())
+	if err == nil {
+		err = rm.roomController.AddRoom(roomDTO)
+	}
+	return err
+}
+
+// checkRoomLocation checks that the building exists and has the floor.
+func (rm *RoomManager) checkRoomLocation(buildingID, floor int) error {
+	building, err := rm.buildingController.GetBuilding(buildingID)
+	if err == nil && !building.HasFloor(floor) {
+		err = appErrors.FloorNotFoundErr
+	}
+	return err
+}
+
+// ChangeRoom updates only the given params of the room.
+func (rm *RoomManager) ChangeRoom(id int, changes models.RoomChanges) error {
+	room, err := rm.roomController.GetRoom(id)
+	if err != nil {
+		return err
+	}
+	roomType, number, capacity, gender := room.GetRoomType(), room.GetRoomNumber(), room.GetCapacity(),
+		room.GetGender()
+	buildingID, floor, block := room.GetBuildingID(), room.GetFloor(), room.GetBlock()
+	if changes.RoomType != nil {
+		roomType = *changes.RoomType
+	}
+	if changes.RoomNumber != nil {
+		number = *changes.RoomNumber
+	}
+	if changes.Capacity != nil {
+		capacity = *changes.Capacity
+	}
+	if changes.Gender != nil {
+		gender = *changes.Gender
+	}
+	if changes.BuildingID != nil {
+		buildingID = *changes.BuildingID
+	}
+	if changes.Floor != nil {
+		floor = *changes.Floor
+	}
+	if changes.Block != nil {
+		block = *changes.Block
+	}
+	if changes.BuildingID != nil || changes.Floor != nil {
+		err = rm.checkRoomLocation(buildingID, floor)
+	}
+	var residents []objects.Student
+	if err == nil && gender != room.GetGender() && room.GetOccupied() > objects.Null {
+		residents, err = rm.studentController.GetRoomStudents(id)
+	}
+	if err == nil {
+		roomDTO := objects.NewRoomDTO(roomType, number, capacity, gender)
+		roomDTO.SetLocation(buildingID, floor, block)
+		err = rm.roomController.ChangeRoom(room, roomDTO, residents)
+	}
+	return err
+}
+
+func (rm *RoomManager) DeleteRoom(id int) error {
+	return rm.roomController.DeleteRoom(id)
+}
+
+func (rm *RoomManager) GetAllBuildings(page, size int) ([]objects.Building, error) {
+	return rm.buildingController.GetBuildings(page, size)
+}
+
+func (rm *RoomManager) GetBuilding(id int) (objects.Building, error) {
+	return rm.buildingController.GetBuilding(id)
+}
+
+func (rm *RoomManager) AddBuilding(name, address string, floors int) error {
+	return rm.buildingController.AddBuilding(name, address, floors)
+}
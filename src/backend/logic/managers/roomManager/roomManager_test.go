@@ -1,11 +1,20 @@
 package roomManager
 
 import (
+	"database/sql"
 	"github.com/bloomberg/go-testgroup"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/db/buildingRepo"
 	"src/db/roomRepo"
+	"src/db/studentRepo"
+	"src/logic/controllers/buildingController"
 	"src/logic/controllers/roomController"
+	"src/logic/controllers/studentController"
+	"src/logic/managers/models"
+	"src/objects"
 	"src/tests"
 	"src/tests/mother"
+	appErrors "src/utils/error"
 	"testing"
 	"time"
 )
@@ -31,10 +40,169 @@ func (*TestRoomManager) TestRoomManager_GetAllRooms(t *testgroup.T) {
 	manager := RoomManager{roomController: controller}
 
 	// Act
-	resultRooms, execErr := manager.GetAllRooms()
+	resultRooms, execErr := manager.GetAllRooms(objects.NewEmptyRoomFilter(), objects.Null, objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
 	tests.AssertMocks(t, mock)
 	tests.AssertResult(t, resultRooms, realRooms)
 }
+
+// TestRoomManager_ChangeRoom проверяет, что неуказанные параметры комнаты остаются прежними.
+func (*TestRoomManager) TestRoomManager_ChangeRoom(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.RoomRepoObjectMother{}
+	ID := 2
+	newCapacity := 4
+	db, mock := objectMother.CreateRepo()
+	realRooms := objectMother.CreateDefaultRooms(3)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).
+		WillReturnRows(objectMother.CreateRows(realRooms[ID-1 : ID]))
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultBuildingID, ID, ID).WillReturnError(nil).
+		WillReturnRows(objectMother.CreateExistsRows(false))
+	mock.ExpectExec("UPDATE").WithArgs(ID, mother.Type, ID, newCapacity, objects.GenderAny, mother.DefaultBuildingID,
+		mother.DefaultFloor, objects.EmptyString).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(int64(ID), 1))
+	repo := roomRepo.PgRoomRepo{Conn: db}
+	controller := roomController.RoomController{Repo: &repo}
+	manager := RoomManager{roomController: controller}
+
+	// Act
+	execErr := manager.ChangeRoom(ID, models.RoomChanges{Capacity: &newCapacity})
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+// TestRoomManager_ChangeRoomGender проверяет, что при смене пола занятой комнаты проверяются её жильцы.
+func (*TestRoomManager) TestRoomManager_ChangeRoomGender(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.RoomRepoObjectMother{}
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	ID := 1
+	newGender := objects.GenderFemale
+	db, mock := objectMother.CreateRepo()
+	room := objectMother.CreateRoom(ID, 1, objects.GenderAny)
+	residents := studentObjectMother.CreateDefaultStudents(1)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).
+		WillReturnRows(objectMother.CreateRows([]objects.Room{room}))
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).
+		WillReturnRows(studentObjectMother.CreateRows(residents))
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultBuildingID, ID, ID).WillReturnError(nil).
+		WillReturnRows(objectMother.CreateExistsRows(false))
+	rRepo := roomRepo.PgRoomRepo{Conn: db}
+	sRepo := studentRepo.PgStudentRepo{Conn: db}
+	manager := CreateNewRoomManager(roomController.RoomController{Repo: &rRepo},
+		studentController.StudentController{Repo: &sRepo}, buildingController.BuildingController{})
+
+	// Act
+	execErr := manager.ChangeRoom(ID, models.RoomChanges{Gender: &newGender})
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.RoomGenderErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestRoomManager_GetRoomFullInfo проверяет, что владельцы вещей находятся и среди жильцов, и вне комнаты,
+// а у ничьих вещей владельца нет.
+func (*TestRoomManager) TestRoomManager_GetRoomFullInfo(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	thingObjectMother := mother.ThingRepoObjectMother{}
+	ID := 1
+	db, mock := roomObjectMother.CreateRepo()
+	room := roomObjectMother.CreateRoom(ID, 1, objects.GenderAny)
+	students := studentObjectMother.CreateDefaultStudents(2)
+	resident, guest := students[0], students[1]
+	things := []objects.Thing{
+		objects.NewThingWithParams(1, mother.DefaultMarkNumber, mother.DefaultThingType, resident.GetID(), ID),
+		objects.NewThingWithParams(2, mother.DefaultMarkNumber+1, mother.DefaultThingType, guest.GetID(), ID),
+		objects.NewThingWithParams(3, mother.DefaultMarkNumber+2, mother.DefaultThingType, objects.None, ID),
+	}
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).
+		WillReturnRows(roomObjectMother.CreateRows([]objects.Room{room}))
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).
+		WillReturnRows(studentObjectMother.CreateRows([]objects.Student{resident}))
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).
+		WillReturnRows(roomObjectMother.CreateRows([]objects.Room{room}))
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).
+		WillReturnRows(thingObjectMother.CreateRows(things))
+	mock.ExpectQuery("SELECT").WithArgs(guest.GetID()).WillReturnError(nil).
+		WillReturnRows(studentObjectMother.CreateRows([]objects.Student{guest}))
+	rRepo := roomRepo.PgRoomRepo{Conn: db}
+	sRepo := studentRepo.PgStudentRepo{Conn: db}
+	manager := CreateNewRoomManager(roomController.RoomController{Repo: &rRepo},
+		studentController.StudentController{Repo: &sRepo}, buildingController.BuildingController{})
+	realRoomInfo := models.RoomFullInfo{
+		Room:      room,
+		Residents: []objects.Student{resident},
+		Things: []models.RoomThing{
+			{Thing: things[0], Owner: resident},
+			{Thing: things[1], Owner: guest},
+			{Thing: things[2], Owner: objects.NewEmptyStudent()},
+		},
+	}
+
+	// Act
+	roomInfo, execErr := manager.GetRoomFullInfo(ID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, roomInfo, realRoomInfo)
+}
+
+// TestRoomManager_GetAllRoomsUnknownBuilding проверяет, что комнаты несуществующего корпуса не ищутся.
+func (*TestRoomManager) TestRoomManager_GetAllRoomsUnknownBuilding(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.BuildingRepoObjectMother{}
+	buildingID := 7
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectQuery("SELECT").WithArgs(buildingID).WillReturnError(sql.ErrNoRows)
+	bRepo := buildingRepo.PgBuildingRepo{Conn: db}
+	rRepo := roomRepo.PgRoomRepo{Conn: db}
+	manager := RoomManager{roomController: roomController.RoomController{Repo: &rRepo},
+		buildingController: buildingController.BuildingController{Repo: &bRepo}}
+
+	// Act
+	_, execErr := manager.GetAllRooms(objects.NewRoomFilter(buildingID, objects.None), objects.Null, objects.Null)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BuildingNotFoundErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestRoomManager_AddRoomWrongFloor проверяет, что комнату нельзя добавить на этаж, которого нет в корпусе.
+func (*TestRoomManager) TestRoomManager_AddRoomWrongFloor(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.BuildingRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	buildings := objectMother.CreateDefaultBuildings(1)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultBuildingID).WillReturnError(nil).
+		WillReturnRows(objectMother.CreateRows(buildings))
+	roomDTO := mother.RoomRepoObjectMother{}.CreateDTORoom()
+	roomDTO.SetLocation(mother.DefaultBuildingID, mother.DefaultFloors+1, objects.EmptyString)
+	bRepo := buildingRepo.PgBuildingRepo{Conn: db}
+	rRepo := roomRepo.PgRoomRepo{Conn: db}
+	manager := RoomManager{roomController: roomController.RoomController{Repo: &rRepo},
+		buildingController: buildingController.BuildingController{Repo: &bRepo}}
+
+	// Act
+	execErr := manager.AddRoom(roomDTO)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.FloorNotFoundErr)
+	tests.AssertMocks(t, mock)
+}
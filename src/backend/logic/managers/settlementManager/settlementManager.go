@@ -0,0 +1,118 @@
+package settlementManager
+
+import (
+	"src/logic/controllers/settlementController"
+	"src/logic/controllers/studentController"
+	"src/logic/managers/models"
+	"src/objects"
+	appErrors "src/utils/error"
+)
+
+type SettlementManager struct {
+	settlementController settlementController.SettlementController
+	studentController    studentController.StudentController
+}
+
+func CreateNewSettlementManager(stc settlementController.SettlementController,
+	sc studentController.StudentController) *SettlementManager {
+	return &SettlementManager{
+		settlementController: stc,
+		studentController:    sc,
+	}
+}
+
+// SubmitRequest puts a student who does not live in the dormitory into the waiting list.
+func (sm *SettlementManager) SubmitRequest(studentNumber string, preferences objects.SettlementPreferences,
+	priority, createdBy int) (int, error) {
+	if studentNumber == objects.EmptyString {
+		return objects.None, appErrors.BadStudentParamsErr
+	}
+
+	studentID, err := sm.studentController.GetStudentIDByNumber(studentNumber)
+	if err != nil {
+		return objects.None, err
+	}
+	student, err := sm.studentController.GetStudent(studentID)
+	if err != nil {
+		return objects.None, err
+	}
+	if !student.IsActive() {
+		return objects.None, appErrors.StudentNotActiveErr
+	}
+	if student.GetRoomID() != objects.NotLiving {
+		return objects.None, appErrors.StudentAlreadyLiveErr
+	}
+	return sm.settlementController.AddRequest(studentID, preferences, priority, createdBy)
+}
+
+func (sm *SettlementManager) GetWaitingList(status objects.SettlementStatus,
+	page, size int) ([]objects.SettlementRequest, error) {
+	return sm.settlementController.GetRequests(status, page, size)
+}
+
+func (sm *SettlementManager) GetStudentRequests(studentNumber string) ([]objects.SettlementRequest, error) {
+	studentID, err := sm.studentController.GetStudentIDByNumber(studentNumber)
+	if err != nil {
+		return make([]objects.SettlementRequest, objects.Empty), err
+	}
+	return sm.settlementController.GetStudentRequests(studentID)
+}
+
+func (sm *SettlementManager) GetRequestInfo(id int) (models.SettlementRequestInfo, error) {
+	var info models.SettlementRequestInfo
+	request, err := sm.settlementController.GetRequest(id)
+	if err == nil {
+		info.Request = request
+		info.History, err = sm.settlementController.GetRequestHistory(id)
+	}
+	return info, err
+}
+
+// ApproveRequest settles the student and closes the request in one transaction. The preferred room is used
+// if roomID is not given.
+func (sm *SettlementManager) ApproveRequest(id, roomID, performedBy int, comment string) error {
+	request, err := sm.settlementController.GetRequest(id)
+	if err != nil {
+		return err
+	}
+	if !request.IsPending() {
+		return appErrors.SettlementClosedErr
+	}
+
+	if roomID == objects.NotLiving {
+		preferences := request.GetPreferences()
+		roomID = preferences.GetRoomID()
+		if roomID == objects.None {
+			return appErrors.BadSettlementParamsErr
+		}
+	} else if roomID < objects.NotLiving {
+		return appErrors.RoomNotFoundErr
+	}
+
+	return sm.settlementController.ApproveRequest(request, roomID, performedBy, comment)
+}
+
+func (sm *SettlementManager) RejectRequest(id, performedBy int, comment string) error {
+	return sm.closeRequest(id, objects.SettlementRejected, performedBy, comment)
+}
+
+func (sm *SettlementManager) CancelRequest(id, performedBy int, comment string) error {
+	return sm.closeRequest(id, objects.SettlementCancelled, performedBy, comment)
+}
+
+func (sm *SettlementManager) closeRequest(id int, status objects.SettlementStatus, performedBy int,
+	comment string) error {
+	request, err := sm.settlementController.GetRequest(id)
+	if err == nil {
+		err = sm.settlementController.CloseRequest(request, status, performedBy, comment)
+	}
+	return err
+}
+
+func (sm *SettlementManager) ChangeRequestPriority(id, priority int) error {
+	request, err := sm.settlementController.GetRequest(id)
+	if err == nil {
+		err = sm.settlementController.ChangeRequestPriority(request, priority)
+	}
+	return err
+}
@@ -0,0 +1,208 @@
+package settlementManager
+
+import (
+	"database/sql"
+	"github.com/bloomberg/go-testgroup"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/db/settlementRepo"
+	"src/db/studentRepo"
+	"src/logic/controllers/settlementController"
+	"src/logic/controllers/studentController"
+	"src/logic/managers/models"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	appErrors "src/utils/error"
+	"testing"
+	"time"
+)
+
+const (
+	InsertID     = 5
+	RowsAffected = 1
+)
+
+type TestSettlementManager struct{}
+
+func Test_SettlementManager(t *testing.T) {
+	testgroup.RunSerially(t, &TestSettlementManager{})
+}
+
+func createManager(db *sql.DB) SettlementManager {
+	settlementRepository := settlementRepo.PgSettlementRepo{Conn: db}
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+
+	settlementC := settlementController.SettlementController{Repo: &settlementRepository}
+	studentC := studentController.StudentController{Repo: &studentRepository}
+
+	return *CreateNewSettlementManager(settlementC, studentC)
+}
+
+func (*TestSettlementManager) TestSettlementManager_SubmitRequestPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		StudentID     = 1
+		StudentNumber = mother.DefaultStudentNumber + "1"
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	settlementObjectMother := mother.SettlementRepoObjectMother{}
+	db, mock := settlementObjectMother.CreateRepo()
+	allStudents := studentObjectMother.CreateDefaultStudents(3)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(StudentID))
+	allStudents[0].SetRoomID(objects.NotLiving)
+	mock.ExpectQuery("SELECT").WithArgs(StudentID).
+		WillReturnRows(studentObjectMother.CreateRows(allStudents[:1]))
+	mock.ExpectQuery("SELECT").WithArgs(StudentID).WillReturnRows(settlementObjectMother.CreateRows(nil))
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(InsertID))
+	mock.ExpectExec("INSERT INTO").WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
+	manager := createManager(db)
+
+	// Act
+	id, execErr := manager.SubmitRequest(StudentNumber, settlementObjectMother.CreatePreferences(),
+		mother.DefaultPriority, mother.DefaultStaffID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, id, InsertID)
+}
+
+// TestSettlementManager_SubmitRequestNegativeStudentIsLiving проверяет, что студент, который уже живёт
+// в общежитии, не попадёт в очередь на заселение.
+func (*TestSettlementManager) TestSettlementManager_SubmitRequestNegativeStudentIsLiving(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		StudentID     = 1
+		StudentNumber = mother.DefaultStudentNumber + "1"
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	settlementObjectMother := mother.SettlementRepoObjectMother{}
+	db, mock := settlementObjectMother.CreateRepo()
+	allStudents := studentObjectMother.CreateDefaultStudents(3)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(StudentID))
+	mock.ExpectQuery("SELECT").WithArgs(StudentID).
+		WillReturnRows(studentObjectMother.CreateRows(allStudents[:1]))
+	manager := createManager(db)
+
+	// Act
+	_, execErr := manager.SubmitRequest(StudentNumber, settlementObjectMother.CreatePreferences(),
+		mother.DefaultPriority, mother.DefaultStaffID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.StudentAlreadyLiveErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestSettlementManager_ApproveRequestPositive проверяет, что одобрение заявки заселяет студента
+// и закрывает заявку.
+func (*TestSettlementManager) TestSettlementManager_ApproveRequestPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		StudentID = 1
+		RoomID    = 1
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	settlementObjectMother := mother.SettlementRepoObjectMother{}
+	db, mock := settlementObjectMother.CreateRepo()
+	request := settlementObjectMother.CreateDefaultRequests(1, objects.SettlementPending)[0]
+	allStudents := studentObjectMother.CreateDefaultStudents(3)
+
+	mock.ExpectQuery("SELECT").WithArgs(request.GetID()).
+		WillReturnRows(settlementObjectMother.CreateRows([]objects.SettlementRequest{request}))
+	allStudents[0].SetRoomID(objects.NotLiving)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT").WithArgs(request.GetID()).
+		WillReturnRows(studentObjectMother.CreateRowForID(StudentID))
+	roomObjectMother.ExpectLocks(mock, StudentID, allStudents[:1],
+		roomObjectMother.CreateRoom(RoomID, objects.Null, objects.GenderAny))
+	mock.ExpectExec("INSERT").WithArgs(StudentID, RoomID, objects.Get, mother.DefaultStaffID).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("UPDATE").WithArgs(request.GetID(), objects.SettlementApproved).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").
+		WithArgs(request.GetID(), objects.SettlementApproved, mother.DefaultStaffID, objects.EmptyString).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
+	manager := createManager(db)
+
+	// Act
+	execErr := manager.ApproveRequest(request.GetID(), RoomID, mother.DefaultStaffID, objects.EmptyString)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+// TestSettlementManager_ApproveRequestNegativeNoRoom проверяет, что заявку без желаемой комнаты нельзя одобрить,
+// не указав комнату.
+func (*TestSettlementManager) TestSettlementManager_ApproveRequestNegativeNoRoom(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	settlementObjectMother := mother.SettlementRepoObjectMother{}
+	db, mock := settlementObjectMother.CreateRepo()
+	request := settlementObjectMother.CreateDefaultRequests(1, objects.SettlementPending)[0]
+	mock.ExpectQuery("SELECT").WithArgs(request.GetID()).
+		WillReturnRows(settlementObjectMother.CreateRows([]objects.SettlementRequest{request}))
+	manager := createManager(db)
+
+	// Act
+	execErr := manager.ApproveRequest(request.GetID(), objects.NotLiving, mother.DefaultStaffID,
+		objects.EmptyString)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadSettlementParamsErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestSettlementManager_ApproveRequestNegativeClosed проверяет, что по отклонённой заявке студента не заселят.
+func (*TestSettlementManager) TestSettlementManager_ApproveRequestNegativeClosed(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	settlementObjectMother := mother.SettlementRepoObjectMother{}
+	db, mock := settlementObjectMother.CreateRepo()
+	request := settlementObjectMother.CreateDefaultRequests(1, objects.SettlementRejected)[0]
+	mock.ExpectQuery("SELECT").WithArgs(request.GetID()).
+		WillReturnRows(settlementObjectMother.CreateRows([]objects.SettlementRequest{request}))
+	manager := createManager(db)
+
+	// Act
+	execErr := manager.ApproveRequest(request.GetID(), 1, mother.DefaultStaffID, objects.EmptyString)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.SettlementClosedErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestSettlementManager) TestSettlementManager_GetRequestInfo(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	settlementObjectMother := mother.SettlementRepoObjectMother{}
+	db, mock := settlementObjectMother.CreateRepo()
+	request := settlementObjectMother.CreateDefaultRequests(1, objects.SettlementRejected)[0]
+	history := settlementObjectMother.CreateHistory(objects.SettlementRejected)
+	mock.ExpectQuery("SELECT").WithArgs(request.GetID()).
+		WillReturnRows(settlementObjectMother.CreateRows([]objects.SettlementRequest{request}))
+	mock.ExpectQuery("SELECT").WithArgs(request.GetID()).
+		WillReturnRows(settlementObjectMother.CreateHistoryRows(history))
+	manager := createManager(db)
+
+	// Act
+	info, execErr := manager.GetRequestInfo(request.GetID())
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, info, models.SettlementRequestInfo{Request: request, History: history})
+}
@@ -0,0 +1,156 @@
+package studentManager
+
+import (
+	"src/logic/managers/models"
+	"src/objects"
+	appErrors "src/utils/error"
+	"src/utils/hashUtils"
+	"src/utils/tableUtils"
+	"strings"
+)
+
+// Import columns are named as the fields of AddNewStudentRequestMessage, login, password and gender are optional.
+const (
+	loginColumn         = "login"
+	passwordColumn      = "password"
+	nameColumn          = "name"
+	surnameColumn       = "surname"
+	groupColumn         = "group"
+	studentNumberColumn = "studentnumber"
+	genderColumn        = "gender"
+
+	initialPasswordBytes = 9
+)
+
+var requiredImportColumns = []string{nameColumn, surnameColumn, groupColumn, studentNumberColumn}
+
+// ImportStudents checks every row of the table, the first row is the header. Valid rows are added in one
+// transaction with new student accounts: a missing login is made from the student number and a missing password
+// is generated. In dry-run nothing is added and no passwords are generated. Both modes return the errors
+// of skipped rows with the lines of the file.
+func (sm *StudentManager) ImportStudents(table []tableUtils.Row, dryRun bool) (models.StudentImportResult, error) {
+	result := models.StudentImportResult{
+		Students: make([]models.ImportedStudent, objects.Empty),
+		Errors:   make([]models.ImportRowError, objects.Empty),
+	}
+	rows, err := parseImportTable(table)
+	if err != nil {
+		return result, err
+	}
+	result.Total = len(rows)
+
+	var (
+		accounts       = make([]objects.StudentAccount, objects.Empty)
+		studentNumbers = make(map[string]bool)
+		logins         = make(map[string]bool)
+	)
+	for _, row := range rows {
+		if row.Login == objects.EmptyString {
+			row.Login = strings.ToLower(row.StudentNumber)
+		}
+		fieldErrors, checkErr := sm.checkImportRow(row, studentNumbers, logins)
+		if checkErr != nil {
+			return result, checkErr
+		}
+		studentNumbers[row.StudentNumber] = true
+		logins[row.Login] = true
+		if len(fieldErrors) != objects.Empty {
+			result.Errors = append(result.Errors, models.ImportRowError{Line: row.Line, Fields: fieldErrors})
+			continue
+		}
+
+		imported := models.ImportedStudent{Line: row.Line, StudentNumber: row.StudentNumber, Login: row.Login}
+		if !dryRun {
+			imported.Password = row.Password
+			if imported.Password == objects.EmptyString {
+				imported.Password, err = hashUtils.GenerateToken(initialPasswordBytes)
+			}
+			var passwordHash string
+			if err == nil {
+				passwordHash, err = sm.userController.HashPassword(imported.Password)
+			}
+			if err != nil {
+				return result, err
+			}
+			studentDTO := objects.NewStudentDTO(row.Name, row.Surname, row.Group, row.StudentNumber, row.Gender)
+			accounts = append(accounts, objects.NewStudentAccount(studentDTO, row.Login, passwordHash))
+		}
+		result.Students = append(result.Students, imported)
+	}
+
+	if !dryRun {
+		err = sm.studentController.AddStudentAccounts(accounts)
+		if err != nil {
+			result.Students = make([]models.ImportedStudent, objects.Empty)
+		}
+	}
+	return result, err
+}
+
+func parseImportTable(table []tableUtils.Row) ([]models.StudentImportRow, error) {
+	rows := make([]models.StudentImportRow, objects.Empty)
+	if len(table) == objects.Empty {
+		return rows, appErrors.BadImportFileErr
+	}
+
+	columns := make(map[string]int)
+	for i, title := range table[objects.Null].Cells {
+		columns[strings.ToLower(title)] = i
+	}
+	for _, column := range requiredImportColumns {
+		if _, exist := columns[column]; !exist {
+			return rows, appErrors.BadImportFileErr
+		}
+	}
+
+	for _, record := range table[1:] {
+		cell := func(column string) string {
+			index, exist := columns[column]
+			if !exist || index >= len(record.Cells) {
+				return objects.EmptyString
+			}
+			return record.Cells[index]
+		}
+		rows = append(rows, models.StudentImportRow{
+			Line:          record.Line,
+			Login:         cell(loginColumn),
+			Password:      cell(passwordColumn),
+			Name:          cell(nameColumn),
+			Surname:       cell(surnameColumn),
+			Group:         cell(groupColumn),
+			StudentNumber: cell(studentNumberColumn),
+			Gender:        objects.Gender(strings.ToLower(cell(genderColumn))),
+		})
+	}
+	return rows, nil
+}
+
+// checkImportRow checks the row like a student change and also looks for duplicates in the file and in the base.
+// An error of the base is returned as is, the row is not reported as valid then.
+func (sm *StudentManager) checkImportRow(row models.StudentImportRow, studentNumbers,
+	logins map[string]bool) ([]models.FieldError, error) {
+	fieldErrors := checkStudentChanges(models.StudentChanges{Name: &row.Name, Surname: &row.Surname,
+		StudentGroup: &row.Group, StudentNumber: &row.StudentNumber, Gender: &row.Gender})
+	addError := func(field, reason string) {
+		fieldErrors = append(fieldErrors, models.FieldError{Field: field, Reason: reason})
+	}
+
+	if !objects.IsValidStudentNumber(row.StudentNumber) {
+		return fieldErrors, nil
+	}
+	if studentNumbers[row.StudentNumber] {
+		addError("studentNumber", objects.ImportDuplicateErrorString)
+	} else if _, err := sm.studentController.GetStudentIDByNumber(row.StudentNumber); err == nil {
+		addError("studentNumber", objects.StudentAlreadyExistErrorString)
+	} else if err != appErrors.StudentNotFoundErr {
+		return fieldErrors, err
+	}
+	if logins[row.Login] {
+		addError("login", objects.ImportDuplicateErrorString)
+	} else if _, err := sm.userController.GetUserID(row.Login); err == nil {
+		addError("login", objects.UserAlreadyExistErrorString)
+	} else if err != appErrors.UserNotFoundErr {
+		return fieldErrors, err
+	}
+	return fieldErrors, nil
+}
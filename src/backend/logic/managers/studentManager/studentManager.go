@@ -4,10 +4,13 @@ import (
 	"src/logic/controllers/roomController"
 	"src/logic/controllers/studentController"
 	"src/logic/controllers/thingController"
+	"src/logic/controllers/tokenController"
 	"src/logic/controllers/userController"
 	"src/logic/managers/models"
 	"src/objects"
 	appErrors "src/utils/error"
+	"src/utils/jwtUtils"
+	"time"
 )
 
 type StudentManager struct {
@@ -15,19 +18,25 @@ type StudentManager struct {
 	studentController studentController.StudentController
 	userController    userController.UserController
 	thingController   thingController.ThingController
+	tokenController   tokenController.TokenController
+	keySet            *jwtUtils.KeySet
 }
 
 func CreateNewStudentManager(rc roomController.RoomController, sc studentController.StudentController,
-	uc userController.UserController, tc thingController.ThingController) *StudentManager {
+	uc userController.UserController, tc thingController.ThingController,
+	tokc tokenController.TokenController, keySet *jwtUtils.KeySet) *StudentManager {
 	return &StudentManager{
 		roomController:    rc,
 		studentController: sc,
 		userController:    uc,
 		thingController:   tc,
+		tokenController:   tokc,
+		keySet:            keySet,
 	}
 }
 
-func (sm *StudentManager) AddNewStudent(name, surname, studentGroup, studentNumber, login, password string) (err error) {
+func (sm *StudentManager) AddNewStudent(name, surname, studentGroup, studentNumber string, gender objects.Gender,
+	login, password string) (err error) {
 	if name == objects.EmptyString || surname == objects.EmptyString || studentGroup == objects.EmptyString ||
 		studentNumber == objects.EmptyString {
 		err = appErrors.BadStudentParamsErr
@@ -42,7 +51,7 @@ func (sm *StudentManager) AddNewStudent(name, surname, studentGroup, studentNumb
 				if addUserErr == nil {
 					accID, getUserErr := sm.userController.GetUserID(login)
 					if getUserErr == nil {
-						err = sm.studentController.AddStudent(name, surname, studentGroup, studentNumber, accID)
+						err = sm.studentController.AddStudent(name, surname, studentGroup, studentNumber, gender, accID)
 					} else {
 						err = getUserErr
 					}
@@ -73,8 +82,13 @@ func (sm *StudentManager) ViewStudent(studentNumber string) (models.StudentFullI
 	return studentInfo, err
 }
 
-func (sm *StudentManager) ViewAllStudents(page, size int) ([]objects.Student, error) {
-	return sm.studentController.GetAllStudents(page, size)
+// ViewAllStudents returns a page of students matching the filter and the number of them on all pages.
+func (sm *StudentManager) ViewAllStudents(filter objects.StudentFilter, page, size int) ([]objects.Student, int,
+	error) {
+	if !objects.IsValidStudentSort(filter.GetSortBy()) {
+		return make([]objects.Student, objects.Empty), objects.Empty, appErrors.WrongRequestParamsErr
+	}
+	return sm.studentController.FindStudents(filter, page, size)
 }
 
 func (sm *StudentManager) ChangeStudentGroup(studentNumber, newGroup string) (err error) {
@@ -91,7 +105,161 @@ func (sm *StudentManager) ChangeStudentGroup(studentNumber, newGroup string) (er
 	return err
 }
 
-func (sm *StudentManager) SettleStudent(studentNumber string, roomID int) error {
+// ChangeStudent updates only the given fields of the student. If some of them are invalid nothing is changed,
+// all invalid fields are returned with BadStudentFieldsErr.
+func (sm *StudentManager) ChangeStudent(studentNumber string,
+	changes models.StudentChanges) ([]models.FieldError, error) {
+	if studentNumber == objects.EmptyString {
+		return nil, appErrors.BadStudentParamsErr
+	}
+
+	fieldErrors := checkStudentChanges(changes)
+	if len(fieldErrors) != objects.Empty {
+		return fieldErrors, appErrors.BadStudentFieldsErr
+	}
+
+	studentID, err := sm.studentController.GetStudentIDByNumber(studentNumber)
+	if err != nil {
+		return nil, err
+	}
+	student, err := sm.studentController.GetStudent(studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, surname, group, number, gender := student.GetName(), student.GetSurname(), student.GetStudentGroup(),
+		student.GetStudentNumber(), student.GetGender()
+	profile := student.GetProfile()
+	patronymic, faculty, course := profile.GetPatronymic(), profile.GetFaculty(), profile.GetCourse()
+	phone, email, dateOfBirth := profile.GetPhone(), profile.GetEmail(), profile.GetDateOfBirth()
+	if changes.Name != nil {
+		name = *changes.Name
+	}
+	if changes.Surname != nil {
+		surname = *changes.Surname
+	}
+	if changes.StudentGroup != nil {
+		group = *changes.StudentGroup
+	}
+	if changes.StudentNumber != nil {
+		number = *changes.StudentNumber
+	}
+	if changes.Gender != nil {
+		gender = *changes.Gender
+	}
+	if changes.Patronymic != nil {
+		patronymic = *changes.Patronymic
+	}
+	if changes.Faculty != nil {
+		faculty = *changes.Faculty
+	}
+	if changes.Course != nil {
+		course = *changes.Course
+	}
+	if changes.Phone != nil {
+		phone = *changes.Phone
+	}
+	if changes.Email != nil {
+		email = *changes.Email
+	}
+	if changes.DateOfBirth != nil {
+		dateOfBirth, _ = parseDateOfBirth(*changes.DateOfBirth)
+	}
+
+	if gender != student.GetGender() && student.GetRoomID() != objects.NotLiving {
+		room, getRoomErr := sm.roomController.GetRoom(student.GetRoomID())
+		if getRoomErr != nil {
+			return nil, getRoomErr
+		}
+		if !room.AllowsGender(gender) {
+			return nil, appErrors.RoomGenderErr
+		}
+	}
+
+	studentDTO := objects.NewStudentDTO(name, surname, group, number, gender)
+	studentDTO.SetProfile(objects.NewStudentProfile(patronymic, faculty, course, phone, email, dateOfBirth))
+	return nil, sm.studentController.ChangeStudent(student, studentDTO)
+}
+
+// checkStudentChanges validates every given field. Optional profile fields may be cleared with empty values.
+func checkStudentChanges(changes models.StudentChanges) []models.FieldError {
+	fieldErrors := make([]models.FieldError, objects.Empty)
+	addError := func(field, reason string) {
+		fieldErrors = append(fieldErrors, models.FieldError{Field: field, Reason: reason})
+	}
+	if changes.Name != nil && *changes.Name == objects.EmptyString {
+		addError("name", objects.EmptyFieldErrorString)
+	}
+	if changes.Surname != nil && *changes.Surname == objects.EmptyString {
+		addError("surname", objects.EmptyFieldErrorString)
+	}
+	if changes.StudentGroup != nil && !objects.IsValidStudentGroup(*changes.StudentGroup) {
+		addError("group", objects.StudentGroupFormatErrorString)
+	}
+	if changes.StudentNumber != nil && !objects.IsValidStudentNumber(*changes.StudentNumber) {
+		addError("studentNumber", objects.StudentNumberFormatErrorString)
+	}
+	if changes.Gender != nil && !changes.Gender.IsValid() {
+		addError("gender", objects.GenderErrorString)
+	}
+	if changes.Course != nil && *changes.Course != objects.Null && !objects.IsValidCourse(*changes.Course) {
+		addError("course", objects.CourseErrorString)
+	}
+	if changes.Phone != nil && *changes.Phone != objects.EmptyString && !objects.IsValidPhone(*changes.Phone) {
+		addError("phone", objects.PhoneErrorString)
+	}
+	if changes.Email != nil && *changes.Email != objects.EmptyString && !objects.IsValidEmail(*changes.Email) {
+		addError("email", objects.EmailErrorString)
+	}
+	if changes.DateOfBirth != nil {
+		if _, err := parseDateOfBirth(*changes.DateOfBirth); err != nil {
+			addError("dateOfBirth", objects.DateOfBirthErrorString)
+		}
+	}
+	return fieldErrors
+}
+
+// parseDateOfBirth returns zero time for an empty date, the date must be in the past.
+func parseDateOfBirth(date string) (time.Time, error) {
+	if date == objects.EmptyString {
+		return time.Time{}, nil
+	}
+	dateOfBirth, err := time.Parse(objects.DateFormat, date)
+	if err == nil && !dateOfBirth.Before(time.Now()) {
+		err = appErrors.BadStudentParamsErr
+	}
+	return dateOfBirth, err
+}
+
+// ChangeStudentStatus moves the student through the lifecycle. To leave the active status the student must be
+// evicted and return all things, then the user account is disabled and its sessions are revoked. The account
+// is enabled again on return from academic leave. The student record is kept for history.
+// Repeating the change re-runs the revocation of sessions, which is done after the status is saved.
+func (sm *StudentManager) ChangeStudentStatus(studentNumber string, status objects.StudentStatus) error {
+	if studentNumber == objects.EmptyString || !status.IsValid() {
+		return appErrors.BadStudentParamsErr
+	}
+
+	studentID, err := sm.studentController.GetStudentIDByNumber(studentNumber)
+	if err != nil {
+		return err
+	}
+	student, err := sm.studentController.GetStudent(studentID)
+	if err != nil {
+		return err
+	}
+
+	if student.GetStatus() != status || status == objects.StudentActive {
+		err = sm.studentController.ChangeStudentStatus(studentID, status)
+	}
+	if err == nil && status != objects.StudentActive {
+		err = sm.tokenController.RevokeUserSessions(student.GetAccID())
+	}
+	return err
+}
+
+// SettleStudent records the act as performed by the staff user performedBy, None if the caller is not a user.
+func (sm *StudentManager) SettleStudent(studentNumber string, roomID, performedBy int) error {
 	if studentNumber == objects.EmptyString {
 		return appErrors.BadStudentParamsErr
 	}
@@ -102,26 +270,93 @@ func (sm *StudentManager) SettleStudent(studentNumber string, roomID int) error
 
 	studentID, err := sm.studentController.GetStudentIDByNumber(studentNumber)
 	if err == nil {
-		_, err = sm.roomController.GetRoom(roomID)
-		if err == nil {
-			err = sm.studentController.SettleStudent(studentID, roomID)
-		}
+		err = sm.studentController.SettleStudent(studentID, roomID, performedBy)
 	}
 	return err
 }
 
-func (sm *StudentManager) EvicStudent(studentNumber string) error {
+func (sm *StudentManager) RelocateStudent(studentNumber string, roomID, performedBy int) error {
 	if studentNumber == objects.EmptyString {
 		return appErrors.BadStudentParamsErr
 	}
 
+	if roomID <= objects.NotLiving {
+		return appErrors.RoomNotFoundErr
+	}
+
 	studentID, err := sm.studentController.GetStudentIDByNumber(studentNumber)
 	if err == nil {
-		err = sm.studentController.EvicStudent(studentID)
+		err = sm.studentController.RelocateStudent(studentID, roomID, performedBy)
 	}
 	return err
 }
 
+// EvicStudent refuses the final departure while the student has unreturned things or unpaid fees.
+func (sm *StudentManager) EvicStudent(studentNumber string, performedBy int, final bool) error {
+	if studentNumber == objects.EmptyString {
+		return appErrors.BadStudentParamsErr
+	}
+
+	studentID, err := sm.studentController.GetStudentIDByNumber(studentNumber)
+	if err == nil {
+		err = sm.studentController.EvicStudent(studentID, performedBy, final)
+	}
+	return err
+}
+
+// GetClearanceReport signs the clearance document if nothing keeps the student in the dormitory,
+// otherwise StudentClearanceErr is returned together with the blockers.
+func (sm *StudentManager) GetClearanceReport(studentNumber string) (models.ClearanceReport, error) {
+	if studentNumber == objects.EmptyString {
+		return models.ClearanceReport{}, appErrors.BadStudentParamsErr
+	}
+
+	studentID, err := sm.studentController.GetStudentIDByNumber(studentNumber)
+	if err != nil {
+		return models.ClearanceReport{}, err
+	}
+	report, err := sm.collectClearance(studentID)
+	if err != nil {
+		return report, err
+	}
+	if report.HasBlockers() {
+		return report, appErrors.StudentClearanceErr
+	}
+
+	report.IssuedAt = time.Now()
+	report.Document, err = sm.keySet.SignClearance(report.Student.GetStudentNumber(), report.IssuedAt)
+	return report, err
+}
+
+func (sm *StudentManager) collectClearance(studentID int) (models.ClearanceReport, error) {
+	var report models.ClearanceReport
+	student, err := sm.studentController.GetStudent(studentID)
+	if err != nil {
+		return report, err
+	}
+	report.Student = student
+	report.RoomID = student.GetRoomID()
+
+	report.Things, err = sm.studentController.GetStudentThings(studentID, objects.Null, objects.Null)
+	if err == nil {
+		report.Fees, err = sm.studentController.GetStudentFees(studentID)
+	}
+	return report, err
+}
+
+func (sm *StudentManager) ViewLivingHistory(studentNumber string, from, to time.Time,
+	page, size int) ([]objects.LivingRecord, error) {
+	if studentNumber == objects.EmptyString {
+		return make([]objects.LivingRecord, objects.Empty), appErrors.BadStudentParamsErr
+	}
+
+	studentID, err := sm.studentController.GetStudentIDByNumber(studentNumber)
+	if err != nil {
+		return make([]objects.LivingRecord, objects.Empty), err
+	}
+	return sm.studentController.GetLivingHistory(studentID, from, to, page, size)
+}
+
 func (sm *StudentManager) GiveStudentThing(studentNumber string, markNumber int) error {
 	if studentNumber == objects.EmptyString {
 		return appErrors.BadStudentParamsErr
@@ -199,6 +434,27 @@ func (sm *StudentManager) GetStudentByAccID(accID int) (string, error) {
 	return resultStudentNumber, err
 }
 
+// CheckStudentAccess lets students see only their own records. Other roles are not limited here.
+func (sm *StudentManager) CheckStudentAccess(login string, role objects.Levels, studentNumber string) error {
+	if role != objects.StudentRole {
+		return nil
+	}
+
+	accID, err := sm.userController.GetUserID(login)
+	if err == nil {
+		ownStudentNumber, getStudentErr := sm.GetStudentByAccID(accID)
+		if getStudentErr == appErrors.StudentNotFoundErr ||
+			(getStudentErr == nil && ownStudentNumber != studentNumber) {
+			err = appErrors.AccessDeniedErr
+		} else {
+			err = getStudentErr
+		}
+	} else if err == appErrors.UserNotFoundErr {
+		err = appErrors.AccessDeniedErr
+	}
+	return err
+}
+
 func (sm *StudentManager) GetCurrentRoom(studentNumber string) (int, error) {
 	var result = objects.None
 	if studentNumber == objects.EmptyString {
@@ -7,15 +7,19 @@ import (
 	"src/db/roomRepo"
 	"src/db/studentRepo"
 	"src/db/thingRepo"
+	"src/db/tokenRepo"
 	"src/db/userRepo"
 	"src/logic/controllers/roomController"
 	"src/logic/controllers/studentController"
 	"src/logic/controllers/thingController"
+	"src/logic/controllers/tokenController"
 	"src/logic/controllers/userController"
+	"src/logic/managers/models"
 	"src/objects"
 	"src/tests"
 	"src/tests/mother"
 	appErrors "src/utils/error"
+	"src/utils/tableUtils"
 	"testing"
 	"time"
 )
@@ -51,17 +55,16 @@ func (*TestStudentManager) TestStudentManager_AddNewStudentPositive(t *testgroup
 	allStudents := studentObjectMother.CreateDefaultStudents(N)
 
 	mock.ExpectQuery("SELECT").WillReturnError(sql.ErrNoRows)
-	firstAllStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnRows(firstAllStudentRows).WillReturnError(nil)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnError(sql.ErrNoRows)
 	mock.ExpectQuery("SELECT").WillReturnError(sql.ErrNoRows)
-	mock.ExpectExec("INSERT INTO").WithArgs(Login, Password, objects.StudentRole).WillReturnError(nil).
-		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").WithArgs(Login, tests.HashedPasswordArg{Password: Password}, objects.StudentRole).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
 	thirdUsersRows := userObjectMother.CreateRowForID(InsertID)
 	mock.ExpectQuery("SELECT").WillReturnError(nil).WillReturnRows(thirdUsersRows)
 
 	allStudentRows := studentObjectMother.CreateRows(allStudents)
 	mock.ExpectQuery("SELECT").WillReturnRows(allStudentRows).WillReturnError(nil)
-	mock.ExpectExec("INSERT INTO").WithArgs(Name, Surname, StudentGroup, StudentNumber, InsertID).
+	mock.ExpectExec("INSERT INTO").WithArgs(Name, Surname, StudentGroup, StudentNumber, InsertID, mother.DefaultGender).
 		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
 
 	studentRepository := studentRepo.PgStudentRepo{Conn: db}
@@ -78,7 +81,7 @@ func (*TestStudentManager) TestStudentManager_AddNewStudentPositive(t *testgroup
 		roomController: roomC}
 
 	// Act
-	execErr := manager.AddNewStudent(Name, Surname, StudentGroup, StudentNumber, Login, Password)
+	execErr := manager.AddNewStudent(Name, Surname, StudentGroup, StudentNumber, mother.DefaultGender, Login, Password)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
@@ -118,7 +121,7 @@ func (*TestStudentManager) TestStudentManager_AddNewStudentNegativeLoginOccuped(
 		roomController: roomC}
 
 	// Act
-	execErr := manager.AddNewStudent(Name, Surname, StudentGroup, StudentNumber, Login, Password)
+	execErr := manager.AddNewStudent(Name, Surname, StudentGroup, StudentNumber, mother.DefaultGender, Login, Password)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.LoginOccupedErr)
@@ -155,7 +158,7 @@ func (*TestStudentManager) TestStudentManager_AddNewStudentNegativeBadLoginParam
 		roomController: roomC}
 
 	// Act
-	execErr := manager.AddNewStudent(Name, Surname, StudentGroup, StudentNumber, Login, Password)
+	execErr := manager.AddNewStudent(Name, Surname, StudentGroup, StudentNumber, mother.DefaultGender, Login, Password)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.BadUserParamsErr)
@@ -192,7 +195,7 @@ func (*TestStudentManager) TestStudentManager_AddNewStudentNegativeBadName(t *te
 		roomController: roomC}
 
 	// Act
-	execErr := manager.AddNewStudent(Name, Surname, StudentGroup, StudentNumber, Login, Password)
+	execErr := manager.AddNewStudent(Name, Surname, StudentGroup, StudentNumber, mother.DefaultGender, Login, Password)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.BadStudentParamsErr)
@@ -216,12 +219,12 @@ func (*TestStudentManager) TestStudentManager_ChangeStudentGroupPositive(t *test
 	N := 4
 	allStudents := studentObjectMother.CreateDefaultStudents(N)
 
-	firstAllStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnRows(firstAllStudentRows).WillReturnError(nil)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(ID))
 
 	secondStudentRow := studentObjectMother.CreateRows(allStudents[:1])
 	mock.ExpectQuery("SELECT").WillReturnError(nil).WillReturnRows(secondStudentRow)
-	mock.ExpectExec("UPDATE").WithArgs(Name, Surname, StudentGroup, StudentNumber, ID).
+	mock.ExpectExec("UPDATE").WithArgs(Name, Surname, StudentGroup, StudentNumber, ID, mother.DefaultGender,
+		objects.EmptyString, objects.EmptyString, objects.Null, objects.EmptyString, objects.EmptyString, nil).
 		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(int64(ID), RowsAffected))
 
 	studentRepository := studentRepo.PgStudentRepo{Conn: db}
@@ -289,11 +292,8 @@ func (*TestStudentManager) TestStudentManager_ChangeStudentGroupNegativeStudentN
 	studentObjectMother := mother.StudentRepoObjectMother{}
 
 	db, mock := studentObjectMother.CreateRepo()
-	N := 4
-	allStudents := studentObjectMother.CreateDefaultStudents(N)
 
-	firstAllStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnRows(firstAllStudentRows).WillReturnError(nil)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnError(sql.ErrNoRows)
 
 	studentRepository := studentRepo.PgStudentRepo{Conn: db}
 	userRepository := userRepo.PgUserRepo{Conn: db}
@@ -316,6 +316,354 @@ func (*TestStudentManager) TestStudentManager_ChangeStudentGroupNegativeStudentN
 	tests.AssertMocks(t, mock)
 }
 
+// TestStudentManager_ChangeStudentPositive проверяет, что неуказанные поля студента остаются прежними.
+func (*TestStudentManager) TestStudentManager_ChangeStudentPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		StudentNumber = mother.DefaultStudentNumber + "1"
+		Faculty       = "ИУ"
+		Course        = 4
+		Email         = "ivanov@student.bmstu.ru"
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+
+	db, mock := studentObjectMother.CreateRepo()
+	ID := 1
+	N := 4
+	allStudents := studentObjectMother.CreateDefaultStudents(N)
+
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(ID))
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnRows(studentObjectMother.CreateRows(allStudents[:1])).
+		WillReturnError(nil)
+	mock.ExpectExec("UPDATE").WithArgs(mother.DefaultStudentName, mother.DefaultStudentSurname,
+		mother.DefaultGroup, StudentNumber, ID, mother.DefaultGender, objects.EmptyString, Faculty, Course,
+		objects.EmptyString, Email, nil).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(int64(ID), RowsAffected))
+
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	userRepository := userRepo.PgUserRepo{Conn: db}
+	thingRepository := thingRepo.PgThingRepo{Conn: db}
+	roomRepository := roomRepo.PgRoomRepo{Conn: db}
+
+	studentC := studentController.StudentController{Repo: &studentRepository}
+	userC := userController.UserController{Repo: &userRepository}
+	thingC := thingController.ThingController{Repo: &thingRepository}
+	roomC := roomController.RoomController{Repo: &roomRepository}
+
+	manager := StudentManager{studentController: studentC, userController: userC, thingController: thingC,
+		roomController: roomC}
+
+	// Act
+	fieldErrors, execErr := manager.ChangeStudent(StudentNumber, models.StudentChanges{Faculty: &Faculty,
+		Course: &Course, Email: &Email})
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertResult(t, len(fieldErrors), objects.Empty)
+	tests.AssertMocks(t, mock)
+}
+
+// TestStudentManager_ChangeStudentNegativeBadFields проверяет, что возвращаются все неверные поля.
+func (*TestStudentManager) TestStudentManager_ChangeStudentNegativeBadFields(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		StudentNumber    = mother.DefaultStudentNumber + "1"
+		NewStudentNumber = "19-609"
+		StudentGroup     = "ИУ7"
+		Course           = 9
+		Email            = "ivanov"
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+
+	db, mock := studentObjectMother.CreateRepo()
+
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	userRepository := userRepo.PgUserRepo{Conn: db}
+	thingRepository := thingRepo.PgThingRepo{Conn: db}
+	roomRepository := roomRepo.PgRoomRepo{Conn: db}
+
+	studentC := studentController.StudentController{Repo: &studentRepository}
+	userC := userController.UserController{Repo: &userRepository}
+	thingC := thingController.ThingController{Repo: &thingRepository}
+	roomC := roomController.RoomController{Repo: &roomRepository}
+
+	manager := StudentManager{studentController: studentC, userController: userC, thingController: thingC,
+		roomController: roomC}
+
+	// Act
+	fieldErrors, execErr := manager.ChangeStudent(StudentNumber, models.StudentChanges{
+		StudentNumber: &NewStudentNumber, StudentGroup: &StudentGroup, Course: &Course, Email: &Email})
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadStudentFieldsErr)
+	tests.AssertResult(t, fieldErrors, []models.FieldError{
+		{Field: "group", Reason: objects.StudentGroupFormatErrorString},
+		{Field: "studentNumber", Reason: objects.StudentNumberFormatErrorString},
+		{Field: "course", Reason: objects.CourseErrorString},
+		{Field: "email", Reason: objects.EmailErrorString},
+	})
+	tests.AssertMocks(t, mock)
+}
+
+// TestStudentManager_ChangeStudentStatusPositive проверяет, что после выпуска аккаунт студента отключается.
+func (*TestStudentManager) TestStudentManager_ChangeStudentStatusPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		StudentID     = 1
+		AccID         = 2
+		StudentNumber = mother.DefaultStudentNumber + "1"
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+
+	db, mock := studentObjectMother.CreateRepo()
+	student := objects.NewStudentWithParams(StudentID, AccID, mother.DefaultStudentName,
+		mother.DefaultStudentSurname, mother.DefaultGroup, StudentNumber, objects.NotLiving, mother.DefaultGender)
+
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(StudentID))
+	mock.ExpectQuery("SELECT").WithArgs(StudentID).
+		WillReturnRows(studentObjectMother.CreateRows([]objects.Student{student}))
+	mock.ExpectBegin()
+	studentObjectMother.ExpectLock(mock, StudentID, []objects.Student{student})
+	mock.ExpectQuery("SELECT").WithArgs(StudentID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("UPDATE").WithArgs(StudentID, objects.StudentGraduated).
+		WillReturnResult(sqlmock.NewResult(int64(StudentID), RowsAffected))
+	mock.ExpectExec("UPDATE").WithArgs(true, AccID).
+		WillReturnResult(sqlmock.NewResult(int64(AccID), RowsAffected))
+	mock.ExpectCommit()
+	mock.ExpectExec("UPDATE").WithArgs(AccID).WillReturnResult(sqlmock.NewResult(int64(AccID), RowsAffected))
+	mock.ExpectExec("INSERT").WithArgs(AccID).WillReturnResult(sqlmock.NewResult(int64(AccID), RowsAffected))
+
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	tokenRepository := tokenRepo.PgTokenRepo{Conn: db}
+
+	studentC := studentController.StudentController{Repo: &studentRepository}
+	tokenC := tokenController.TokenController{Repo: &tokenRepository}
+
+	manager := StudentManager{studentController: studentC, tokenController: tokenC}
+
+	// Act
+	execErr := manager.ChangeStudentStatus(StudentNumber, objects.StudentGraduated)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+// TestStudentManager_ChangeStudentStatusRepeat проверяет, что повторный выпуск только снова отзывает сессии
+// на случай, если в первый раз это не удалось.
+func (*TestStudentManager) TestStudentManager_ChangeStudentStatusRepeat(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		StudentID     = 1
+		AccID         = 2
+		StudentNumber = mother.DefaultStudentNumber + "1"
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+
+	db, mock := studentObjectMother.CreateRepo()
+	student := objects.NewStudentWithParams(StudentID, AccID, mother.DefaultStudentName,
+		mother.DefaultStudentSurname, mother.DefaultGroup, StudentNumber, objects.NotLiving, mother.DefaultGender)
+	student.SetStatus(objects.StudentGraduated)
+
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(StudentID))
+	mock.ExpectQuery("SELECT").WithArgs(StudentID).
+		WillReturnRows(studentObjectMother.CreateRows([]objects.Student{student}))
+	mock.ExpectExec("UPDATE").WithArgs(AccID).WillReturnResult(sqlmock.NewResult(int64(AccID), RowsAffected))
+	mock.ExpectExec("INSERT").WithArgs(AccID).WillReturnResult(sqlmock.NewResult(int64(AccID), RowsAffected))
+
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	tokenRepository := tokenRepo.PgTokenRepo{Conn: db}
+
+	studentC := studentController.StudentController{Repo: &studentRepository}
+	tokenC := tokenController.TokenController{Repo: &tokenRepository}
+
+	manager := StudentManager{studentController: studentC, tokenController: tokenC}
+
+	// Act
+	execErr := manager.ChangeStudentStatus(StudentNumber, objects.StudentGraduated)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+// TestStudentManager_ChangeStudentStatusNegativeLiving проверяет, что нельзя выпустить студента,
+// который ещё живёт в общежитии.
+func (*TestStudentManager) TestStudentManager_ChangeStudentStatusNegativeLiving(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		StudentID     = 1
+		StudentNumber = mother.DefaultStudentNumber + "1"
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+
+	db, mock := studentObjectMother.CreateRepo()
+	students := studentObjectMother.CreateDefaultStudents(StudentID)
+
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(StudentID))
+	mock.ExpectQuery("SELECT").WithArgs(StudentID).WillReturnRows(studentObjectMother.CreateRows(students))
+	mock.ExpectBegin()
+	studentObjectMother.ExpectLock(mock, StudentID, students)
+	mock.ExpectQuery("SELECT").WithArgs(StudentID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectRollback()
+
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	studentC := studentController.StudentController{Repo: &studentRepository}
+
+	manager := StudentManager{studentController: studentC}
+
+	// Act
+	execErr := manager.ChangeStudentStatus(StudentNumber, objects.StudentExpelled)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.StudentIsLivingErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestStudentManager_ImportStudentsDryRun проверяет, что в режиме проверки ничего не добавляется,
+// а ошибки возвращаются с номерами строк файла.
+func (*TestStudentManager) TestStudentManager_ImportStudentsDryRun(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		StudentNumber = "22У101"
+		Login         = "22у101"
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+
+	db, mock := studentObjectMother.CreateRepo()
+	table := []tableUtils.Row{
+		{Line: 1, Cells: []string{"name", "surname", "group", "studentNumber", "gender"}},
+		{Line: 2, Cells: []string{mother.DefaultStudentName, mother.DefaultStudentSurname, "ИУ7-15Б", StudentNumber,
+			"Male"}},
+		{Line: 4, Cells: []string{mother.DefaultStudentName, objects.EmptyString, "ИУ7", "22-101", "man"}},
+		{Line: 5, Cells: []string{mother.DefaultStudentName, mother.DefaultStudentSurname, "ИУ7-15Б", StudentNumber,
+			objects.EmptyString}},
+	}
+
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT").WithArgs(Login).WillReturnError(sql.ErrNoRows)
+
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	userRepository := userRepo.PgUserRepo{Conn: db}
+
+	studentC := studentController.StudentController{Repo: &studentRepository}
+	userC := userController.UserController{Repo: &userRepository}
+
+	manager := StudentManager{studentController: studentC, userController: userC}
+
+	// Act
+	result, execErr := manager.ImportStudents(table, true)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, result.Total, 3)
+	tests.AssertResult(t, result.Students, []models.ImportedStudent{
+		{Line: 2, StudentNumber: StudentNumber, Login: Login},
+	})
+	tests.AssertResult(t, result.Errors, []models.ImportRowError{
+		{Line: 4, Fields: []models.FieldError{
+			{Field: "surname", Reason: objects.EmptyFieldErrorString},
+			{Field: "group", Reason: objects.StudentGroupFormatErrorString},
+			{Field: "studentNumber", Reason: objects.StudentNumberFormatErrorString},
+			{Field: "gender", Reason: objects.GenderErrorString},
+		}},
+		{Line: 5, Fields: []models.FieldError{
+			{Field: "studentNumber", Reason: objects.ImportDuplicateErrorString},
+			{Field: "login", Reason: objects.ImportDuplicateErrorString},
+		}},
+	})
+}
+
+// TestStudentManager_ImportStudentsDBError проверяет, что ошибка базы при проверке строки прерывает импорт,
+// а не выдаёт строку за новую.
+func (*TestStudentManager) TestStudentManager_ImportStudentsDBError(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	StudentNumber := "22У101"
+	studentObjectMother := mother.StudentRepoObjectMother{}
+
+	db, mock := studentObjectMother.CreateRepo()
+	table := []tableUtils.Row{
+		{Line: 1, Cells: []string{"name", "surname", "group", "studentnumber"}},
+		{Line: 2, Cells: []string{mother.DefaultStudentName, mother.DefaultStudentSurname, "ИУ7-15Б", StudentNumber}},
+	}
+
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnError(sql.ErrConnDone)
+
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	studentC := studentController.StudentController{Repo: &studentRepository}
+
+	manager := StudentManager{studentController: studentC}
+
+	// Act
+	_, execErr := manager.ImportStudents(table, false)
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrConnDone)
+	tests.AssertMocks(t, mock)
+}
+
+// TestStudentManager_ImportStudentsPositive проверяет, что студенты добавляются в одной транзакции
+// и для них генерируются пароли.
+func (*TestStudentManager) TestStudentManager_ImportStudentsPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		StudentNumber = "22У101"
+		Login         = "ivanov"
+		AccID         = 9
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+
+	db, mock := studentObjectMother.CreateRepo()
+	table := []tableUtils.Row{
+		{Line: 1, Cells: []string{"login", "name", "surname", "group", "studentnumber"}},
+		{Line: 2, Cells: []string{Login, mother.DefaultStudentName, mother.DefaultStudentSurname, "ИУ7-15Б",
+			StudentNumber}},
+	}
+
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT").WithArgs(Login).WillReturnError(sql.ErrNoRows)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT").WithArgs(Login, sqlmock.AnyArg(), objects.StudentRole).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(AccID))
+	mock.ExpectExec("INSERT").WithArgs(mother.DefaultStudentName, mother.DefaultStudentSurname, "ИУ7-15Б",
+		StudentNumber, AccID, objects.GenderAny).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
+
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	userRepository := userRepo.PgUserRepo{Conn: db}
+
+	studentC := studentController.StudentController{Repo: &studentRepository}
+	userC := userController.UserController{Repo: &userRepository}
+
+	manager := StudentManager{studentController: studentC, userController: userC}
+
+	// Act
+	result, execErr := manager.ImportStudents(table, false)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, len(result.Students), 1)
+	tests.AssertResult(t, result.Students[objects.Null].Password != objects.EmptyString, true)
+}
+
 func (*TestStudentManager) TestStudentManager_SettleStudentPositive(t *testgroup.T) {
 	defer tests.TimeTrack(time.Now())
 	// Arrange
@@ -332,19 +680,15 @@ func (*TestStudentManager) TestStudentManager_SettleStudentPositive(t *testgroup
 	studentsN := 4
 	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
 
-	roomsN := 1
-	realRooms := roomObjectMother.CreateDefaultRooms(roomsN)
-	rows := roomObjectMother.CreateRows(realRooms)
-
-	firstStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnError(nil).WillReturnRows(firstStudentRows)
-	mock.ExpectQuery("SELECT").WithArgs(RoomID).WillReturnError(nil).WillReturnRows(rows)
+	room := roomObjectMother.CreateRoom(RoomID, objects.Null, objects.GenderAny)
 
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(StudentID))
 	allStudents[0].SetRoomID(objects.NotLiving)
-	secondStudentRows := studentObjectMother.CreateRows(allStudents[:1])
-	mock.ExpectQuery("SELECT").WithArgs(StudentID).WillReturnError(nil).WillReturnRows(secondStudentRows)
-	mock.ExpectExec("INSERT").WithArgs(StudentID, RoomID, objects.Get).
+	mock.ExpectBegin()
+	roomObjectMother.ExpectLocks(mock, StudentID, allStudents[:1], room)
+	mock.ExpectExec("INSERT").WithArgs(StudentID, RoomID, objects.Get, mother.DefaultStaffID).
 		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
 
 	studentRepository := studentRepo.PgStudentRepo{Conn: db}
 	userRepository := userRepo.PgUserRepo{Conn: db}
@@ -360,7 +704,7 @@ func (*TestStudentManager) TestStudentManager_SettleStudentPositive(t *testgroup
 		roomController: roomC}
 
 	// Act
-	execErr := manager.SettleStudent(StudentNumber, RoomID)
+	execErr := manager.SettleStudent(StudentNumber, RoomID, mother.DefaultStaffID)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
@@ -378,11 +722,8 @@ func (*TestStudentManager) TestStudentManager_SettleStudentNegativeStudentNotFou
 	studentObjectMother := mother.StudentRepoObjectMother{}
 
 	db, mock := studentObjectMother.CreateRepo()
-	studentsN := 4
-	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
 
-	firstStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnError(nil).WillReturnRows(firstStudentRows)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnError(sql.ErrNoRows)
 
 	studentRepository := studentRepo.PgStudentRepo{Conn: db}
 	userRepository := userRepo.PgUserRepo{Conn: db}
@@ -398,7 +739,7 @@ func (*TestStudentManager) TestStudentManager_SettleStudentNegativeStudentNotFou
 		roomController: roomC}
 
 	// Act
-	execErr := manager.SettleStudent(StudentNumber, RoomID)
+	execErr := manager.SettleStudent(StudentNumber, RoomID, mother.DefaultStaffID)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.StudentNotFoundErr)
@@ -421,16 +762,12 @@ func (*TestStudentManager) TestStudentManager_SettleStudentNegativeStudentIsLivi
 	studentsN := 4
 	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
 
-	roomsN := 1
-	realRooms := roomObjectMother.CreateDefaultRooms(roomsN)
-	rows := roomObjectMother.CreateRows(realRooms)
+	room := roomObjectMother.CreateRoom(RoomID, objects.Null, objects.GenderAny)
 
-	firstStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnError(nil).WillReturnRows(firstStudentRows)
-	mock.ExpectQuery("SELECT").WithArgs(RoomID).WillReturnError(nil).WillReturnRows(rows)
-
-	secondStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WithArgs(StudentID).WillReturnError(nil).WillReturnRows(secondStudentRows)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(StudentID))
+	mock.ExpectBegin()
+	roomObjectMother.ExpectLocks(mock, StudentID, allStudents[:1], room)
+	mock.ExpectRollback()
 
 	studentRepository := studentRepo.PgStudentRepo{Conn: db}
 	userRepository := userRepo.PgUserRepo{Conn: db}
@@ -446,7 +783,7 @@ func (*TestStudentManager) TestStudentManager_SettleStudentNegativeStudentIsLivi
 		roomController: roomC}
 
 	// Act
-	execErr := manager.SettleStudent(StudentNumber, RoomID)
+	execErr := manager.SettleStudent(StudentNumber, RoomID, mother.DefaultStaffID)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.StudentAlreadyLiveErr)
@@ -464,12 +801,16 @@ func (*TestStudentManager) TestStudentManager_SettleStudentNegativeRoomNotFound(
 	studentObjectMother := mother.StudentRepoObjectMother{}
 
 	db, mock := studentObjectMother.CreateRepo()
-	studentsN := 4
-	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
 
-	firstStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnError(nil).WillReturnRows(firstStudentRows)
+	allStudents := studentObjectMother.CreateDefaultStudents(1)
+	allStudents[0].SetRoomID(objects.NotLiving)
+
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(1))
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT").WithArgs(1).WillReturnRows(studentObjectMother.CreateRowForID(1))
+	mock.ExpectQuery("SELECT").WithArgs(1).WillReturnRows(studentObjectMother.CreateRows(allStudents))
 	mock.ExpectQuery("SELECT").WithArgs(RoomID).WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
 
 	studentRepository := studentRepo.PgStudentRepo{Conn: db}
 	userRepository := userRepo.PgUserRepo{Conn: db}
@@ -485,7 +826,7 @@ func (*TestStudentManager) TestStudentManager_SettleStudentNegativeRoomNotFound(
 		roomController: roomC}
 
 	// Act
-	execErr := manager.SettleStudent(StudentNumber, RoomID)
+	execErr := manager.SettleStudent(StudentNumber, RoomID, mother.DefaultStaffID)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.RoomNotFoundErr)
@@ -518,7 +859,7 @@ func (*TestStudentManager) TestStudentManager_SettleStudentBadRoomIDParam(t *tes
 		roomController: roomC}
 
 	// Act
-	execErr := manager.SettleStudent(StudentNumber, RoomID)
+	execErr := manager.SettleStudent(StudentNumber, RoomID, mother.DefaultStaffID)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.RoomNotFoundErr)
@@ -551,7 +892,7 @@ func (*TestStudentManager) TestStudentManager_SettleStudentBadStudNumberErr(t *t
 		roomController: roomC}
 
 	// Act
-	execErr := manager.SettleStudent(StudentNumber, RoomID)
+	execErr := manager.SettleStudent(StudentNumber, RoomID, mother.DefaultStaffID)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.BadStudentParamsErr)
@@ -573,13 +914,14 @@ func (*TestStudentManager) TestStudentManager_EvicStudentPositive(t *testgroup.T
 	studentsN := 4
 	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
 
-	firstStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnError(nil).WillReturnRows(firstStudentRows)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(StudentID))
 
-	secondStudentRows := studentObjectMother.CreateRows(allStudents[:1])
-	mock.ExpectQuery("SELECT").WithArgs(StudentID).WillReturnError(nil).WillReturnRows(secondStudentRows)
-	mock.ExpectExec("INSERT").WithArgs(StudentID, RoomID, objects.Ret).
+	mock.ExpectBegin()
+	studentObjectMother.ExpectLock(mock, StudentID, allStudents[:1])
+	studentObjectMother.ExpectDebts(mock, StudentID, objects.Null, objects.Null)
+	mock.ExpectExec("INSERT").WithArgs(StudentID, RoomID, objects.Ret, mother.DefaultStaffID).
 		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
 
 	studentRepository := studentRepo.PgStudentRepo{Conn: db}
 	userRepository := userRepo.PgUserRepo{Conn: db}
@@ -595,11 +937,129 @@ func (*TestStudentManager) TestStudentManager_EvicStudentPositive(t *testgroup.T
 		roomController: roomC}
 
 	// Act
-	execErr := manager.EvicStudent(StudentNumber)
+	execErr := manager.EvicStudent(StudentNumber, mother.DefaultStaffID, false)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+// TestStudentManager_EvicStudentNegativeClearance проверяет, что студент с долгами не может выехать из общежития.
+func (*TestStudentManager) TestStudentManager_EvicStudentNegativeClearance(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		StudentID     = 1
+		StudentNumber = mother.DefaultStudentNumber + "1"
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+
+	db, mock := studentObjectMother.CreateRepo()
+	students := studentObjectMother.CreateDefaultStudents(StudentID)
+
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(StudentID))
+	mock.ExpectBegin()
+	studentObjectMother.ExpectLock(mock, StudentID, students)
+	studentObjectMother.ExpectDebts(mock, StudentID, objects.Null, 1)
+	mock.ExpectRollback()
+
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	studentC := studentController.StudentController{Repo: &studentRepository}
+
+	manager := StudentManager{studentController: studentC}
+
+	// Act
+	execErr := manager.EvicStudent(StudentNumber, mother.DefaultStaffID, true)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.StudentClearanceErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestStudentManager_GetClearanceReportPositive проверяет, что выселенному студенту без долгов
+// выдаётся подписанный обходной лист.
+func (*TestStudentManager) TestStudentManager_GetClearanceReportPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		StudentID     = 1
+		StudentNumber = mother.DefaultStudentNumber + "1"
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	thingObjectMother := mother.ThingRepoObjectMother{}
+	tokenObjectMother := mother.TokenRepoObjectMother{}
+
+	db, mock := studentObjectMother.CreateRepo()
+	student := objects.NewStudentWithParams(StudentID, StudentID, mother.DefaultStudentName,
+		mother.DefaultStudentSurname, mother.DefaultGroup, StudentNumber, objects.NotLiving, mother.DefaultGender)
+
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(StudentID))
+	mock.ExpectQuery("SELECT").WithArgs(StudentID).
+		WillReturnRows(studentObjectMother.CreateRows([]objects.Student{student}))
+	mock.ExpectQuery("SELECT").WithArgs(StudentID).
+		WillReturnRows(studentObjectMother.CreateRows([]objects.Student{student}))
+	mock.ExpectQuery("SELECT").WithArgs(StudentID, nil, objects.Null).
+		WillReturnRows(thingObjectMother.CreateRows([]objects.Thing{}))
+	mock.ExpectQuery("SELECT").WithArgs(StudentID).
+		WillReturnRows(studentObjectMother.CreateFeeRows([]objects.Fee{}))
+
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	studentC := studentController.StudentController{Repo: &studentRepository}
+
+	manager := StudentManager{studentController: studentC,
+		keySet: tokenObjectMother.CreateKeySet(mother.DefaultKeyID)}
+
+	// Act
+	report, execErr := manager.GetClearanceReport(StudentNumber)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
 	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, report.HasBlockers(), false)
+	tests.AssertResult(t, report.Document != objects.EmptyString, true)
+}
+
+// TestStudentManager_GetClearanceReportNegativeBlockers проверяет, что вместо обходного листа
+// возвращаются комната и невозвращённые вещи.
+func (*TestStudentManager) TestStudentManager_GetClearanceReportNegativeBlockers(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		StudentID     = 1
+		StudentNumber = mother.DefaultStudentNumber + "1"
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	thingObjectMother := mother.ThingRepoObjectMother{}
+
+	db, mock := studentObjectMother.CreateRepo()
+	students := studentObjectMother.CreateDefaultStudents(StudentID)
+	things := thingObjectMother.CreateDefaultThings(2)
+
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(StudentID))
+	mock.ExpectQuery("SELECT").WithArgs(StudentID).WillReturnRows(studentObjectMother.CreateRows(students))
+	mock.ExpectQuery("SELECT").WithArgs(StudentID).WillReturnRows(studentObjectMother.CreateRows(students))
+	mock.ExpectQuery("SELECT").WithArgs(StudentID, nil, objects.Null).
+		WillReturnRows(thingObjectMother.CreateRows(things))
+	mock.ExpectQuery("SELECT").WithArgs(StudentID).
+		WillReturnRows(studentObjectMother.CreateFeeRows([]objects.Fee{}))
+
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	studentC := studentController.StudentController{Repo: &studentRepository}
+
+	manager := StudentManager{studentController: studentC}
+
+	// Act
+	report, execErr := manager.GetClearanceReport(StudentNumber)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.StudentClearanceErr)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, report.RoomID, students[0].GetRoomID())
+	tests.AssertResult(t, report.Things, things)
+	tests.AssertResult(t, report.Document, objects.EmptyString)
 }
 
 func (*TestStudentManager) TestStudentManager_EvicStudentNegativeStudentNotFound(t *testgroup.T) {
@@ -612,11 +1072,8 @@ func (*TestStudentManager) TestStudentManager_EvicStudentNegativeStudentNotFound
 	studentObjectMother := mother.StudentRepoObjectMother{}
 
 	db, mock := studentObjectMother.CreateRepo()
-	studentsN := 4
-	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
 
-	firstStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnError(nil).WillReturnRows(firstStudentRows)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnError(sql.ErrNoRows)
 
 	studentRepository := studentRepo.PgStudentRepo{Conn: db}
 	userRepository := userRepo.PgUserRepo{Conn: db}
@@ -632,7 +1089,7 @@ func (*TestStudentManager) TestStudentManager_EvicStudentNegativeStudentNotFound
 		roomController: roomC}
 
 	// Act
-	execErr := manager.EvicStudent(StudentNumber)
+	execErr := manager.EvicStudent(StudentNumber, mother.DefaultStaffID, false)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.StudentNotFoundErr)
@@ -653,12 +1110,13 @@ func (*TestStudentManager) TestStudentManager_EvicStudentNegativeStudentNotLivin
 	studentsN := 4
 	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
 
-	firstStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnError(nil).WillReturnRows(firstStudentRows)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(StudentID))
 
 	allStudents[0].SetRoomID(objects.NotLiving)
-	secondStudentRows := studentObjectMother.CreateRows(allStudents[:1])
-	mock.ExpectQuery("SELECT").WithArgs(StudentID).WillReturnError(nil).WillReturnRows(secondStudentRows)
+	mock.ExpectBegin()
+	studentObjectMother.ExpectLock(mock, StudentID, allStudents[:1])
+	studentObjectMother.ExpectDebts(mock, StudentID, objects.Null, objects.Null)
+	mock.ExpectRollback()
 
 	studentRepository := studentRepo.PgStudentRepo{Conn: db}
 	userRepository := userRepo.PgUserRepo{Conn: db}
@@ -674,7 +1132,7 @@ func (*TestStudentManager) TestStudentManager_EvicStudentNegativeStudentNotLivin
 		roomController: roomC}
 
 	// Act
-	execErr := manager.EvicStudent(StudentNumber)
+	execErr := manager.EvicStudent(StudentNumber, mother.DefaultStaffID, false)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.StudentNotLivingErr)
@@ -706,7 +1164,7 @@ func (*TestStudentManager) TestStudentManager_EvicStudentBadParam(t *testgroup.T
 		roomController: roomC}
 
 	// Act
-	execErr := manager.EvicStudent(StudentNumber)
+	execErr := manager.EvicStudent(StudentNumber, mother.DefaultStaffID, false)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.BadStudentParamsErr)
@@ -789,6 +1247,101 @@ func (*TestStudentManager) TestStudentManager_GetStudentByAccIDNegative(t *testg
 	tests.AssertMocks(t, mock)
 }
 
+func (*TestStudentManager) TestStudentManager_CheckStudentAccessPositiveOwnRecord(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		AccID         = 1
+		StudentNumber = mother.DefaultStudentNumber + "1"
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	userObjectMother := mother.UserRepoObjectMother{}
+
+	db, mock := studentObjectMother.CreateRepo()
+	studentsN := 4
+	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
+
+	userRows := userObjectMother.CreateRowForID(AccID)
+	studentRows := studentObjectMother.CreateRows(allStudents)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(nil).WillReturnRows(userRows)
+	mock.ExpectQuery("SELECT").WillReturnError(nil).WillReturnRows(studentRows)
+
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	userRepository := userRepo.PgUserRepo{Conn: db}
+
+	studentC := studentController.StudentController{Repo: &studentRepository}
+	userC := userController.UserController{Repo: &userRepository}
+
+	manager := StudentManager{studentController: studentC, userController: userC}
+
+	// Act
+	execErr := manager.CheckStudentAccess(mother.DefaultLogin, objects.StudentRole, StudentNumber)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestStudentManager) TestStudentManager_CheckStudentAccessNegativeOtherStudent(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		AccID         = 1
+		StudentNumber = mother.DefaultStudentNumber + "2"
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	userObjectMother := mother.UserRepoObjectMother{}
+
+	db, mock := studentObjectMother.CreateRepo()
+	studentsN := 4
+	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
+
+	userRows := userObjectMother.CreateRowForID(AccID)
+	studentRows := studentObjectMother.CreateRows(allStudents)
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(nil).WillReturnRows(userRows)
+	mock.ExpectQuery("SELECT").WillReturnError(nil).WillReturnRows(studentRows)
+
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	userRepository := userRepo.PgUserRepo{Conn: db}
+
+	studentC := studentController.StudentController{Repo: &studentRepository}
+	userC := userController.UserController{Repo: &userRepository}
+
+	manager := StudentManager{studentController: studentC, userController: userC}
+
+	// Act
+	execErr := manager.CheckStudentAccess(mother.DefaultLogin, objects.StudentRole, StudentNumber)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.AccessDeniedErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestStudentManager) TestStudentManager_CheckStudentAccessPositiveStaff(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	db, mock := studentObjectMother.CreateRepo()
+
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	userRepository := userRepo.PgUserRepo{Conn: db}
+
+	studentC := studentController.StudentController{Repo: &studentRepository}
+	userC := userController.UserController{Repo: &userRepository}
+
+	manager := StudentManager{studentController: studentC, userController: userC}
+
+	// Act
+	execErr := manager.CheckStudentAccess(mother.DefaultLogin, objects.ComendRole, mother.DefaultStudentNumber)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
 func (*TestStudentManager) TestStudentManager_ViewAllStudents(t *testgroup.T) {
 	defer tests.TimeTrack(time.Now())
 	// Arrange
@@ -801,6 +1354,8 @@ func (*TestStudentManager) TestStudentManager_ViewAllStudents(t *testgroup.T) {
 
 	firstStudentRows := studentObjectMother.CreateRows(allStudents)
 	mock.ExpectQuery("SELECT").WillReturnError(nil).WillReturnRows(firstStudentRows)
+	mock.ExpectQuery("SELECT count").WillReturnError(nil).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(studentsN))
 
 	studentRepository := studentRepo.PgStudentRepo{Conn: db}
 	userRepository := userRepo.PgUserRepo{Conn: db}
@@ -816,12 +1371,14 @@ func (*TestStudentManager) TestStudentManager_ViewAllStudents(t *testgroup.T) {
 		roomController: roomC}
 
 	// Act
-	realStudents, execErr := manager.ViewAllStudents()
+	realStudents, total, execErr := manager.ViewAllStudents(objects.NewEmptyStudentFilter(), objects.Null,
+		objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
 	tests.AssertMocks(t, mock)
 	tests.AssertResult(t, realStudents, allStudents)
+	tests.AssertResult(t, total, studentsN)
 }
 
 func (*TestStudentManager) TestStudentManager_ViewStudentPositive(t *testgroup.T) {
@@ -882,11 +1439,8 @@ func (*TestStudentManager) TestStudentManager_ViewStudentNegative(t *testgroup.T
 	studentObjectMother := mother.StudentRepoObjectMother{}
 
 	db, mock := studentObjectMother.CreateRepo()
-	studentsN := 4
-	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
 
-	firstStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnError(nil).WillReturnRows(firstStudentRows)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnError(sql.ErrNoRows)
 
 	studentRepository := studentRepo.PgStudentRepo{Conn: db}
 	userRepository := userRepo.PgUserRepo{Conn: db}
@@ -929,8 +1483,7 @@ func (*TestStudentManager) TestStudentManager_GiveStudentThingPositive(t *testgr
 	realThings := thingObjectMother.CreateDefaultThings(thingsN)
 	realThings[0].SetOwnerID(objects.None)
 
-	firstAllStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnRows(firstAllStudentRows).WillReturnError(nil)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(StudentID))
 
 	mock.ExpectQuery("SELECT").WithArgs(MarkNumber).WillReturnError(nil).
 		WillReturnRows(thingObjectMother.CreateRowForID(ThingID))
@@ -938,11 +1491,11 @@ func (*TestStudentManager) TestStudentManager_GiveStudentThingPositive(t *testgr
 	realThingsRow := thingObjectMother.CreateRows(realThings)
 	mock.ExpectQuery("SELECT").WithArgs(ThingID).WillReturnError(nil).WillReturnRows(realThingsRow)
 
-	secondAllStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WithArgs(StudentID).WillReturnError(nil).WillReturnRows(secondAllStudentRows)
-
+	mock.ExpectBegin()
+	studentObjectMother.ExpectLock(mock, StudentID, allStudents)
 	mock.ExpectExec("INSERT").WithArgs(StudentID, ThingID, objects.Get).WillReturnError(nil).
 		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectCommit()
 
 	studentRepository := studentRepo.PgStudentRepo{Conn: db}
 	userRepository := userRepo.PgUserRepo{Conn: db}
@@ -977,14 +1530,11 @@ func (*TestStudentManager) TestStudentManager_GiveStudentThingNegativeStudentNot
 	thingObjectMother := mother.ThingRepoObjectMother{}
 
 	db, mock := studentObjectMother.CreateRepo()
-	studentsN := 4
 	thingsN := 1
-	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
 	realThings := thingObjectMother.CreateDefaultThings(thingsN)
 	realThings[0].SetOwnerID(objects.None)
 
-	firstAllStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnRows(firstAllStudentRows).WillReturnError(nil)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnError(sql.ErrNoRows)
 
 	studentRepository := studentRepo.PgStudentRepo{Conn: db}
 	userRepository := userRepo.PgUserRepo{Conn: db}
@@ -1019,14 +1569,11 @@ func (*TestStudentManager) TestStudentManager_GiveStudentThingNegativeThingNotFo
 	thingObjectMother := mother.ThingRepoObjectMother{}
 
 	db, mock := studentObjectMother.CreateRepo()
-	studentsN := 4
 	thingsN := 1
-	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
 	realThings := thingObjectMother.CreateDefaultThings(thingsN)
 	realThings[0].SetOwnerID(objects.None)
 
-	firstAllStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnRows(firstAllStudentRows).WillReturnError(nil)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(3))
 
 	mock.ExpectQuery("SELECT").WithArgs(MarkNumber).WillReturnError(sql.ErrNoRows)
 
@@ -1064,13 +1611,10 @@ func (*TestStudentManager) TestStudentManager_GiveStudentThingNegativeThingHasOw
 	thingObjectMother := mother.ThingRepoObjectMother{}
 
 	db, mock := studentObjectMother.CreateRepo()
-	studentsN := 4
 	thingsN := 1
-	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
 	realThings := thingObjectMother.CreateDefaultThings(thingsN)
 
-	firstAllStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnRows(firstAllStudentRows).WillReturnError(nil)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(3))
 
 	mock.ExpectQuery("SELECT").WithArgs(MarkNumber).WillReturnError(nil).
 		WillReturnRows(thingObjectMother.CreateRowForID(ThingID))
@@ -1185,8 +1729,7 @@ func (*TestStudentManager) TestStudentManager_ReturnStudentThingPositive(t *test
 	realThings := thingObjectMother.CreateDefaultThings(thingsN)
 	realThings[0].SetOwnerID(StudentID)
 
-	firstAllStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnRows(firstAllStudentRows).WillReturnError(nil)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(StudentID))
 
 	mock.ExpectQuery("SELECT").WithArgs(MarkNumber).WillReturnError(nil).
 		WillReturnRows(thingObjectMother.CreateRowForID(ThingID))
@@ -1233,14 +1776,11 @@ func (*TestStudentManager) TestStudentManager_ReturnStudentThingNegativeStudentN
 	thingObjectMother := mother.ThingRepoObjectMother{}
 
 	db, mock := studentObjectMother.CreateRepo()
-	studentsN := 4
 	thingsN := 1
-	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
 	realThings := thingObjectMother.CreateDefaultThings(thingsN)
 	realThings[0].SetOwnerID(objects.None)
 
-	firstAllStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnRows(firstAllStudentRows).WillReturnError(nil)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnError(sql.ErrNoRows)
 
 	studentRepository := studentRepo.PgStudentRepo{Conn: db}
 	userRepository := userRepo.PgUserRepo{Conn: db}
@@ -1275,14 +1815,11 @@ func (*TestStudentManager) TestStudentManager_ReturnStudentThingNegativeThingNot
 	thingObjectMother := mother.ThingRepoObjectMother{}
 
 	db, mock := studentObjectMother.CreateRepo()
-	studentsN := 4
 	thingsN := 1
-	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
 	realThings := thingObjectMother.CreateDefaultThings(thingsN)
 	realThings[0].SetOwnerID(objects.None)
 
-	firstAllStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnRows(firstAllStudentRows).WillReturnError(nil)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(3))
 
 	mock.ExpectQuery("SELECT").WithArgs(MarkNumber).WillReturnError(sql.ErrNoRows)
 
@@ -1320,14 +1857,11 @@ func (*TestStudentManager) TestStudentManager_ReturnStudentThingNegativeThingHas
 	thingObjectMother := mother.ThingRepoObjectMother{}
 
 	db, mock := studentObjectMother.CreateRepo()
-	studentsN := 4
 	thingsN := 1
-	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
 	realThings := thingObjectMother.CreateDefaultThings(thingsN)
 	realThings[0].SetOwnerID(objects.None)
 
-	firstAllStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnRows(firstAllStudentRows).WillReturnError(nil)
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(3))
 
 	mock.ExpectQuery("SELECT").WithArgs(MarkNumber).WillReturnError(nil).
 		WillReturnRows(thingObjectMother.CreateRowForID(ThingID))
@@ -1421,3 +1955,59 @@ func (*TestStudentManager) TestStudentManager_ReturnStudentThingNegativeBadStude
 	tests.AssertErrors(t, execErr, appErrors.BadStudentParamsErr)
 	tests.AssertMocks(t, mock)
 }
+
+func (*TestStudentManager) TestStudentManager_ViewLivingHistoryPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		StudentID     = 1
+		StudentNumber = mother.DefaultStudentNumber + "1"
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+
+	db, mock := studentObjectMother.CreateRepo()
+	realRecords := studentObjectMother.CreateLivingRecords(StudentID, 3)
+
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnRows(studentObjectMother.CreateRowForID(StudentID))
+	recordRows := studentObjectMother.CreateLivingRecordRows(realRecords)
+	mock.ExpectQuery("SELECT").WithArgs(StudentID, nil, nil, nil, objects.Null).
+		WillReturnError(nil).WillReturnRows(recordRows)
+
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	studentC := studentController.StudentController{Repo: &studentRepository}
+	manager := StudentManager{studentController: studentC}
+
+	// Act
+	records, execErr := manager.ViewLivingHistory(StudentNumber, time.Time{}, time.Time{}, objects.Null, objects.Null)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, records, realRecords)
+}
+
+func (*TestStudentManager) TestStudentManager_ViewLivingHistoryNegativeStudentNotFound(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		StudentNumber = mother.DefaultStudentNumber + "9"
+	)
+	studentObjectMother := mother.StudentRepoObjectMother{}
+
+	db, mock := studentObjectMother.CreateRepo()
+
+	mock.ExpectQuery("SELECT").WithArgs(StudentNumber).WillReturnError(sql.ErrNoRows)
+
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	studentC := studentController.StudentController{Repo: &studentRepository}
+	manager := StudentManager{studentController: studentC}
+
+	// Act
+	_, execErr := manager.ViewLivingHistory(StudentNumber, time.Time{}, time.Time{}, objects.Null, objects.Null)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.StudentNotFoundErr)
+	tests.AssertMocks(t, mock)
+}
@@ -0,0 +1,86 @@
+package swapManager
+
+import (
+	"src/logic/controllers/studentController"
+	"src/logic/controllers/swapController"
+	"src/objects"
+	appErrors "src/utils/error"
+)
+
+type SwapManager struct {
+	swapController    swapController.SwapController
+	studentController studentController.StudentController
+}
+
+func CreateNewSwapManager(swc swapController.SwapController, sc studentController.StudentController) *SwapManager {
+	return &SwapManager{
+		swapController:    swc,
+		studentController: sc,
+	}
+}
+
+func (sm *SwapManager) getStudentByNumber(studentNumber string) (objects.Student, error) {
+	studentID, err := sm.studentController.GetStudentIDByNumber(studentNumber)
+	if err != nil {
+		return objects.NewEmptyStudent(), err
+	}
+	return sm.studentController.GetStudent(studentID)
+}
+
+// ProposeSwap offers the partner to swap rooms with the initiator.
+func (sm *SwapManager) ProposeSwap(initiatorNumber, partnerNumber string) (int, error) {
+	if initiatorNumber == objects.EmptyString || partnerNumber == objects.EmptyString {
+		return objects.None, appErrors.BadStudentParamsErr
+	}
+
+	initiator, err := sm.getStudentByNumber(initiatorNumber)
+	if err != nil {
+		return objects.None, err
+	}
+	partner, err := sm.getStudentByNumber(partnerNumber)
+	if err != nil {
+		return objects.None, err
+	}
+	return sm.swapController.AddSwap(initiator, partner)
+}
+
+func (sm *SwapManager) GetSwap(id int) (objects.RoomSwap, error) {
+	return sm.swapController.GetSwap(id)
+}
+
+func (sm *SwapManager) GetSwaps(status objects.SwapStatus, page, size int) ([]objects.RoomSwap, error) {
+	return sm.swapController.GetSwaps(status, page, size)
+}
+
+func (sm *SwapManager) GetStudentSwaps(studentNumber string) ([]objects.RoomSwap, error) {
+	studentID, err := sm.studentController.GetStudentIDByNumber(studentNumber)
+	if err != nil {
+		return make([]objects.RoomSwap, objects.Empty), err
+	}
+	return sm.swapController.GetStudentSwaps(studentID)
+}
+
+func (sm *SwapManager) AcceptSwap(id int) error {
+	swap, err := sm.swapController.GetSwap(id)
+	if err == nil {
+		err = sm.swapController.AcceptSwap(swap)
+	}
+	return err
+}
+
+func (sm *SwapManager) RejectSwap(id int) error {
+	swap, err := sm.swapController.GetSwap(id)
+	if err == nil {
+		err = sm.swapController.RejectSwap(swap)
+	}
+	return err
+}
+
+// ConfirmSwap moves both students of the accepted swap, performedBy is the commandant confirming it.
+func (sm *SwapManager) ConfirmSwap(id, performedBy int) error {
+	swap, err := sm.swapController.GetSwap(id)
+	if err != nil {
+		return err
+	}
+	return sm.swapController.ConfirmSwap(swap, performedBy)
+}
@@ -0,0 +1,122 @@
+package swapManager
+
+import (
+	"database/sql"
+	"github.com/bloomberg/go-testgroup"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/db/studentRepo"
+	"src/db/swapRepo"
+	"src/logic/controllers/studentController"
+	"src/logic/controllers/swapController"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	appErrors "src/utils/error"
+	"testing"
+	"time"
+)
+
+const (
+	InsertID     = 5
+	RowsAffected = 1
+)
+
+type TestSwapManager struct{}
+
+func Test_SwapManager(t *testing.T) {
+	testgroup.RunSerially(t, &TestSwapManager{})
+}
+
+func (*TestSwapManager) TestSwapManager_ProposeSwapPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SwapRepoObjectMother{}
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	students := studentObjectMother.CreateDefaultStudents(3)
+	mock.ExpectQuery("SELECT").WithArgs(students[0].GetStudentNumber()).
+		WillReturnRows(studentObjectMother.CreateRowForID(1))
+	mock.ExpectQuery("SELECT").WithArgs(1).WillReturnRows(studentObjectMother.CreateRows(students[:1]))
+	mock.ExpectQuery("SELECT").WithArgs(students[1].GetStudentNumber()).
+		WillReturnRows(studentObjectMother.CreateRowForID(2))
+	mock.ExpectQuery("SELECT").WithArgs(2).WillReturnRows(studentObjectMother.CreateRows(students[1:2]))
+	mock.ExpectQuery("SELECT").WithArgs(1).WillReturnRows(objectMother.CreateRows(nil))
+	mock.ExpectQuery("SELECT").WithArgs(2).WillReturnRows(objectMother.CreateRows(nil))
+	mock.ExpectQuery("INSERT INTO").WithArgs(1, 1, 2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(InsertID))
+
+	swapRepository := swapRepo.PgSwapRepo{Conn: db}
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	manager := SwapManager{swapController: swapController.SwapController{Repo: &swapRepository},
+		studentController: studentController.StudentController{Repo: &studentRepository}}
+
+	// Act
+	id, execErr := manager.ProposeSwap(students[0].GetStudentNumber(), students[1].GetStudentNumber())
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, id, InsertID)
+}
+
+// TestSwapManager_ProposeSwapNegativePartnerNotFound проверяет, что нельзя предложить обмен несуществующему студенту.
+func (*TestSwapManager) TestSwapManager_ProposeSwapNegativePartnerNotFound(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SwapRepoObjectMother{}
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	students := studentObjectMother.CreateDefaultStudents(3)
+	mock.ExpectQuery("SELECT").WithArgs(students[0].GetStudentNumber()).
+		WillReturnRows(studentObjectMother.CreateRowForID(1))
+	mock.ExpectQuery("SELECT").WithArgs(1).WillReturnRows(studentObjectMother.CreateRows(students[:1]))
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultStudentNumber + "7").WillReturnError(sql.ErrNoRows)
+
+	swapRepository := swapRepo.PgSwapRepo{Conn: db}
+	studentRepository := studentRepo.PgStudentRepo{Conn: db}
+	manager := SwapManager{swapController: swapController.SwapController{Repo: &swapRepository},
+		studentController: studentController.StudentController{Repo: &studentRepository}}
+
+	// Act
+	_, execErr := manager.ProposeSwap(students[0].GetStudentNumber(), mother.DefaultStudentNumber+"7")
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.StudentNotFoundErr)
+	tests.AssertMocks(t, mock)
+}
+
+// TestSwapManager_ConfirmSwapPositive проверяет, что подтверждённый обмен переселяет обоих студентов.
+func (*TestSwapManager) TestSwapManager_ConfirmSwapPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.SwapRepoObjectMother{}
+	studentObjectMother := mother.StudentRepoObjectMother{}
+	roomObjectMother := mother.RoomRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	swap := objectMother.CreateSwap(1, 1, 2, objects.SwapAccepted)
+	students := studentObjectMother.CreateDefaultStudents(2)
+	rooms := roomObjectMother.CreateDefaultRooms(2)
+	mock.ExpectQuery("SELECT").WithArgs(swap.GetID()).
+		WillReturnRows(objectMother.CreateRows([]objects.RoomSwap{swap}))
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE").WithArgs(swap.GetID(), objects.SwapAccepted, objects.SwapConfirmed).
+		WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	objectMother.ExpectLocks(mock, students, rooms)
+	for i := 0; i < 4; i++ {
+		mock.ExpectExec("INSERT INTO").WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	}
+	mock.ExpectCommit()
+
+	swapRepository := swapRepo.PgSwapRepo{Conn: db}
+	manager := SwapManager{swapController: swapController.SwapController{Repo: &swapRepository}}
+
+	// Act
+	execErr := manager.ConfirmSwap(swap.GetID(), mother.DefaultStaffID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
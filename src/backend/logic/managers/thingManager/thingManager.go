@@ -1,6 +1,7 @@
 package thingManager
 
 import (
+	"sort"
 	"src/logic/controllers/roomController"
 	"src/logic/controllers/studentController"
 	"src/logic/controllers/thingController"
@@ -117,6 +118,38 @@ func (tm *ThingManager) TransferThing(markNumber int, roomID int) error {
 	return err
 }
 
+// GetThingHistory merges owner acts with room moves. Acts of one day keep the owner ones first.
+func (tm *ThingManager) GetThingHistory(markNumber int) (models.ThingHistory, error) {
+	var result models.ThingHistory
+	if markNumber <= objects.None {
+		return result, appErrors.BadThingParamsErr
+	}
+
+	thingID, err := tm.thingController.GetThingIDByMarkNumber(markNumber)
+	if err != nil {
+		return result, err
+	}
+	result.Thing, err = tm.thingController.GetThing(thingID)
+	if err != nil {
+		return result, err
+	}
+	ownerEvents, err := tm.thingController.GetOwnerHistory(thingID)
+	if err != nil {
+		return result, err
+	}
+	roomEvents, err := tm.thingController.GetRoomHistory(thingID)
+	if err != nil {
+		return result, err
+	}
+
+	result.Owners = objects.CreateOwnershipPeriods(ownerEvents)
+	result.Events = append(ownerEvents, roomEvents...)
+	sort.SliceStable(result.Events, func(i, j int) bool {
+		return result.Events[i].GetDate().Before(result.Events[j].GetDate())
+	})
+	return result, nil
+}
+
 func (tm *ThingManager) GetOwner(markNumber int) (string, error) {
 	var result = objects.EmptyString
 	if markNumber <= objects.None {
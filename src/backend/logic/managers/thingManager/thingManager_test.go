@@ -169,7 +169,7 @@ func (*TestThingManager) TestThingManager_GetFreeThings(t *testgroup.T) {
 		roomController: roomC}
 
 	// Act
-	_, execErr := manager.GetFreeThings()
+	_, execErr := manager.GetFreeThings(objects.Null, objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
@@ -206,7 +206,7 @@ func (*TestThingManager) TestThingManager_GetFullThingInfo(t *testgroup.T) {
 		roomController: roomC}
 
 	// Act
-	_, execErr := manager.GetFullThingInfo()
+	_, execErr := manager.GetFullThingInfo(objects.Null, objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
@@ -260,7 +260,7 @@ func (*TestThingManager) TestThingManager_GetStudentThingsPositive(t *testgroup.
 		roomController: roomC}
 
 	// Act
-	_, execErr := manager.GetStudentThings(studentNumber)
+	_, execErr := manager.GetStudentThings(studentNumber, objects.Null, objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, nil)
@@ -273,16 +273,11 @@ func (*TestThingManager) TestThingManager_GetStudentThingsNegativeStudentNotFoun
 
 	studentNumber := mother.DefaultStudentNumber + "6"
 
-	studentObjectMother := mother.StudentRepoObjectMother{}
 	thingObjectMother := mother.ThingRepoObjectMother{}
 
 	db, mock := thingObjectMother.CreateRepo()
-	studentsN := 4
-
-	allStudents := studentObjectMother.CreateDefaultStudents(studentsN)
 
-	firstAllStudentRows := studentObjectMother.CreateRows(allStudents)
-	mock.ExpectQuery("SELECT").WillReturnRows(firstAllStudentRows).WillReturnError(nil)
+	mock.ExpectQuery("SELECT").WithArgs(studentNumber).WillReturnError(sql.ErrNoRows)
 
 	studentRepository := studentRepo.PgStudentRepo{Conn: db}
 	thingRepository := thingRepo.PgThingRepo{Conn: db}
@@ -296,7 +291,7 @@ func (*TestThingManager) TestThingManager_GetStudentThingsNegativeStudentNotFoun
 		roomController: roomC}
 
 	// Act
-	_, execErr := manager.GetStudentThings(studentNumber)
+	_, execErr := manager.GetStudentThings(studentNumber, objects.Null, objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.StudentNotFoundErr)
@@ -325,7 +320,7 @@ func (*TestThingManager) TestThingManager_GetStudentThingsNegativeBadStudNumber(
 		roomController: roomC}
 
 	// Act
-	_, execErr := manager.GetStudentThings(studentNumber)
+	_, execErr := manager.GetStudentThings(studentNumber, objects.Null, objects.Null)
 
 	// Assert
 	tests.AssertErrors(t, execErr, appErrors.BadStudentParamsErr)
@@ -514,3 +509,67 @@ func (*TestThingManager) TestThingManager_TransferThingBadRoom(t *testgroup.T) {
 	tests.AssertErrors(t, execErr, appErrors.BadDstRoomErr)
 	tests.AssertMocks(t, mock)
 }
+
+func (*TestThingManager) TestThingManager_GetThingHistoryPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var (
+		ThingID    = 1
+		MarkNumber = mother.DefaultMarkNumber + 1
+	)
+
+	thingObjectMother := mother.ThingRepoObjectMother{}
+
+	db, mock := thingObjectMother.CreateRepo()
+	things := thingObjectMother.CreateDefaultThings(1)
+	ownerEvents := thingObjectMother.CreateOwnerEvents(2)
+	moveEvent := objects.NewMoveEvent(time.Date(2023, time.September, 15, 0, 0, 0, 0, time.UTC), 1, 2)
+
+	mock.ExpectQuery("SELECT").WithArgs(MarkNumber).WillReturnError(nil).
+		WillReturnRows(thingObjectMother.CreateRowForID(ThingID))
+	mock.ExpectQuery("SELECT").WithArgs(ThingID).WillReturnError(nil).
+		WillReturnRows(thingObjectMother.CreateRows(things))
+	mock.ExpectQuery("SELECT").WithArgs(ThingID).WillReturnError(nil).
+		WillReturnRows(thingObjectMother.CreateOwnerEventRows(ownerEvents))
+	mock.ExpectQuery("SELECT").WithArgs(ThingID).WillReturnError(nil).
+		WillReturnRows(thingObjectMother.CreateMoveEventRows([]objects.ThingEvent{moveEvent}))
+
+	thingRepository := thingRepo.PgThingRepo{Conn: db}
+	thingC := thingController.ThingController{Repo: &thingRepository}
+
+	manager := ThingManager{thingController: thingC}
+
+	// Act
+	history, execErr := manager.GetThingHistory(MarkNumber)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+	tests.AssertResult(t, len(history.Owners), 2)
+	tests.AssertResult(t, history.Owners[0].GetDaysHeld(time.Now()), 30)
+	tests.AssertResult(t, len(history.Events), 5)
+	tests.AssertResult(t, history.Events[1], moveEvent)
+}
+
+func (*TestThingManager) TestThingManager_GetThingHistoryNegativeThingNotFound(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	thingObjectMother := mother.ThingRepoObjectMother{}
+
+	db, mock := thingObjectMother.CreateRepo()
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultMarkNumber).WillReturnError(sql.ErrNoRows)
+
+	thingRepository := thingRepo.PgThingRepo{Conn: db}
+	thingC := thingController.ThingController{Repo: &thingRepository}
+
+	manager := ThingManager{thingController: thingC}
+
+	// Act
+	_, execErr := manager.GetThingHistory(mother.DefaultMarkNumber)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.ThingNotFoundErr)
+	tests.AssertMocks(t, mock)
+}
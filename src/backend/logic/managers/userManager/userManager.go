@@ -0,0 +1,83 @@
+package userManager
+
+import (
+	"src/logic/controllers/tokenController"
+	"src/logic/controllers/userController"
+	"src/objects"
+	appErrors "src/utils/error"
+)
+
+type UserManager struct {
+	userController  userController.UserController
+	tokenController tokenController.TokenController
+}
+
+func CreateNewUserManager(uc userController.UserController, tc tokenController.TokenController) *UserManager {
+	return &UserManager{
+		userController:  uc,
+		tokenController: tc,
+	}
+}
+
+// AddStaffUser creates supply manager and commandant accounts. Student accounts are created with the student.
+func (um *UserManager) AddStaffUser(login, password string, role objects.Levels) error {
+	if !isStaffRole(role) {
+		return appErrors.BadRoleErr
+	}
+	return um.userController.AddUser(login, password, role)
+}
+
+func (um *UserManager) GetUsers(page, size int) ([]objects.User, error) {
+	return um.userController.GetUsers(page, size)
+}
+
+// DisableUser blocks the login and closes all sessions of the account.
+func (um *UserManager) DisableUser(callerID, id int) error {
+	err := um.checkStaffUser(callerID, id)
+	if err == nil {
+		err = um.userController.SetUserDisabled(id, true)
+	}
+	if err == nil {
+		err = um.tokenController.RevokeUserSessions(id)
+	}
+	return err
+}
+
+func (um *UserManager) EnableUser(callerID, id int) error {
+	err := um.checkStaffUser(callerID, id)
+	if err == nil {
+		err = um.userController.SetUserDisabled(id, false)
+	}
+	return err
+}
+
+// ChangeUserRole moves a staff account between supply and commandant roles.
+// All sessions of the account are closed, so tokens with the old role stop working at once.
+func (um *UserManager) ChangeUserRole(callerID, id int, role objects.Levels) error {
+	if !isStaffRole(role) {
+		return appErrors.BadRoleErr
+	}
+	err := um.checkStaffUser(callerID, id)
+	if err == nil {
+		err = um.userController.ChangeUserRole(id, role)
+	}
+	if err == nil {
+		err = um.tokenController.RevokeUserSessions(id)
+	}
+	return err
+}
+
+func (um *UserManager) checkStaffUser(callerID, id int) error {
+	if callerID == id {
+		return appErrors.SelfActionErr
+	}
+	user, err := um.userController.GetUser(id)
+	if err == nil && !isStaffRole(user.GetPrivelegeLevel()) {
+		err = appErrors.NotStaffUserErr
+	}
+	return err
+}
+
+func isStaffRole(role objects.Levels) bool {
+	return role == objects.SupplyRole || role == objects.ComendRole
+}
@@ -0,0 +1,246 @@
+package userManager
+
+import (
+	"database/sql"
+	"github.com/bloomberg/go-testgroup"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/db/tokenRepo"
+	"src/db/userRepo"
+	"src/logic/controllers/tokenController"
+	"src/logic/controllers/userController"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	appErrors "src/utils/error"
+	"testing"
+	"time"
+)
+
+var (
+	InsertID     int64 = 5
+	RowsAffected int64 = 1
+)
+
+type TestUserManager struct{}
+
+func Test_UserManager(t *testing.T) {
+	testgroup.RunSerially(t, &TestUserManager{})
+}
+
+func createManager(db *sql.DB) *UserManager {
+	repo := userRepo.PgUserRepo{Conn: db}
+	tokenRepository := tokenRepo.PgTokenRepo{Conn: db}
+	return CreateNewUserManager(userController.UserController{Repo: &repo},
+		tokenController.TokenController{Repo: &tokenRepository})
+}
+
+func (*TestUserManager) TestUserManager_AddStaffUserPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	mock.ExpectQuery("SELECT").WithArgs(mother.DefaultLogin).WillReturnError(sql.ErrNoRows)
+	mock.ExpectExec("INSERT INTO").WithArgs(mother.DefaultLogin,
+		tests.HashedPasswordArg{Password: mother.DefaultPassword}, objects.SupplyRole).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	manager := createManager(db)
+
+	// Act
+	execErr := manager.AddStaffUser(mother.DefaultLogin, mother.DefaultPassword, objects.SupplyRole)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestUserManager) TestUserManager_AddStaffUserNegativeStudentRole(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+
+	manager := createManager(db)
+
+	// Act
+	execErr := manager.AddStaffUser(mother.DefaultLogin, mother.DefaultPassword, objects.StudentRole)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadRoleErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestUserManager) TestUserManager_DisableUserPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	callerID := 1
+	ID := 2
+	user := objectMother.CreateUserWithRole(ID, objects.SupplyRole)
+	firstRows := objectMother.CreateRows([]objects.User{user})
+	secondRows := objectMother.CreateRows([]objects.User{user})
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(firstRows)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(secondRows)
+	mock.ExpectExec("UPDATE").WithArgs(true, ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("UPDATE").WithArgs(ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").WithArgs(ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	manager := createManager(db)
+
+	// Act
+	execErr := manager.DisableUser(callerID, ID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestUserManager) TestUserManager_DisableUserNegativeSelf(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	ID := 1
+
+	manager := createManager(db)
+
+	// Act
+	execErr := manager.DisableUser(ID, ID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.SelfActionErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestUserManager) TestUserManager_DisableUserNegativeStudent(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	callerID := 1
+	ID := 2
+	user := objectMother.CreateUserWithRole(ID, objects.StudentRole)
+	rows := objectMother.CreateRows([]objects.User{user})
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(rows)
+
+	manager := createManager(db)
+
+	// Act
+	execErr := manager.DisableUser(callerID, ID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.NotStaffUserErr)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestUserManager) TestUserManager_EnableUserPositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	callerID := 1
+	ID := 2
+	user := objectMother.CreateUserWithRole(ID, objects.ComendRole)
+	user.SetDisabled(true)
+	firstRows := objectMother.CreateRows([]objects.User{user})
+	secondRows := objectMother.CreateRows([]objects.User{user})
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(firstRows)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(secondRows)
+	mock.ExpectExec("UPDATE").WithArgs(false, ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	manager := createManager(db)
+
+	// Act
+	execErr := manager.EnableUser(callerID, ID)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestUserManager) TestUserManager_ChangeUserRolePositive(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	callerID := 1
+	ID := 2
+	user := objectMother.CreateUserWithRole(ID, objects.SupplyRole)
+	firstRows := objectMother.CreateRows([]objects.User{user})
+	secondRows := objectMother.CreateRows([]objects.User{user})
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(firstRows)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(secondRows)
+	mock.ExpectExec("UPDATE").WithArgs(objects.ComendRole, ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("UPDATE").WithArgs(ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("INSERT INTO").WithArgs(ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+
+	manager := createManager(db)
+
+	// Act
+	execErr := manager.ChangeUserRole(callerID, ID, objects.ComendRole)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestUserManager) TestUserManager_ChangeUserRoleNegativeRevokeSessions(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	callerID := 1
+	ID := 2
+	user := objectMother.CreateUserWithRole(ID, objects.ComendRole)
+	firstRows := objectMother.CreateRows([]objects.User{user})
+	secondRows := objectMother.CreateRows([]objects.User{user})
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(firstRows)
+	mock.ExpectQuery("SELECT").WithArgs(ID).WillReturnError(nil).WillReturnRows(secondRows)
+	mock.ExpectExec("UPDATE").WithArgs(objects.SupplyRole, ID).
+		WillReturnError(nil).WillReturnResult(sqlmock.NewResult(InsertID, RowsAffected))
+	mock.ExpectExec("UPDATE").WithArgs(ID).WillReturnError(sql.ErrConnDone)
+
+	manager := createManager(db)
+
+	// Act
+	execErr := manager.ChangeUserRole(callerID, ID, objects.SupplyRole)
+
+	// Assert
+	tests.AssertErrors(t, execErr, sql.ErrConnDone)
+	tests.AssertMocks(t, mock)
+}
+
+func (*TestUserManager) TestUserManager_ChangeUserRoleNegativeStudentRole(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.UserRepoObjectMother{}
+	db, mock := objectMother.CreateRepo()
+	callerID := 1
+	ID := 2
+
+	manager := createManager(db)
+
+	// Act
+	execErr := manager.ChangeUserRole(callerID, ID, objects.StudentRole)
+
+	// Assert
+	tests.AssertErrors(t, execErr, appErrors.BadRoleErr)
+	tests.AssertMocks(t, mock)
+}
@@ -1,29 +1,91 @@
 package middleware
 
 import (
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
 	"net/http"
 	"src/objects"
+	"src/utils"
 	"src/utils/access"
+	appErrors "src/utils/error"
 	"src/utils/jwtUtils"
+	"src/utils/logger"
+	"strings"
 )
 
-func CheckAccess(next http.Handler) http.Handler {
+type TokenVerifier interface {
+	VerifyAccessToken(accessToken string) (jwtUtils.Claims, error)
+}
+
+type APIKeyVerifier interface {
+	VerifyAPIKey(key string) (objects.APIKey, error)
+}
+
+// CheckAccess authenticates the request by "Authorization: ApiKey <key>" header or by access-token header
+// and checks the role in the permission table. Read-only keys may only call GET routes.
+func CheckAccess(router *mux.Router, tokenVerifier TokenVerifier, keyVerifier APIKeyVerifier,
+	log *logrus.Entry) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.RequestURI == "/test" {
-			next.ServeHTTP(w, r)
+			router.ServeHTTP(w, r)
 		} else if r.RequestURI == "/status" {
-			next.ServeHTTP(w, r)
+			router.ServeHTTP(w, r)
 		} else if r.RequestURI == "/next/index1.html/" || r.RequestURI == "/next/style1.css/" ||
 			r.RequestURI == "/next/image1.jpg/" || r.RequestURI == "/index1.html/" {
-			next.ServeHTTP(w, r)
+			router.ServeHTTP(w, r)
 		} else {
-			accessToken := r.Header.Get("access-token")
-			role := jwtUtils.GetRoleFromJWT(accessToken)
-			if access.CheckRoleAccess(r.RequestURI, r.Method, role) {
-				next.ServeHTTP(w, r)
+			var match mux.RouteMatch
+			if !router.Match(r, &match) {
+				// Unknown route or method: the router answers 404/405 without calling any handler.
+				router.ServeHTTP(w, r)
+				return
+			}
+			template, _ := match.Route.GetPathTemplate()
+
+			identity, hasCredentials, authErr := authenticate(r, tokenVerifier, keyVerifier)
+			role := identity.GetRole()
+			if authErr == appErrors.ReadOnlyAPIKeyErr {
+				role = objects.NonAuth
+			}
+
+			if access.CheckRoleAccess(template, r.Method, role) {
+				if authErr == nil {
+					r = r.WithContext(objects.ContextWithIdentity(r.Context(), identity))
+				}
+				router.ServeHTTP(w, r)
+			} else if hasCredentials && authErr != nil && authErr != appErrors.ReadOnlyAPIKeyErr {
+				utils.SendShortResponse(w, http.StatusUnauthorized, objects.UnauthorizedErrorString)
 			} else {
+				logger.WriteAccessDeniedInLog(log, r, template, identity.GetLogin(), identity.GetRole())
 				http.Error(w, objects.ForbiddenErrorString, http.StatusForbidden)
 			}
 		}
 	})
 }
+
+// authenticate returns an empty identity with an error if the credentials are missing or invalid.
+// A read-only key used for other methods gives the identity of the key with ReadOnlyAPIKeyErr.
+func authenticate(r *http.Request, tokenVerifier TokenVerifier,
+	keyVerifier APIKeyVerifier) (objects.Identity, bool, error) {
+	authorization := r.Header.Get("Authorization")
+	if strings.HasPrefix(authorization, objects.APIKeyAuthScheme) {
+		apiKey, err := keyVerifier.VerifyAPIKey(strings.TrimPrefix(authorization, objects.APIKeyAuthScheme))
+		if err != nil {
+			return objects.NewEmptyIdentity(), true, err
+		}
+		if apiKey.IsReadOnly() && r.Method != http.MethodGet {
+			err = appErrors.ReadOnlyAPIKeyErr
+		}
+		return apiKey.GetIdentity(), true, err
+	}
+
+	accessToken := r.Header.Get("access-token")
+	if accessToken == objects.EmptyString {
+		return objects.NewEmptyIdentity(), false, appErrors.BadAccessTokenErr
+	}
+	claims, err := tokenVerifier.VerifyAccessToken(accessToken)
+	if err != nil {
+		return objects.NewEmptyIdentity(), true, err
+	}
+	return claims.GetIdentity(), true, nil
+}
@@ -0,0 +1,116 @@
+package objects
+
+import "time"
+
+// APIKey lets scripts call the API without a user password. The key itself is shown only once
+// on creation, the table keeps its hash.
+type APIKey struct {
+	id         int
+	name       string
+	keyHash    string
+	role       Levels
+	readOnly   bool
+	createdBy  int
+	createdAt  time.Time
+	lastUsedAt time.Time
+	revoked    bool
+}
+
+type APIKeyResponseDTO struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Role       Levels     `json:"role"`
+	ReadOnly   bool       `json:"read-only"`
+	CreatedBy  int        `json:"created-by"`
+	CreatedAt  time.Time  `json:"created-at"`
+	LastUsedAt *time.Time `json:"last-used-at"`
+	Revoked    bool       `json:"revoked"`
+}
+
+func NewAPIKeyWithParams(id int, name, keyHash string, role Levels, readOnly bool, createdBy int,
+	createdAt time.Time) APIKey {
+	return APIKey{
+		id:        id,
+		name:      name,
+		keyHash:   keyHash,
+		role:      role,
+		readOnly:  readOnly,
+		createdBy: createdBy,
+		createdAt: createdAt,
+	}
+}
+
+func NewEmptyAPIKey() APIKey {
+	return APIKey{id: None, role: NonAuth}
+}
+
+func (k *APIKey) GetID() int {
+	return k.id
+}
+
+func (k *APIKey) GetName() string {
+	return k.name
+}
+
+func (k *APIKey) GetKeyHash() string {
+	return k.keyHash
+}
+
+func (k *APIKey) GetRole() Levels {
+	return k.role
+}
+
+func (k *APIKey) IsReadOnly() bool {
+	return k.readOnly
+}
+
+func (k *APIKey) GetCreatedBy() int {
+	return k.createdBy
+}
+
+func (k *APIKey) GetCreatedAt() time.Time {
+	return k.createdAt
+}
+
+// GetLastUsedAt returns zero time for a key which was never used.
+func (k *APIKey) GetLastUsedAt() time.Time {
+	return k.lastUsedAt
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.revoked
+}
+
+func (k *APIKey) SetLastUsedAt(lastUsedAt time.Time) {
+	k.lastUsedAt = lastUsedAt
+}
+
+func (k *APIKey) SetRevoked(revoked bool) {
+	k.revoked = revoked
+}
+
+// GetIdentity describes the caller of a request made with the key. The key is not a user,
+// so the identity has no user id and the login is the name of the key.
+func (k *APIKey) GetIdentity() Identity {
+	return NewIdentityWithParams(None, APIKeyLoginPrefix+k.name, k.role, EmptyString)
+}
+
+func CreateAPIKeyResponse(keys []APIKey) []APIKeyResponseDTO {
+	result := make([]APIKeyResponseDTO, Empty)
+	for _, key := range keys {
+		dto := APIKeyResponseDTO{
+			ID:        key.GetID(),
+			Name:      key.GetName(),
+			Role:      key.GetRole(),
+			ReadOnly:  key.IsReadOnly(),
+			CreatedBy: key.GetCreatedBy(),
+			CreatedAt: key.GetCreatedAt(),
+			Revoked:   key.IsRevoked(),
+		}
+		if lastUsedAt := key.GetLastUsedAt(); !lastUsedAt.IsZero() {
+			dto.LastUsedAt = &lastUsedAt
+		}
+		result = append(result, dto)
+	}
+	return result
+}
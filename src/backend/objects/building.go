@@ -0,0 +1,93 @@
+package objects
+
+// Building is a dormitory building. Its rooms are on floors from 1 to floors.
+type Building struct {
+	id      int
+	name    string
+	address string
+	floors  int
+}
+
+type BuildingDTO struct {
+	name    string
+	address string
+	floors  int
+}
+
+type BuildingResponseDTO struct {
+	BuildingID int    `json:"building-id"`
+	Name       string `json:"name"`
+	Address    string `json:"address"`
+	Floors     int    `json:"floors"`
+}
+
+func NewBuildingWithParams(id int, name, address string, floors int) Building {
+	return Building{
+		id:      id,
+		name:    name,
+		address: address,
+		floors:  floors,
+	}
+}
+
+func NewEmptyBuilding() Building {
+	return Building{id: None}
+}
+
+func (b *Building) GetID() int {
+	return b.id
+}
+
+func (b *Building) GetName() string {
+	return b.name
+}
+
+func (b *Building) GetAddress() string {
+	return b.address
+}
+
+func (b *Building) GetFloors() int {
+	return b.floors
+}
+
+// HasFloor reports whether the floor exists in the building.
+func (b *Building) HasFloor(floor int) bool {
+	return floor >= FirstFloor && floor <= b.floors
+}
+
+func NewBuildingDTO(name, address string, floors int) BuildingDTO {
+	return BuildingDTO{
+		name:    name,
+		address: address,
+		floors:  floors,
+	}
+}
+
+func (bd *BuildingDTO) GetName() string {
+	return bd.name
+}
+
+func (bd *BuildingDTO) GetAddress() string {
+	return bd.address
+}
+
+func (bd *BuildingDTO) GetFloors() int {
+	return bd.floors
+}
+
+func CreateBuildingResponse(building Building) BuildingResponseDTO {
+	return BuildingResponseDTO{
+		BuildingID: building.GetID(),
+		Name:       building.GetName(),
+		Address:    building.GetAddress(),
+		Floors:     building.GetFloors(),
+	}
+}
+
+func CreateBuildingResponseArr(arr []Building) []BuildingResponseDTO {
+	result := make([]BuildingResponseDTO, Empty)
+	for _, building := range arr {
+		result = append(result, CreateBuildingResponse(building))
+	}
+	return result
+}
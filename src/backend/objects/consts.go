@@ -11,6 +11,9 @@ const (
 	DefaultPageSize                = 25
 	DefaultPage                    = 0
 	Max                            = 10000
+	FirstFloor                     = 1
+	FirstCourse                    = 1
+	LastCourse                     = 6
 	LoginInputMessage              = "Введите логин: "
 	PasswordInputMessage           = "Введите пароль: "
 	MarkInputMessage               = "Введите маркировочный номер: "
@@ -63,7 +66,100 @@ const (
 	MustBeIntErrorString           = "Параметр обязательно должен быть числом!"
 	EmptyParamsErrorString         = "Параметр не должен быть пустой"
 	WrongParamsErrorString         = "Параметры указаны неверно!"
+	UnauthorizedErrorString        = "Токен недействителен, требуется повторная авторизация!"
+	LogoutOK                       = "Выход из системы выполнен!"
+	UserDisabledErrorString        = "Учётная запись заблокирована!"
+	BadRoleErrorString             = "Указана неверная роль пользователя!"
+	NotStaffUserErrorString        = "Пользователь не является сотрудником!"
+	SelfActionErrorString          = "Нельзя изменить собственную учётную запись!"
+	UserChangeOKString             = "Данные пользователя успешно обновлены!"
+	PasswordChangeOKString         = "Пароль успешно изменён!"
+	BadResetTokenErrorString       = "Ссылка для сброса пароля недействительна или устарела!"
+	InvalidCredentialsErrorString  = "Неверный логин или пароль!"
+	TooManyAttemptsErrorString     = "Слишком много неудачных попыток входа, попробуйте позже!"
+	UnknownProviderErrorString     = "Способ входа не поддерживается!"
+	ExternalAuthErrorString        = "Не удалось войти через внешний сервис!"
+	ExternalNotLinkedErrorString   = "Пользователь с таким логином уже есть, привяжите внешний вход в своей учётной записи!"
+	ExternalLinkedErrorString      = "Внешняя учётная запись уже привязана!"
+	ExternalLinkOKString           = "Внешняя учётная запись привязана!"
+	APIKeyNotFoundErrorString      = "API-ключ не найден!"
+	APIKeyRevokeOKString           = "API-ключ отозван!"
+	APIKeyLoginPrefix              = "api-key:"
+	APIKeyPrefix                   = "hk_"
+	APIKeyAuthScheme               = "ApiKey "
+	SortBySurname                  = "surname"
+	SortByGroup                    = "group"
+	SortByStudentNumber            = "number"
+	SortByRoom                     = "room"
+	SortByCourse                   = "course"
+	SortAsc                        = "asc"
+	SortDesc                       = "desc"
+	SettleDirection                = "settle"
+	EvictDirection                 = "evict"
+	DateFormat                     = "2006-01-02"
+	RoomIsFullErrorString          = "В комнате нет свободных мест!"
+	RoomNotForLivingErrorString    = "В этой комнате нельзя проживать!"
+	RoomGenderErrorString          = "Комната предназначена для проживания студентов другого пола!"
+	SameRoomErrorString            = "Студент уже живёт в этой комнате!"
+	RoomAlreadyExistErrorString    = "Комната с таким номером уже есть в этом корпусе!"
+	BuildingNotFoundErrorString    = "Корпус не найден!"
+	BuildingExistsErrorString      = "Корпус с таким названием уже существует!"
+	FloorNotFoundErrorString       = "В корпусе нет такого этажа!"
+	RoomHasStudentsErrorString     = "В комнате проживают студенты!"
+	RoomHasThingsErrorString       = "В комнате находятся вещи!"
+	RoomHasHistoryErrorString      = "Комната упоминается в истории перемещений!"
+	RoomCapacityErrorString        = "Вместимость меньше числа проживающих!"
+	RoomChangeOKString             = "Данные комнаты успешно обновлены!"
+	RoomDeleteOKString             = "Комната удалена!"
+	SettlementNotFoundErrorString  = "Заявка на заселение не найдена!"
+	SettlementExistsErrorString    = "У студента уже есть заявка на заселение!"
+	SettlementClosedErrorString    = "Заявка на заселение уже рассмотрена!"
+	SettlementChangeOKString       = "Заявка на заселение обновлена!"
+	SettlementCancelOKString       = "Заявка на заселение отменена!"
+	SwapNotFoundErrorString        = "Обмен комнатами не найден!"
+	SwapExistsErrorString          = "Студент уже участвует в незавершённом обмене комнатами!"
+	SwapStatusErrorString          = "Обмен комнатами нельзя перевести в этот статус!"
+	SwapOutdatedErrorString        = "Студенты переселились после предложения обмена!"
+	SwapChangeOKString             = "Обмен комнатами обновлён!"
+	StudentFieldsErrorString       = "Некоторые поля указаны неверно!"
+	EmptyFieldErrorString          = "Поле не должно быть пустым!"
+	StudentNumberFormatErrorString = "Номер студенческого билета должен быть в формате 19У609!"
+	StudentGroupFormatErrorString  = "Группа должна быть в формате ИУ7-75Б!"
+	GenderErrorString              = "Пол указан неверно!"
+	CourseErrorString              = "Курс должен быть от 1 до 6!"
+	PhoneErrorString               = "Телефон указан неверно!"
+	EmailErrorString               = "Электронная почта указана неверно!"
+	DateOfBirthErrorString         = "Дата рождения должна быть прошедшей датой в формате ГГГГ-ММ-ДД!"
+	StudentNotActiveErrorString    = "Студент не обучается в данный момент!"
+	StudentStatusErrorString       = "Студента нельзя перевести в этот статус!"
+	StudentIsLivingErrorString     = "Студент ещё живёт в общежитии!"
+	StudentHasThingsErrorString    = "У студента есть невозвращённые вещи!"
+	StudentStatusChangeOKString    = "Статус студента изменён!"
+	StudentClearanceErrorString    = "Обходной лист не подписан: у студента есть задолженности!"
+	StudentClearanceOKString       = "Обходной лист подписан!"
+	ImportFileErrorString          = "Файл должен быть в формате CSV или XLSX и содержать заголовок!"
+	ImportDuplicateErrorString     = "Значение повторяется в файле!"
+	ImportDryRunOKString           = "Файл проверен, студенты не добавлены."
+	ImportOKString                 = "Студенты добавлены!"
 	AuthURI                        = "/api/v1/login"
+	RefreshURI                     = "/api/v1/refresh"
+	LogoutURI                      = "/api/v1/logout"
+	JWKSURI                        = "/api/v1/.well-known/jwks.json"
+	PasswordResetURI               = "/api/v1/password-reset"
 )
 
 type TransferDirection int
+
+// Gender of a student or the gender a room is reserved for. GenderAny means unknown for a student
+// and a mixed room for a room.
+type Gender string
+
+const (
+	GenderAny    Gender = ""
+	GenderMale   Gender = "male"
+	GenderFemale Gender = "female"
+)
+
+func (g Gender) IsValid() bool {
+	return g == GenderAny || g == GenderMale || g == GenderFemale
+}
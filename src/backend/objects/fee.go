@@ -0,0 +1,60 @@
+package objects
+
+import "time"
+
+// Fee is an unpaid charge of a student, amount is in kopecks.
+type Fee struct {
+	id          int
+	studentID   int
+	amount      int
+	description string
+	dueDate     time.Time
+}
+
+type FeeResponseDTO struct {
+	Amount      int    `json:"amount"`
+	Description string `json:"description"`
+	DueDate     string `json:"due-date"`
+}
+
+func NewFeeWithParams(id, studentID, amount int, description string, dueDate time.Time) Fee {
+	return Fee{
+		id:          id,
+		studentID:   studentID,
+		amount:      amount,
+		description: description,
+		dueDate:     dueDate,
+	}
+}
+
+func (f *Fee) GetID() int {
+	return f.id
+}
+
+func (f *Fee) GetStudentID() int {
+	return f.studentID
+}
+
+func (f *Fee) GetAmount() int {
+	return f.amount
+}
+
+func (f *Fee) GetDescription() string {
+	return f.description
+}
+
+func (f *Fee) GetDueDate() time.Time {
+	return f.dueDate
+}
+
+func CreateFeesResponse(fees []Fee) []FeeResponseDTO {
+	result := make([]FeeResponseDTO, Empty)
+	for _, fee := range fees {
+		result = append(result, FeeResponseDTO{
+			Amount:      fee.GetAmount(),
+			Description: fee.GetDescription(),
+			DueDate:     fee.GetDueDate().Format(DateFormat),
+		})
+	}
+	return result
+}
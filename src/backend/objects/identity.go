@@ -0,0 +1,60 @@
+package objects
+
+import "context"
+
+// Identity describes the caller of the current request. It is created by the auth middleware
+// from the access token and lives in the request context.
+type Identity struct {
+	userID  int
+	login   string
+	role    Levels
+	tokenID string
+}
+
+type identityKey struct{}
+
+func NewIdentityWithParams(userID int, login string, role Levels, tokenID string) Identity {
+	return Identity{
+		userID:  userID,
+		login:   login,
+		role:    role,
+		tokenID: tokenID,
+	}
+}
+
+func NewEmptyIdentity() Identity {
+	return Identity{userID: None, role: NonAuth}
+}
+
+func (i *Identity) GetUserID() int {
+	return i.userID
+}
+
+func (i *Identity) GetLogin() string {
+	return i.login
+}
+
+func (i *Identity) GetRole() Levels {
+	return i.role
+}
+
+func (i *Identity) GetTokenID() string {
+	return i.tokenID
+}
+
+func (i *Identity) IsAuthorized() bool {
+	return i.role > NonAuth
+}
+
+func ContextWithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns an empty NonAuth identity for requests without a valid token.
+func IdentityFromContext(ctx context.Context) Identity {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	if !ok {
+		identity = NewEmptyIdentity()
+	}
+	return identity
+}
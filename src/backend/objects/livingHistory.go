@@ -0,0 +1,74 @@
+package objects
+
+import "time"
+
+// LivingRecord is one settle or evict act from StudentRoomHistory.
+type LivingRecord struct {
+	id           int
+	studentID    int
+	roomID       int
+	direction    TransferDirection
+	transferDate time.Time
+	performedBy  string
+}
+
+type LivingRecordResponseDTO struct {
+	RoomID       int    `json:"room-id"`
+	Direction    string `json:"direction"`
+	TransferDate string `json:"date"`
+	PerformedBy  string `json:"performed-by"`
+}
+
+func NewLivingRecordWithParams(id, studentID, roomID int, direction TransferDirection, transferDate time.Time,
+	performedBy string) LivingRecord {
+	return LivingRecord{
+		id:           id,
+		studentID:    studentID,
+		roomID:       roomID,
+		direction:    direction,
+		transferDate: transferDate,
+		performedBy:  performedBy,
+	}
+}
+
+func (lr *LivingRecord) GetID() int {
+	return lr.id
+}
+
+func (lr *LivingRecord) GetStudentID() int {
+	return lr.studentID
+}
+
+func (lr *LivingRecord) GetRoomID() int {
+	return lr.roomID
+}
+
+func (lr *LivingRecord) GetDirection() TransferDirection {
+	return lr.direction
+}
+
+func (lr *LivingRecord) GetTransferDate() time.Time {
+	return lr.transferDate
+}
+
+// GetPerformedBy returns the login of the staff member, empty for acts recorded before it was tracked.
+func (lr *LivingRecord) GetPerformedBy() string {
+	return lr.performedBy
+}
+
+func CreateLivingHistoryResponse(records []LivingRecord) []LivingRecordResponseDTO {
+	result := make([]LivingRecordResponseDTO, Empty)
+	for _, record := range records {
+		direction := EvictDirection
+		if record.GetDirection() == Get {
+			direction = SettleDirection
+		}
+		result = append(result, LivingRecordResponseDTO{
+			RoomID:       record.GetRoomID(),
+			Direction:    direction,
+			TransferDate: record.GetTransferDate().Format(DateFormat),
+			PerformedBy:  record.GetPerformedBy(),
+		})
+	}
+	return result
+}
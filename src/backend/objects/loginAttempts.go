@@ -0,0 +1,68 @@
+package objects
+
+import "time"
+
+// LoginAttempts counts failed logins for one key: a login or a client address.
+type LoginAttempts struct {
+	key         string
+	failures    int
+	lastFailure time.Time
+}
+
+func NewLoginAttemptsWithParams(key string, failures int, lastFailure time.Time) LoginAttempts {
+	return LoginAttempts{
+		key:         key,
+		failures:    failures,
+		lastFailure: lastFailure,
+	}
+}
+
+func NewEmptyLoginAttempts(key string) LoginAttempts {
+	return LoginAttempts{key: key, failures: Null}
+}
+
+func (la *LoginAttempts) GetKey() string {
+	return la.key
+}
+
+func (la *LoginAttempts) GetFailures() int {
+	return la.failures
+}
+
+func (la *LoginAttempts) GetLastFailure() time.Time {
+	return la.lastFailure
+}
+
+// AttemptLimits sets when a key is locked: each failure over FreeAttempts doubles the delay from BaseDelay
+// up to MaxDelay, failures older than ResetAfter are forgotten.
+type AttemptLimits struct {
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	ResetAfter   time.Duration
+}
+
+// Delay returns how long to wait after the last of failures.
+func (al AttemptLimits) Delay(failures int) time.Duration {
+	if failures <= al.FreeAttempts {
+		return 0
+	}
+	delay := al.BaseDelay
+	for i := al.FreeAttempts + 1; i < failures && delay < al.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > al.MaxDelay {
+		delay = al.MaxDelay
+	}
+	return delay
+}
+
+// IsExpired reports that the failures are older than ResetAfter and the counter starts from one again.
+func (la *LoginAttempts) IsExpired(limits AttemptLimits, now time.Time) bool {
+	return now.Sub(la.lastFailure) > limits.ResetAfter
+}
+
+// LockedUntil returns the time before which the next attempt is rejected, it is in the past if the key is free.
+func (la *LoginAttempts) LockedUntil(limits AttemptLimits) time.Time {
+	return la.lastFailure.Add(limits.Delay(la.failures))
+}
@@ -1,19 +1,45 @@
 package objects
 
+// Room has capacity beds. Rooms with no beds, like storerooms, are not for living.
+// Room number is unique within its building, block is an optional label of rooms sharing a hall.
 type Room struct {
 	id         int
 	roomType   string
 	roomNumber int
+	capacity   int
+	gender     Gender
+	occupied   int
+	buildingID int
+	floor      int
+	block      string
+}
+
+// RoomFilter selects rooms of one building and floor, None means any.
+type RoomFilter struct {
+	buildingID int
+	floor      int
 }
 
 type RoomResponseDTO struct {
 	RoomID     int    `json:"room-id"`
 	RoomType   string `json:"room-type"`
 	RoomNumber int    `json:"room-number"`
+	BuildingID int    `json:"building-id"`
+	Floor      int    `json:"floor"`
+	Block      string `json:"block,omitempty"`
+	Capacity   int    `json:"capacity"`
+	Gender     Gender `json:"gender"`
+	FreeBeds   int    `json:"free-beds"`
 }
 
-func NewRoomWithParams(id int, roomType string, roomNumber int) Room {
-	return Room{id, roomType, roomNumber}
+func NewRoomWithParams(id int, roomType string, roomNumber, capacity int, gender Gender) Room {
+	return Room{
+		id:         id,
+		roomType:   roomType,
+		roomNumber: roomNumber,
+		capacity:   capacity,
+		gender:     gender,
+	}
 }
 
 func NewEmptyRoom() Room {
@@ -32,15 +58,70 @@ func (r *Room) GetRoomNumber() int {
 	return r.roomNumber
 }
 
+func (r *Room) GetCapacity() int {
+	return r.capacity
+}
+
+func (r *Room) GetGender() Gender {
+	return r.gender
+}
+
+// GetOccupied returns the number of students living in the room now.
+func (r *Room) GetOccupied() int {
+	return r.occupied
+}
+
+func (r *Room) SetOccupied(occupied int) {
+	r.occupied = occupied
+}
+
+func (r *Room) GetBuildingID() int {
+	return r.buildingID
+}
+
+func (r *Room) GetFloor() int {
+	return r.floor
+}
+
+func (r *Room) GetBlock() string {
+	return r.block
+}
+
+func (r *Room) SetLocation(buildingID, floor int, block string) {
+	r.buildingID = buildingID
+	r.floor = floor
+	r.block = block
+}
+
+func (r *Room) GetFreeBeds() int {
+	if r.occupied >= r.capacity {
+		return Null
+	}
+	return r.capacity - r.occupied
+}
+
+// AllowsGender reports whether a student of the gender may live in the room.
+// Students with unknown gender may live in any room.
+func (r *Room) AllowsGender(gender Gender) bool {
+	return r.gender == GenderAny || gender == GenderAny || r.gender == gender
+}
+
 type RoomDTO struct {
 	roomType   string
 	roomNumber int
+	capacity   int
+	gender     Gender
+	buildingID int
+	floor      int
+	block      string
 }
 
-func NewRoomDTO(roomType string, roomNumber int) RoomDTO {
+func NewRoomDTO(roomType string, roomNumber, capacity int, gender Gender) RoomDTO {
 	return RoomDTO{
 		roomType:   roomType,
 		roomNumber: roomNumber,
+		capacity:   capacity,
+		gender:     gender,
 	}
 }
 
@@ -52,30 +133,73 @@ func (rd *RoomDTO) GetRoomNumber() int {
 	return rd.roomNumber
 }
 
-func CreateRoomResponseDTO(arr Room) RoomResponseDTO {
-	return RoomResponseDTO{
-		RoomID:     arr.GetID(),
-		RoomType:   arr.GetRoomType(),
-		RoomNumber: arr.GetRoomNumber(),
+func (rd *RoomDTO) GetCapacity() int {
+	return rd.capacity
+}
+
+func (rd *RoomDTO) GetGender() Gender {
+	return rd.gender
+}
+
+func (rd *RoomDTO) GetBuildingID() int {
+	return rd.buildingID
+}
+
+func (rd *RoomDTO) GetFloor() int {
+	return rd.floor
+}
+
+func (rd *RoomDTO) GetBlock() string {
+	return rd.block
+}
+
+func (rd *RoomDTO) SetLocation(buildingID, floor int, block string) {
+	rd.buildingID = buildingID
+	rd.floor = floor
+	rd.block = block
+}
+
+func NewRoomFilter(buildingID, floor int) RoomFilter {
+	return RoomFilter{
+		buildingID: buildingID,
+		floor:      floor,
 	}
 }
 
+func NewEmptyRoomFilter() RoomFilter {
+	return RoomFilter{buildingID: None, floor: None}
+}
+
+func (rf *RoomFilter) GetBuildingID() int {
+	return rf.buildingID
+}
+
+func (rf *RoomFilter) GetFloor() int {
+	return rf.floor
+}
+
+func CreateRoomResponseDTO(arr Room) RoomResponseDTO {
+	return CreateRoomResponse(arr)
+}
+
 func CreateRoomResponse(room Room) RoomResponseDTO {
 	return RoomResponseDTO{
 		RoomID:     room.GetID(),
 		RoomType:   room.GetRoomType(),
 		RoomNumber: room.GetRoomNumber(),
+		BuildingID: room.GetBuildingID(),
+		Floor:      room.GetFloor(),
+		Block:      room.GetBlock(),
+		Capacity:   room.GetCapacity(),
+		Gender:     room.GetGender(),
+		FreeBeds:   room.GetFreeBeds(),
 	}
 }
 
 func CreateRoomResponseArr(arr []Room) []RoomResponseDTO {
 	result := make([]RoomResponseDTO, Empty)
 	for _, room := range arr {
-		result = append(result, RoomResponseDTO{
-			RoomID:     room.GetID(),
-			RoomType:   room.GetRoomType(),
-			RoomNumber: room.GetRoomNumber(),
-		})
+		result = append(result, CreateRoomResponse(room))
 	}
 	return result
 }
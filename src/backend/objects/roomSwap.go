@@ -0,0 +1,145 @@
+package objects
+
+import "time"
+
+// SwapStatus of a room swap. A swap proposed by one student is pending until the partner accepts it,
+// an accepted swap is carried out when the commandant confirms it. Rejected and confirmed swaps are closed.
+type SwapStatus string
+
+const (
+	SwapPending   SwapStatus = "pending"
+	SwapAccepted  SwapStatus = "accepted"
+	SwapRejected  SwapStatus = "rejected"
+	SwapConfirmed SwapStatus = "confirmed"
+)
+
+func (s SwapStatus) IsValid() bool {
+	return s == SwapPending || s == SwapAccepted || s == SwapRejected || s == SwapConfirmed
+}
+
+// SwapParty is a student taking part in a swap and the room of the student when the swap was proposed.
+type SwapParty struct {
+	studentID     int
+	studentNumber string
+	roomID        int
+}
+
+// RoomSwap of two students: the initiator moves to the partner's room and the partner to the initiator's one.
+type RoomSwap struct {
+	id        int
+	initiator SwapParty
+	partner   SwapParty
+	status    SwapStatus
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+type SwapPartyResponseDTO struct {
+	StudentNumber string `json:"stud-number"`
+	RoomID        int    `json:"room-id"`
+}
+
+type RoomSwapResponseDTO struct {
+	SwapID    int                  `json:"swap-id"`
+	Initiator SwapPartyResponseDTO `json:"initiator"`
+	Partner   SwapPartyResponseDTO `json:"partner"`
+	Status    SwapStatus           `json:"status"`
+	CreatedAt time.Time            `json:"created-at"`
+	UpdatedAt time.Time            `json:"updated-at"`
+}
+
+func NewSwapParty(studentID int, studentNumber string, roomID int) SwapParty {
+	return SwapParty{
+		studentID:     studentID,
+		studentNumber: studentNumber,
+		roomID:        roomID,
+	}
+}
+
+func (sp *SwapParty) GetStudentID() int {
+	return sp.studentID
+}
+
+func (sp *SwapParty) GetStudentNumber() string {
+	return sp.studentNumber
+}
+
+func (sp *SwapParty) GetRoomID() int {
+	return sp.roomID
+}
+
+func NewRoomSwapWithParams(id int, initiator, partner SwapParty, status SwapStatus,
+	createdAt, updatedAt time.Time) RoomSwap {
+	return RoomSwap{
+		id:        id,
+		initiator: initiator,
+		partner:   partner,
+		status:    status,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+func NewEmptyRoomSwap() RoomSwap {
+	return RoomSwap{id: None}
+}
+
+func (rs *RoomSwap) GetID() int {
+	return rs.id
+}
+
+func (rs *RoomSwap) GetInitiator() SwapParty {
+	return rs.initiator
+}
+
+func (rs *RoomSwap) GetPartner() SwapParty {
+	return rs.partner
+}
+
+func (rs *RoomSwap) GetStatus() SwapStatus {
+	return rs.status
+}
+
+func (rs *RoomSwap) GetCreatedAt() time.Time {
+	return rs.createdAt
+}
+
+func (rs *RoomSwap) GetUpdatedAt() time.Time {
+	return rs.updatedAt
+}
+
+// IsOpen reports whether the swap is still waiting for the partner or the commandant.
+func (rs *RoomSwap) IsOpen() bool {
+	return rs.status == SwapPending || rs.status == SwapAccepted
+}
+
+// HasStudent reports whether the student takes part in the swap.
+func (rs *RoomSwap) HasStudent(studentID int) bool {
+	return rs.initiator.studentID == studentID || rs.partner.studentID == studentID
+}
+
+func createSwapPartyResponse(party SwapParty) SwapPartyResponseDTO {
+	return SwapPartyResponseDTO{
+		StudentNumber: party.GetStudentNumber(),
+		RoomID:        party.GetRoomID(),
+	}
+}
+
+func CreateRoomSwapResponse(swap RoomSwap) RoomSwapResponseDTO {
+	return RoomSwapResponseDTO{
+		SwapID:    swap.GetID(),
+		Initiator: createSwapPartyResponse(swap.GetInitiator()),
+		Partner:   createSwapPartyResponse(swap.GetPartner()),
+		Status:    swap.GetStatus(),
+		CreatedAt: swap.GetCreatedAt(),
+		UpdatedAt: swap.GetUpdatedAt(),
+	}
+}
+
+func CreateRoomSwapResponseArr(swaps []RoomSwap) []RoomSwapResponseDTO {
+	result := make([]RoomSwapResponseDTO, Empty)
+	for _, swap := range swaps {
+		result = append(result, CreateRoomSwapResponse(swap))
+	}
+	return result
+}
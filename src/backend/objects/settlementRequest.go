@@ -0,0 +1,209 @@
+package objects
+
+import "time"
+
+// SettlementStatus of a settlement request. Pending requests form the waiting list,
+// the other statuses are final.
+type SettlementStatus string
+
+const (
+	SettlementPending   SettlementStatus = "pending"
+	SettlementApproved  SettlementStatus = "approved"
+	SettlementRejected  SettlementStatus = "rejected"
+	SettlementCancelled SettlementStatus = "cancelled"
+)
+
+func (s SettlementStatus) IsValid() bool {
+	return s == SettlementPending || s == SettlementApproved || s == SettlementRejected || s == SettlementCancelled
+}
+
+// SettlementPreferences are the building, floor and room a student asks for, None means no preference.
+type SettlementPreferences struct {
+	buildingID int
+	floor      int
+	roomID     int
+	comment    string
+}
+
+// SettlementRequest is a student application for a place. Requests with higher priority are served first,
+// equal ones in order of submission.
+type SettlementRequest struct {
+	id            int
+	studentID     int
+	studentNumber string
+	preferences   SettlementPreferences
+	priority      int
+	status        SettlementStatus
+	createdAt     time.Time
+}
+
+// SettlementStatusChange is one status transition of a request, changedBy is the login of the user.
+type SettlementStatusChange struct {
+	status    SettlementStatus
+	changedAt time.Time
+	changedBy string
+	comment   string
+}
+
+type SettlementRequestResponseDTO struct {
+	RequestID     int              `json:"request-id"`
+	StudentNumber string           `json:"student-number"`
+	BuildingID    *int             `json:"building-id,omitempty"`
+	Floor         *int             `json:"floor,omitempty"`
+	RoomID        *int             `json:"room-id,omitempty"`
+	Comment       string           `json:"comment"`
+	Priority      int              `json:"priority"`
+	Status        SettlementStatus `json:"status"`
+	CreatedAt     time.Time        `json:"created-at"`
+}
+
+type SettlementStatusChangeResponseDTO struct {
+	Status    SettlementStatus `json:"status"`
+	ChangedAt time.Time        `json:"changed-at"`
+	ChangedBy string           `json:"changed-by"`
+	Comment   string           `json:"comment"`
+}
+
+func NewSettlementPreferences(buildingID, floor, roomID int, comment string) SettlementPreferences {
+	return SettlementPreferences{
+		buildingID: buildingID,
+		floor:      floor,
+		roomID:     roomID,
+		comment:    comment,
+	}
+}
+
+func (sp *SettlementPreferences) GetBuildingID() int {
+	return sp.buildingID
+}
+
+func (sp *SettlementPreferences) GetFloor() int {
+	return sp.floor
+}
+
+func (sp *SettlementPreferences) GetRoomID() int {
+	return sp.roomID
+}
+
+func (sp *SettlementPreferences) GetComment() string {
+	return sp.comment
+}
+
+func NewSettlementRequestWithParams(id, studentID int, studentNumber string, preferences SettlementPreferences,
+	priority int, status SettlementStatus, createdAt time.Time) SettlementRequest {
+	return SettlementRequest{
+		id:            id,
+		studentID:     studentID,
+		studentNumber: studentNumber,
+		preferences:   preferences,
+		priority:      priority,
+		status:        status,
+		createdAt:     createdAt,
+	}
+}
+
+func NewEmptySettlementRequest() SettlementRequest {
+	return SettlementRequest{id: None}
+}
+
+func (sr *SettlementRequest) GetID() int {
+	return sr.id
+}
+
+func (sr *SettlementRequest) GetStudentID() int {
+	return sr.studentID
+}
+
+func (sr *SettlementRequest) GetStudentNumber() string {
+	return sr.studentNumber
+}
+
+func (sr *SettlementRequest) GetPreferences() SettlementPreferences {
+	return sr.preferences
+}
+
+func (sr *SettlementRequest) GetPriority() int {
+	return sr.priority
+}
+
+func (sr *SettlementRequest) GetStatus() SettlementStatus {
+	return sr.status
+}
+
+func (sr *SettlementRequest) GetCreatedAt() time.Time {
+	return sr.createdAt
+}
+
+func (sr *SettlementRequest) IsPending() bool {
+	return sr.status == SettlementPending
+}
+
+func NewSettlementStatusChange(status SettlementStatus, changedAt time.Time, changedBy,
+	comment string) SettlementStatusChange {
+	return SettlementStatusChange{
+		status:    status,
+		changedAt: changedAt,
+		changedBy: changedBy,
+		comment:   comment,
+	}
+}
+
+func (sc *SettlementStatusChange) GetStatus() SettlementStatus {
+	return sc.status
+}
+
+func (sc *SettlementStatusChange) GetChangedAt() time.Time {
+	return sc.changedAt
+}
+
+func (sc *SettlementStatusChange) GetChangedBy() string {
+	return sc.changedBy
+}
+
+func (sc *SettlementStatusChange) GetComment() string {
+	return sc.comment
+}
+
+// optionalInt hides preferences which are not set.
+func optionalInt(value int) *int {
+	if value == None {
+		return nil
+	}
+	return &value
+}
+
+func CreateSettlementRequestResponse(request SettlementRequest) SettlementRequestResponseDTO {
+	preferences := request.GetPreferences()
+	return SettlementRequestResponseDTO{
+		RequestID:     request.GetID(),
+		StudentNumber: request.GetStudentNumber(),
+		BuildingID:    optionalInt(preferences.GetBuildingID()),
+		Floor:         optionalInt(preferences.GetFloor()),
+		RoomID:        optionalInt(preferences.GetRoomID()),
+		Comment:       preferences.GetComment(),
+		Priority:      request.GetPriority(),
+		Status:        request.GetStatus(),
+		CreatedAt:     request.GetCreatedAt(),
+	}
+}
+
+func CreateSettlementRequestResponseArr(requests []SettlementRequest) []SettlementRequestResponseDTO {
+	result := make([]SettlementRequestResponseDTO, Empty)
+	for _, request := range requests {
+		result = append(result, CreateSettlementRequestResponse(request))
+	}
+	return result
+}
+
+func CreateSettlementStatusChangeResponse(changes []SettlementStatusChange) []SettlementStatusChangeResponseDTO {
+	result := make([]SettlementStatusChangeResponseDTO, Empty)
+	for _, change := range changes {
+		result = append(result, SettlementStatusChangeResponseDTO{
+			Status:    change.GetStatus(),
+			ChangedAt: change.GetChangedAt(),
+			ChangedBy: change.GetChangedBy(),
+			Comment:   change.GetComment(),
+		})
+	}
+	return result
+}
@@ -1,5 +1,45 @@
 package objects
 
+import (
+	"net/mail"
+	"regexp"
+	"time"
+)
+
+// Student numbers look like "19У609" (year of admission, letter, number) and groups like "ИУ7-75Б".
+var (
+	studentNumberPattern = regexp.MustCompile(`^\d{2}[А-ЯЁA-Zа-яёa-z]\d{3,4}$`)
+	studentGroupPattern  = regexp.MustCompile(`^[А-ЯЁA-Z]{1,4}\d{1,2}[А-ЯЁA-Z]?-\d{2,3}[А-ЯЁA-Z]?$`)
+	phonePattern         = regexp.MustCompile(`^\+?\d{10,15}$`)
+)
+
+// StudentStatus of a student. Only active students may be settled and take things. Students on academic leave
+// may return to active status, graduated and expelled students are archived for good.
+type StudentStatus string
+
+const (
+	StudentActive        StudentStatus = "active"
+	StudentAcademicLeave StudentStatus = "academic-leave"
+	StudentGraduated     StudentStatus = "graduated"
+	StudentExpelled      StudentStatus = "expelled"
+)
+
+func (s StudentStatus) IsValid() bool {
+	return s == StudentActive || s == StudentAcademicLeave || s == StudentGraduated || s == StudentExpelled
+}
+
+// CanChangeTo reports whether the lifecycle allows to move a student from s to the status.
+func (s StudentStatus) CanChangeTo(status StudentStatus) bool {
+	switch s {
+	case StudentActive:
+		return status == StudentAcademicLeave || status == StudentGraduated || status == StudentExpelled
+	case StudentAcademicLeave:
+		return status == StudentActive || status == StudentGraduated || status == StudentExpelled
+	default:
+		return false
+	}
+}
+
 type Student struct {
 	id            int
 	accID         int
@@ -8,6 +48,33 @@ type Student struct {
 	studentGroup  string
 	studentNumber string
 	roomID        int
+	gender        Gender
+	profile       StudentProfile
+	status        StudentStatus
+}
+
+// StudentProfile is optional personal data of a student. Course is Null and date of birth is zero when unknown.
+type StudentProfile struct {
+	patronymic  string
+	faculty     string
+	course      int
+	phone       string
+	email       string
+	dateOfBirth time.Time
+}
+
+// StudentFilter selects students by surname prefix, group, faculty, room, living status, things they have and status.
+// Empty strings, None and nil mean any. Students are sorted by sortBy, by id if it is empty.
+type StudentFilter struct {
+	surnamePrefix string
+	group         string
+	faculty       string
+	roomID        int
+	living        *bool
+	hasThings     *bool
+	status        StudentStatus
+	sortBy        string
+	descending    bool
 }
 
 type StudentDTO struct {
@@ -15,17 +82,35 @@ type StudentDTO struct {
 	surname       string
 	studentGroup  string
 	studentNumber string
+	gender        Gender
+	profile       StudentProfile
+}
+
+// StudentAccount is a new student together with the user account created for the student on import.
+type StudentAccount struct {
+	student      StudentDTO
+	login        string
+	passwordHash string
 }
 
 type StudentResponseDTO struct {
-	Name          string `json:"name"`
-	Surname       string `json:"surname"`
-	StudentGroup  string `json:"studentGroup"`
-	StudentNumber string `json:"studentNumber"`
-	RoomID        int    `json:"roomID"`
+	Name          string        `json:"name"`
+	Surname       string        `json:"surname"`
+	StudentGroup  string        `json:"studentGroup"`
+	StudentNumber string        `json:"studentNumber"`
+	RoomID        int           `json:"roomID"`
+	Gender        Gender        `json:"gender"`
+	Patronymic    string        `json:"patronymic,omitempty"`
+	Faculty       string        `json:"faculty,omitempty"`
+	Course        int           `json:"course,omitempty"`
+	Phone         string        `json:"phone,omitempty"`
+	Email         string        `json:"email,omitempty"`
+	DateOfBirth   string        `json:"dateOfBirth,omitempty"`
+	Status        StudentStatus `json:"status"`
 }
 
-func NewStudentWithParams(id, accID int, name, surname, studentGroup, studentNumber string, roomID int) Student {
+func NewStudentWithParams(id, accID int, name, surname, studentGroup, studentNumber string, roomID int,
+	gender Gender) Student {
 	return Student{
 		id:            id,
 		accID:         accID,
@@ -34,6 +119,8 @@ func NewStudentWithParams(id, accID int, name, surname, studentGroup, studentNum
 		studentGroup:  studentGroup,
 		studentNumber: studentNumber,
 		roomID:        roomID,
+		gender:        gender,
+		status:        StudentActive,
 	}
 }
 
@@ -69,6 +156,30 @@ func (s *Student) GetRoomID() int {
 	return s.roomID
 }
 
+func (s *Student) GetGender() Gender {
+	return s.gender
+}
+
+func (s *Student) GetProfile() StudentProfile {
+	return s.profile
+}
+
+func (s *Student) SetProfile(profile StudentProfile) {
+	s.profile = profile
+}
+
+func (s *Student) GetStatus() StudentStatus {
+	return s.status
+}
+
+func (s *Student) IsActive() bool {
+	return s.status == StudentActive
+}
+
+func (s *Student) SetStatus(status StudentStatus) {
+	s.status = status
+}
+
 func (s *Student) SetRoomID(id int) {
 	s.roomID = id
 }
@@ -85,15 +196,36 @@ func (s *Student) SetSurname(surname string) {
 	s.surname = surname
 }
 
-func NewStudentDTO(name, surname, group, studNumber string) StudentDTO {
+func NewStudentDTO(name, surname, group, studNumber string, gender Gender) StudentDTO {
 	return StudentDTO{
 		name:          name,
 		surname:       surname,
 		studentGroup:  group,
 		studentNumber: studNumber,
+		gender:        gender,
+	}
+}
+
+func NewStudentAccount(student StudentDTO, login, passwordHash string) StudentAccount {
+	return StudentAccount{
+		student:      student,
+		login:        login,
+		passwordHash: passwordHash,
 	}
 }
 
+func (sa *StudentAccount) GetStudent() StudentDTO {
+	return sa.student
+}
+
+func (sa *StudentAccount) GetLogin() string {
+	return sa.login
+}
+
+func (sa *StudentAccount) GetPasswordHash() string {
+	return sa.passwordHash
+}
+
 func (s *StudentDTO) GetName() string {
 	return s.name
 }
@@ -110,26 +242,165 @@ func (s *StudentDTO) GetStudentNumber() string {
 	return s.studentNumber
 }
 
+func (s *StudentDTO) GetGender() Gender {
+	return s.gender
+}
+
+func (s *StudentDTO) GetProfile() StudentProfile {
+	return s.profile
+}
+
+func (s *StudentDTO) SetProfile(profile StudentProfile) {
+	s.profile = profile
+}
+
+func NewStudentProfile(patronymic, faculty string, course int, phone, email string,
+	dateOfBirth time.Time) StudentProfile {
+	return StudentProfile{
+		patronymic:  patronymic,
+		faculty:     faculty,
+		course:      course,
+		phone:       phone,
+		email:       email,
+		dateOfBirth: dateOfBirth,
+	}
+}
+
+func (sp *StudentProfile) GetPatronymic() string {
+	return sp.patronymic
+}
+
+func (sp *StudentProfile) GetFaculty() string {
+	return sp.faculty
+}
+
+func (sp *StudentProfile) GetCourse() int {
+	return sp.course
+}
+
+func (sp *StudentProfile) GetPhone() string {
+	return sp.phone
+}
+
+func (sp *StudentProfile) GetEmail() string {
+	return sp.email
+}
+
+func (sp *StudentProfile) GetDateOfBirth() time.Time {
+	return sp.dateOfBirth
+}
+
 func CreateStudentResponse(students []Student) []StudentResponseDTO {
 	newArray := make([]StudentResponseDTO, Empty)
 	for _, tmpStudent := range students {
-		newArray = append(newArray, StudentResponseDTO{
-			Name:          tmpStudent.GetName(),
-			Surname:       tmpStudent.GetSurname(),
-			StudentGroup:  tmpStudent.GetStudentGroup(),
-			StudentNumber: tmpStudent.GetStudentNumber(),
-			RoomID:        tmpStudent.GetRoomID(),
-		})
+		newArray = append(newArray, CreateStudentResponseSingle(tmpStudent))
 	}
 	return newArray
 }
 
 func CreateStudentResponseSingle(student Student) StudentResponseDTO {
+	profile := student.GetProfile()
+	var dateOfBirth string
+	if !profile.GetDateOfBirth().IsZero() {
+		dateOfBirth = profile.GetDateOfBirth().Format(DateFormat)
+	}
 	return StudentResponseDTO{
 		Name:          student.GetName(),
 		Surname:       student.GetSurname(),
 		StudentGroup:  student.GetStudentGroup(),
 		StudentNumber: student.GetStudentNumber(),
 		RoomID:        student.GetRoomID(),
+		Gender:        student.GetGender(),
+		Patronymic:    profile.GetPatronymic(),
+		Faculty:       profile.GetFaculty(),
+		Course:        profile.GetCourse(),
+		Phone:         profile.GetPhone(),
+		Email:         profile.GetEmail(),
+		DateOfBirth:   dateOfBirth,
+		Status:        student.GetStatus(),
+	}
+}
+
+func NewStudentFilter(surnamePrefix, group, faculty string, roomID int, living, hasThings *bool,
+	status StudentStatus) StudentFilter {
+	return StudentFilter{
+		surnamePrefix: surnamePrefix,
+		group:         group,
+		faculty:       faculty,
+		roomID:        roomID,
+		living:        living,
+		hasThings:     hasThings,
+		status:        status,
 	}
 }
+
+func NewEmptyStudentFilter() StudentFilter {
+	return StudentFilter{roomID: None}
+}
+
+func (sf *StudentFilter) SetSort(sortBy string, descending bool) {
+	sf.sortBy = sortBy
+	sf.descending = descending
+}
+
+func (sf *StudentFilter) GetSurnamePrefix() string {
+	return sf.surnamePrefix
+}
+
+func (sf *StudentFilter) GetGroup() string {
+	return sf.group
+}
+
+func (sf *StudentFilter) GetFaculty() string {
+	return sf.faculty
+}
+
+func (sf *StudentFilter) GetRoomID() int {
+	return sf.roomID
+}
+
+func (sf *StudentFilter) GetLiving() *bool {
+	return sf.living
+}
+
+func (sf *StudentFilter) GetHasThings() *bool {
+	return sf.hasThings
+}
+
+func (sf *StudentFilter) GetStatus() StudentStatus {
+	return sf.status
+}
+
+func (sf *StudentFilter) GetSortBy() string {
+	return sf.sortBy
+}
+
+func (sf *StudentFilter) IsDescending() bool {
+	return sf.descending
+}
+
+func IsValidStudentSort(sortBy string) bool {
+	return sortBy == EmptyString || sortBy == SortBySurname || sortBy == SortByGroup ||
+		sortBy == SortByStudentNumber || sortBy == SortByRoom || sortBy == SortByCourse
+}
+
+func IsValidStudentNumber(studentNumber string) bool {
+	return studentNumberPattern.MatchString(studentNumber)
+}
+
+func IsValidStudentGroup(studentGroup string) bool {
+	return studentGroupPattern.MatchString(studentGroup)
+}
+
+func IsValidCourse(course int) bool {
+	return course >= FirstCourse && course <= LastCourse
+}
+
+func IsValidPhone(phone string) bool {
+	return phonePattern.MatchString(phone)
+}
+
+func IsValidEmail(email string) bool {
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email
+}
@@ -0,0 +1,148 @@
+package objects
+
+import "time"
+
+type ThingEventKind string
+
+const (
+	ThingGiven    ThingEventKind = "given"
+	ThingReturned ThingEventKind = "returned"
+	ThingMoved    ThingEventKind = "moved"
+)
+
+// ThingEvent is one act from StudentThingHistory (given, returned) or ThingRoomHistory (moved).
+type ThingEvent struct {
+	kind          ThingEventKind
+	date          time.Time
+	studentNumber string
+	srcRoomID     int
+	dstRoomID     int
+}
+
+type ThingEventResponseDTO struct {
+	Event         ThingEventKind `json:"event"`
+	Date          string         `json:"date"`
+	StudentNumber string         `json:"student-number,omitempty"`
+	SrcRoomID     int            `json:"src-room-id,omitempty"`
+	DstRoomID     int            `json:"dst-room-id,omitempty"`
+}
+
+func NewOwnerEvent(direction TransferDirection, date time.Time, studentNumber string) ThingEvent {
+	kind := ThingReturned
+	if direction == Get {
+		kind = ThingGiven
+	}
+	return ThingEvent{kind: kind, date: date, studentNumber: studentNumber}
+}
+
+func NewMoveEvent(date time.Time, srcRoomID, dstRoomID int) ThingEvent {
+	return ThingEvent{kind: ThingMoved, date: date, srcRoomID: srcRoomID, dstRoomID: dstRoomID}
+}
+
+func (e *ThingEvent) GetKind() ThingEventKind {
+	return e.kind
+}
+
+func (e *ThingEvent) GetDate() time.Time {
+	return e.date
+}
+
+func (e *ThingEvent) GetStudentNumber() string {
+	return e.studentNumber
+}
+
+func (e *ThingEvent) GetSrcRoomID() int {
+	return e.srcRoomID
+}
+
+func (e *ThingEvent) GetDstRoomID() int {
+	return e.dstRoomID
+}
+
+// OwnershipPeriod is the time a student held a thing. returnedAt is zero while the thing is not returned.
+type OwnershipPeriod struct {
+	studentNumber string
+	givenAt       time.Time
+	returnedAt    time.Time
+}
+
+type OwnershipPeriodResponseDTO struct {
+	StudentNumber string `json:"student-number"`
+	GivenAt       string `json:"given-at"`
+	ReturnedAt    string `json:"returned-at,omitempty"`
+	DaysHeld      int    `json:"days-held"`
+}
+
+func NewOwnershipPeriod(studentNumber string, givenAt, returnedAt time.Time) OwnershipPeriod {
+	return OwnershipPeriod{studentNumber: studentNumber, givenAt: givenAt, returnedAt: returnedAt}
+}
+
+func (p *OwnershipPeriod) GetStudentNumber() string {
+	return p.studentNumber
+}
+
+func (p *OwnershipPeriod) GetGivenAt() time.Time {
+	return p.givenAt
+}
+
+func (p *OwnershipPeriod) GetReturnedAt() time.Time {
+	return p.returnedAt
+}
+
+// GetDaysHeld counts the days up to now for a thing which is still held.
+func (p *OwnershipPeriod) GetDaysHeld(now time.Time) int {
+	end := p.returnedAt
+	if end.IsZero() {
+		end = now
+	}
+	return int(end.Sub(p.givenAt).Hours() / 24)
+}
+
+// CreateOwnershipPeriods pairs every given event with the next returned one.
+func CreateOwnershipPeriods(ownerEvents []ThingEvent) []OwnershipPeriod {
+	result := make([]OwnershipPeriod, Empty)
+	open := None
+	for _, event := range ownerEvents {
+		switch event.GetKind() {
+		case ThingGiven:
+			result = append(result, NewOwnershipPeriod(event.GetStudentNumber(), event.GetDate(), time.Time{}))
+			open = len(result) - 1
+		case ThingReturned:
+			if open != None && result[open].studentNumber == event.GetStudentNumber() {
+				result[open].returnedAt = event.GetDate()
+				open = None
+			}
+		}
+	}
+	return result
+}
+
+func CreateThingEventResponse(events []ThingEvent) []ThingEventResponseDTO {
+	result := make([]ThingEventResponseDTO, Empty)
+	for _, event := range events {
+		result = append(result, ThingEventResponseDTO{
+			Event:         event.GetKind(),
+			Date:          event.GetDate().Format(DateFormat),
+			StudentNumber: event.GetStudentNumber(),
+			SrcRoomID:     event.GetSrcRoomID(),
+			DstRoomID:     event.GetDstRoomID(),
+		})
+	}
+	return result
+}
+
+func CreateOwnershipPeriodResponse(periods []OwnershipPeriod, now time.Time) []OwnershipPeriodResponseDTO {
+	result := make([]OwnershipPeriodResponseDTO, Empty)
+	for _, period := range periods {
+		dto := OwnershipPeriodResponseDTO{
+			StudentNumber: period.GetStudentNumber(),
+			GivenAt:       period.GetGivenAt().Format(DateFormat),
+			DaysHeld:      period.GetDaysHeld(now),
+		}
+		if !period.GetReturnedAt().IsZero() {
+			dto.ReturnedAt = period.GetReturnedAt().Format(DateFormat)
+		}
+		result = append(result, dto)
+	}
+	return result
+}
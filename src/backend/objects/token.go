@@ -0,0 +1,95 @@
+package objects
+
+import "time"
+
+type RefreshToken struct {
+	id        int
+	userID    int
+	tokenHash string
+	expiresAt time.Time
+	revoked   bool
+}
+
+func NewRefreshTokenWithParams(id, userID int, tokenHash string, expiresAt time.Time, revoked bool) RefreshToken {
+	return RefreshToken{
+		id:        id,
+		userID:    userID,
+		tokenHash: tokenHash,
+		expiresAt: expiresAt,
+		revoked:   revoked,
+	}
+}
+
+func NewEmptyRefreshToken() RefreshToken {
+	return RefreshToken{id: None}
+}
+
+func (rt *RefreshToken) GetID() int {
+	return rt.id
+}
+
+func (rt *RefreshToken) GetUserID() int {
+	return rt.userID
+}
+
+func (rt *RefreshToken) GetTokenHash() string {
+	return rt.tokenHash
+}
+
+func (rt *RefreshToken) GetExpiresAt() time.Time {
+	return rt.expiresAt
+}
+
+func (rt *RefreshToken) IsRevoked() bool {
+	return rt.revoked
+}
+
+func (rt *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(rt.expiresAt)
+}
+
+type PasswordResetToken struct {
+	id        int
+	userID    int
+	tokenHash string
+	expiresAt time.Time
+	used      bool
+}
+
+func NewPasswordResetTokenWithParams(id, userID int, tokenHash string, expiresAt time.Time, used bool) PasswordResetToken {
+	return PasswordResetToken{
+		id:        id,
+		userID:    userID,
+		tokenHash: tokenHash,
+		expiresAt: expiresAt,
+		used:      used,
+	}
+}
+
+func NewEmptyPasswordResetToken() PasswordResetToken {
+	return PasswordResetToken{id: None}
+}
+
+func (pt *PasswordResetToken) GetID() int {
+	return pt.id
+}
+
+func (pt *PasswordResetToken) GetUserID() int {
+	return pt.userID
+}
+
+func (pt *PasswordResetToken) GetTokenHash() string {
+	return pt.tokenHash
+}
+
+func (pt *PasswordResetToken) GetExpiresAt() time.Time {
+	return pt.expiresAt
+}
+
+func (pt *PasswordResetToken) IsUsed() bool {
+	return pt.used
+}
+
+func (pt *PasswordResetToken) IsExpired(now time.Time) bool {
+	return !now.Before(pt.expiresAt)
+}
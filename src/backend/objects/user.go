@@ -14,6 +14,14 @@ type User struct {
 	login          string
 	password       string
 	privelegeLevel Levels
+	disabled       bool
+}
+
+type UserResponseDTO struct {
+	ID       int    `json:"id"`
+	Login    string `json:"login"`
+	Role     Levels `json:"role"`
+	Disabled bool   `json:"disabled"`
 }
 
 func NewUserWithParams(id int, login, password string, privelegeLevel Levels) User {
@@ -44,3 +52,28 @@ func (u *User) GetPassword() string {
 func (u *User) GetPrivelegeLevel() Levels {
 	return u.privelegeLevel
 }
+
+func (u *User) IsDisabled() bool {
+	return u.disabled
+}
+
+func (u *User) SetDisabled(disabled bool) {
+	u.disabled = disabled
+}
+
+func (u *User) SetPrivelegeLevel(level Levels) {
+	u.privelegeLevel = level
+}
+
+func CreateUserResponse(users []User) []UserResponseDTO {
+	result := make([]UserResponseDTO, Empty)
+	for _, user := range users {
+		result = append(result, UserResponseDTO{
+			ID:       user.GetID(),
+			Login:    user.GetLogin(),
+			Role:     user.GetPrivelegeLevel(),
+			Disabled: user.IsDisabled(),
+		})
+	}
+	return result
+}
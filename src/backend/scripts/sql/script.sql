@@ -6,25 +6,114 @@ CREATE TABLE users
     id SERIAL PRIMARY KEY,
     userlogin TEXT unique,
     userpassword TEXT,
-    userrole int
+    userrole int,
+    disabled boolean DEFAULT false
 );
 
+-- Seed passwords are stored as plaintext and get hashed on the first successful login.
 INSERT INTO users(userlogin, userpassword, userrole) VALUES ('commend', '1234', 3);
 INSERT INTO users(userlogin, userpassword, userrole) VALUES ('supp', 'supp', 2);
 INSERT INTO users(userlogin, userpassword, userrole) VALUES ('priany', '123456', 1);
 INSERT INTO users(userlogin, userpassword, userrole) VALUES ('artem', '123456', 1);
 INSERT INTO users(userlogin, userpassword, userrole) VALUES ('sofa', '123456', 1);
 
+-- Accounts of LDAP and SSO providers linked to local users, subject is the id of the account in the provider.
+CREATE TABLE externalidentities
+(
+    provider TEXT,
+    subject TEXT,
+    userid int,
+    PRIMARY KEY (provider, subject),
+    FOREIGN KEY (userid) references users(id)
+);
+
+CREATE TABLE apikeys
+(
+    id SERIAL PRIMARY KEY,
+    name TEXT,
+    keyhash TEXT UNIQUE,
+    role int,
+    readonly boolean DEFAULT false,
+    createdby int,
+    createdat timestamp,
+    lastusedat timestamp,
+    revoked boolean DEFAULT false,
+    FOREIGN KEY (createdby) references users(id)
+);
+
+CREATE TABLE refreshtokens
+(
+    id SERIAL PRIMARY KEY,
+    userid int,
+    tokenhash TEXT UNIQUE,
+    expiresat timestamp,
+    revoked boolean DEFAULT false,
+    createdat timestamp,
+    FOREIGN KEY (userid) references users(id)
+);
+
+CREATE TABLE revokedtokens
+(
+    tokenid TEXT PRIMARY KEY,
+    expiresat timestamp
+);
+
+-- Failed login counters, key is "login:<login>" or "ip:<address>".
+CREATE TABLE loginattempts
+(
+    key TEXT PRIMARY KEY,
+    failures int,
+    lastfailure timestamptz
+);
+
+-- Access tokens of the user issued before revokedat are rejected, e.g. after a password change.
+CREATE TABLE userrevocations
+(
+    userid int PRIMARY KEY,
+    revokedat timestamptz,
+    FOREIGN KEY (userid) references users(id)
+);
+
+CREATE TABLE passwordresets
+(
+    id SERIAL PRIMARY KEY,
+    userid int,
+    tokenhash TEXT UNIQUE,
+    expiresat timestamp,
+    used boolean DEFAULT false,
+    createdat timestamp,
+    FOREIGN KEY (userid) references users(id)
+);
+
+CREATE TABLE buildings
+(
+    buildingid SERIAL PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL,
+    address TEXT DEFAULT '',
+    floors INT NOT NULL
+);
+
+INSERT INTO buildings(name, address, floors) VALUES ('Общежитие №1', '', 9);
+
 CREATE TABLE rooms
 (
     roomid SERIAL PRIMARY KEY,
     roomtype TEXT,
-    roomnumber INT
+    roomnumber INT,
+    capacity INT DEFAULT 0,
+    gender TEXT DEFAULT '',
+    buildingid INT NOT NULL,
+    floor INT NOT NULL,
+    block TEXT DEFAULT '',
+    FOREIGN KEY (buildingid) references buildings(buildingid),
+    UNIQUE (buildingid, roomnumber)
 );
 
-INSERT INTO rooms(roomtype, roomnumber) VALUES ('Склад', 0);
-INSERT INTO rooms(roomtype, roomnumber) VALUES ('Комната', 628);
-INSERT INTO rooms(roomtype, roomnumber) VALUES ('Комната', 629);
+INSERT INTO rooms(roomtype, roomnumber, capacity, gender, buildingid, floor) VALUES ('Склад', 0, 0, '', 1, 1);
+INSERT INTO rooms(roomtype, roomnumber, capacity, gender, buildingid, floor, block)
+    VALUES ('Комната', 628, 3, 'male', 1, 6, '628');
+INSERT INTO rooms(roomtype, roomnumber, capacity, gender, buildingid, floor, block)
+    VALUES ('Комната', 629, 3, '', 1, 6, '628');
 
 CREATE TABLE student
 (
@@ -35,17 +124,37 @@ CREATE TABLE student
     studentnumber TEXT UNIQUE,
     settledate DATE,
     webaccid int,
+    gender TEXT DEFAULT '',
+    patronymic TEXT NOT NULL DEFAULT '',
+    faculty TEXT NOT NULL DEFAULT '',
+    course int NOT NULL DEFAULT 0,
+    phone TEXT NOT NULL DEFAULT '',
+    email TEXT NOT NULL DEFAULT '',
+    dateofbirth DATE,
+    status TEXT NOT NULL DEFAULT 'active',
+    statusdate DATE,
     FOREIGN KEY (webaccid) references users(id)
 );
 
-INSERT INTO student(studentname, studentsurname, studentgroup, studentnumber, settledate, webaccid)
-VALUES ('Александр', 'Прянишников', 'ИУ7-75Б', '19У609', current_date, 3);
+INSERT INTO student(studentname, studentsurname, studentgroup, studentnumber, settledate, webaccid, gender)
+VALUES ('Александр', 'Прянишников', 'ИУ7-75Б', '19У609', current_date, 3, 'male');
+
+INSERT INTO student(studentname, studentsurname, studentgroup, studentnumber, settledate, webaccid, gender)
+VALUES ('Артем', 'Богаченко', 'ИУ7-55Б', '18У712', current_date, 4, 'male');
 
-INSERT INTO student(studentname, studentsurname, studentgroup, studentnumber, settledate, webaccid)
-VALUES ('Артем', 'Богаченко', 'ИУ7-55Б', '18У712', current_date, 4);
+INSERT INTO student(studentname, studentsurname, studentgroup, studentnumber, settledate, webaccid, gender)
+VALUES ('София', 'Шелия', 'ИУ7-65Б', '19У709', current_date, 5, 'female');
 
-INSERT INTO student(studentname, studentsurname, studentgroup, studentnumber, settledate, webaccid)
-VALUES ('София', 'Шелия', 'ИУ7-65Б', '19У709', current_date, 5);
+CREATE TABLE studentfees
+(
+    id SERIAL PRIMARY KEY,
+    studentid int NOT NULL,
+    amount int NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    duedate DATE NOT NULL,
+    paiddate DATE,
+    FOREIGN KEY (studentid) references student(studentid)
+);
 
 CREATE TABLE thing
 (
@@ -69,8 +178,10 @@ CREATE TABLE studentroomhistory
     roomid int,
     direction int,
     transferdate date,
+    performedby int,
     FOREIGN KEY (studentid) references student(studentid),
-    FOREIGN KEY (roomid) references rooms(roomid)
+    FOREIGN KEY (roomid) references rooms(roomid),
+    FOREIGN KEY (performedby) references users(id)
 );
 
 INSERT INTO studentroomhistory(studentid, roomid, direction, transferdate) VALUES (1, 2, 1, current_date);
@@ -114,6 +225,50 @@ INSERT INTO thingroomhistory(srcroomid, dstroomid, thingid, transferdate) VALUES
 INSERT INTO thingroomhistory(srcroomid, dstroomid, thingid, transferdate) VALUES (2, 3, 3, current_date);
 INSERT INTO thingroomhistory(srcroomid, dstroomid, thingid, transferdate) VALUES (1, 3, 6, current_date);
 
+CREATE TABLE settlementrequests
+(
+    id SERIAL PRIMARY KEY,
+    studentid int NOT NULL,
+    buildingid int,
+    floor int,
+    roomid int,
+    comment TEXT DEFAULT '',
+    priority int DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'pending',
+    createdat TIMESTAMP NOT NULL DEFAULT now(),
+    FOREIGN KEY (studentid) references student(studentid),
+    FOREIGN KEY (buildingid) references buildings(buildingid),
+    FOREIGN KEY (roomid) references rooms(roomid)
+);
+
+CREATE TABLE settlementrequesthistory
+(
+    id SERIAL PRIMARY KEY,
+    requestid int NOT NULL,
+    status TEXT NOT NULL,
+    changedat TIMESTAMP NOT NULL DEFAULT now(),
+    changedby int,
+    comment TEXT DEFAULT '',
+    FOREIGN KEY (requestid) references settlementrequests(id),
+    FOREIGN KEY (changedby) references users(id)
+);
+
+CREATE TABLE roomswaps
+(
+    id SERIAL PRIMARY KEY,
+    initiatorid int NOT NULL,
+    initiatorroomid int NOT NULL,
+    partnerid int NOT NULL,
+    partnerroomid int NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    createdat TIMESTAMP NOT NULL DEFAULT now(),
+    updatedat TIMESTAMP NOT NULL DEFAULT now(),
+    FOREIGN KEY (initiatorid) references student(studentid),
+    FOREIGN KEY (partnerid) references student(studentid),
+    FOREIGN KEY (initiatorroomid) references rooms(roomid),
+    FOREIGN KEY (partnerroomid) references rooms(roomid)
+);
+
 create function findroom(idthing integer) returns integer
     language plpgsql
 as
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"expvar"
+	"fmt"
 	"github.com/gorilla/mux"
 	_ "github.com/lib/pq"
 	"github.com/sirupsen/logrus"
@@ -8,26 +10,49 @@ import (
 	"net/http"
 	"os"
 	"src/configs/backend"
+	"src/db/apiKeyRepo"
+	"src/db/attemptRepo"
+	"src/db/buildingRepo"
 	"src/db/roomRepo"
+	"src/db/settlementRepo"
 	"src/db/studentRepo"
+	"src/db/swapRepo"
 	"src/db/thingRepo"
+	"src/db/tokenRepo"
 	"src/db/userRepo"
+	"src/delivery/http/apiKeyHandler"
 	"src/delivery/http/authHandler"
 	"src/delivery/http/roomHandler"
+	"src/delivery/http/settlementHandler"
 	"src/delivery/http/studentHandler"
+	"src/delivery/http/swapHandler"
 	"src/delivery/http/thingHandler"
+	"src/delivery/http/userHandler"
 	"src/docs"
+	"src/logic/controllers/apiKeyController"
+	"src/logic/controllers/attemptController"
+	"src/logic/controllers/buildingController"
 	"src/logic/controllers/roomController"
+	"src/logic/controllers/settlementController"
 	"src/logic/controllers/studentController"
+	"src/logic/controllers/swapController"
 	"src/logic/controllers/thingController"
+	"src/logic/controllers/tokenController"
 	"src/logic/controllers/userController"
-	"src/logic/managers/appManager"
+	"src/logic/managers/apiKeyManager"
 	"src/logic/managers/authManager"
 	"src/logic/managers/roomManager"
+	"src/logic/managers/settlementManager"
 	"src/logic/managers/studentManager"
+	"src/logic/managers/swapManager"
 	"src/logic/managers/thingManager"
+	"src/logic/managers/userManager"
 	"src/middleware"
+	httpUtils "src/utils"
+	"src/utils/access"
 	utils "src/utils/connection"
+	"src/utils/hashUtils"
+	"src/utils/jwtUtils"
 )
 
 var serverType = os.Getenv("SERVER_TYPE")
@@ -48,6 +73,15 @@ func (s *Server) Start() error {
 		docs.SwaggerInfo.BasePath = "/mirror1"
 	}
 
+	keySet, err := jwtUtils.NewKeySet(s.config.JWT)
+	if err != nil {
+		return err
+	}
+	trustedProxies, err := httpUtils.ParseTrustedProxies(s.config.TrustedProxies)
+	if err != nil {
+		return err
+	}
+
 	r := mux.NewRouter()
 	router := r.PathPrefix("/api/v1/").Subrouter()
 	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
@@ -56,33 +90,61 @@ func (s *Server) Start() error {
 	studentDB := utils.NewPgSQLConnection(s.config.ConnParams)
 	thingDB := utils.NewPgSQLConnection(s.config.ConnParams)
 	userDB := utils.NewPgSQLConnection(s.config.ConnParams)
+	tokenDB := utils.NewPgSQLConnection(s.config.ConnParams)
+	apiKeyDB := utils.NewPgSQLConnection(s.config.ConnParams)
 
 	roomRepository := roomRepo.PgRoomRepo{Conn: roomDB}
+	buildingRepository := buildingRepo.PgBuildingRepo{Conn: roomDB}
 	studentRepository := studentRepo.PgStudentRepo{Conn: studentDB}
+	settlementRepository := settlementRepo.PgSettlementRepo{Conn: studentDB}
+	swapRepository := swapRepo.PgSwapRepo{Conn: studentDB}
 	thingRepository := thingRepo.PgThingRepo{Conn: thingDB}
 	userRepository := userRepo.PgUserRepo{Conn: userDB}
+	tokenRepository := tokenRepo.PgTokenRepo{Conn: tokenDB}
+	attemptRepository := s.createAttemptRepo()
+	apiKeyRepository := apiKeyRepo.PgAPIKeyRepo{Conn: apiKeyDB}
 
 	RoomController := roomController.RoomController{Repo: &roomRepository}
+	BuildingController := buildingController.BuildingController{Repo: &buildingRepository}
 	StudentController := studentController.StudentController{Repo: &studentRepository}
+	SettlementController := settlementController.SettlementController{Repo: &settlementRepository}
+	SwapController := swapController.SwapController{Repo: &swapRepository}
 	ThingController := thingController.ThingController{Repo: &thingRepository}
-	UserController := userController.UserController{Repo: &userRepository}
-
-	RoomManager := roomManager.CreateNewRoomManager(RoomController)
-	StudentManager := studentManager.CreateNewStudentManager(RoomController, StudentController, UserController, ThingController)
+	UserController := userController.UserController{Repo: &userRepository,
+		Hasher: hashUtils.NewBcryptHasher(s.config.PasswordHashCost)}
+	TokenController := tokenController.TokenController{Repo: &tokenRepository}
+	APIKeyController := apiKeyController.APIKeyController{Repo: &apiKeyRepository}
+	AttemptController := attemptController.AttemptController{Repo: attemptRepository, Params: s.config.LoginThrottle}
+
+	RoomManager := roomManager.CreateNewRoomManager(RoomController, StudentController, BuildingController)
+	StudentManager := studentManager.CreateNewStudentManager(RoomController, StudentController, UserController,
+		ThingController, TokenController, keySet)
+	SettlementManager := settlementManager.CreateNewSettlementManager(SettlementController, StudentController)
+	SwapManager := swapManager.CreateNewSwapManager(SwapController, StudentController)
 	ThingManager := thingManager.CreateNewThingManager(RoomController, StudentController, ThingController)
-	AuthManager := authManager.CreateNewAuthManager(UserController)
-	AppManager := appManager.AppManager{}
+	AuthManager := authManager.CreateNewAuthManager(UserController, TokenController, AttemptController, keySet,
+		s.createAuthProviders()...)
+	UserManager := userManager.CreateNewUserManager(UserController, TokenController)
+	APIKeyManager := apiKeyManager.CreateNewAPIKeyManager(APIKeyController)
 
 	StudentHandler := studentHandler.CreateNewStudentHandler(s.logger, *StudentManager)
-	AuthHandler := authHandler.CreateNewAuthHandler(s.logger, *AuthManager, AppManager)
-	ThingHandler := thingHandler.CreateNewThingHandler(s.logger, *ThingManager)
+	AuthHandler := authHandler.CreateNewAuthHandler(s.logger, *AuthManager, trustedProxies)
+	ThingHandler := thingHandler.CreateNewThingHandler(s.logger, *ThingManager, *StudentManager)
 	RoomHandler := roomHandler.CreateNewRoomHandler(s.logger, *RoomManager)
+	SettlementHandler := settlementHandler.CreateNewSettlementHandler(s.logger, *SettlementManager, *StudentManager)
+	SwapHandler := swapHandler.CreateNewSwapHandler(s.logger, *SwapManager, *StudentManager)
+	UserHandler := userHandler.CreateNewUserHandler(s.logger, *UserManager)
+	APIKeyHandler := apiKeyHandler.CreateNewAPIKeyHandler(s.logger, *APIKeyManager)
 
 	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 
 	router.HandleFunc("/students", StudentHandler.GetAllStudents).Methods("GET")
 	router.HandleFunc("/students", StudentHandler.AddNewStudent).Methods("POST")
+	router.HandleFunc("/students/import", StudentHandler.ImportStudents).Methods("POST")
 	router.HandleFunc("/students/{stud-number}", StudentHandler.ChangeStudentGroup).Methods("PUT")
+	router.HandleFunc("/students/{stud-number}", StudentHandler.ChangeStudent).Methods("PATCH")
+	router.HandleFunc("/students/{stud-number}/status", StudentHandler.ChangeStudentStatus).Methods("PUT")
+	router.HandleFunc("/students/{stud-number}/clearance", StudentHandler.GetStudentClearance).Methods("GET")
 	router.HandleFunc("/students/{stud-number}", StudentHandler.ViewStudentInfo).Methods("GET")
 	router.HandleFunc("/student-live-acts/{stud-number}", StudentHandler.TransferStudent).Methods("POST")
 	router.HandleFunc("/student-live-acts/{stud-number}", StudentHandler.ViewStudentLivingHistory).Methods("GET")
@@ -94,11 +156,88 @@ func (s *Server) Start() error {
 	router.HandleFunc("/things", ThingHandler.AddNewThing).Methods("POST")
 	router.HandleFunc("/things/{mark-number}", ThingHandler.TransferThingBetweenRooms).Methods("PATCH")
 	router.HandleFunc("/login", AuthHandler.Authorize).Methods("POST")
+	router.HandleFunc("/login/{provider}", AuthHandler.ExternalLogin).Methods("GET")
+	router.HandleFunc("/login/{provider}/callback", AuthHandler.ExternalLoginCallback).Methods("GET")
+	router.HandleFunc("/login/{provider}/link", AuthHandler.LinkExternalAccount).Methods("POST")
+	router.HandleFunc("/refresh", AuthHandler.Refresh).Methods("POST")
+	router.HandleFunc("/logout", AuthHandler.Logout).Methods("POST")
+	router.HandleFunc("/.well-known/jwks.json", AuthHandler.GetJWKS).Methods("GET")
+	router.HandleFunc("/password", AuthHandler.ChangePassword).Methods("PUT")
+	router.HandleFunc("/password-reset", AuthHandler.ResetPassword).Methods("POST")
 	router.HandleFunc("/rooms", RoomHandler.GetAllRooms).Methods("GET")
 	router.HandleFunc("/rooms/{room-id}", RoomHandler.GetRoom).Methods("GET")
+	router.HandleFunc("/rooms/{room-id}/details", RoomHandler.GetRoomDetails).Methods("GET")
+	router.HandleFunc("/rooms", RoomHandler.AddRoom).Methods("POST")
+	router.HandleFunc("/rooms/{room-id}", RoomHandler.ChangeRoom).Methods("PATCH")
+	router.HandleFunc("/rooms/{room-id}", RoomHandler.DeleteRoom).Methods("DELETE")
+	router.HandleFunc("/buildings", RoomHandler.GetAllBuildings).Methods("GET")
+	router.HandleFunc("/buildings", RoomHandler.AddBuilding).Methods("POST")
+	router.HandleFunc("/buildings/{building-id}", RoomHandler.GetBuilding).Methods("GET")
+	router.HandleFunc("/settlement-requests", SettlementHandler.GetSettlementRequests).Methods("GET")
+	router.HandleFunc("/settlement-requests", SettlementHandler.SubmitSettlementRequest).Methods("POST")
+	router.HandleFunc("/settlement-requests/{request-id}", SettlementHandler.GetSettlementRequest).Methods("GET")
+	router.HandleFunc("/settlement-requests/{request-id}", SettlementHandler.ChangeSettlementRequest).Methods("PATCH")
+	router.HandleFunc("/settlement-requests/{request-id}", SettlementHandler.CancelSettlementRequest).Methods("DELETE")
+	router.HandleFunc("/room-swaps", SwapHandler.GetSwaps).Methods("GET")
+	router.HandleFunc("/room-swaps", SwapHandler.ProposeSwap).Methods("POST")
+	router.HandleFunc("/room-swaps/{swap-id}", SwapHandler.GetSwap).Methods("GET")
+	router.HandleFunc("/room-swaps/{swap-id}", SwapHandler.ChangeSwap).Methods("PATCH")
+	router.HandleFunc("/users", UserHandler.GetUsers).Methods("GET")
+	router.HandleFunc("/users", UserHandler.AddNewUser).Methods("POST")
+	router.HandleFunc("/users/{user-id}", UserHandler.ChangeUser).Methods("PATCH")
+	router.HandleFunc("/users/{user-id}/password-reset", AuthHandler.CreatePasswordReset).Methods("POST")
+	router.HandleFunc("/api-keys", APIKeyHandler.GetAPIKeys).Methods("GET")
+	router.HandleFunc("/api-keys", APIKeyHandler.CreateAPIKey).Methods("POST")
+	router.HandleFunc("/api-keys/{key-id}", APIKeyHandler.RevokeAPIKey).Methods("DELETE")
+	router.Handle("/debug/vars", expvar.Handler()).Methods("GET")
+
+	err = checkPermissions(router)
+	if err != nil {
+		return err
+	}
 
-	accessRouter := middleware.CheckAccess(router)
+	accessRouter := middleware.CheckAccess(router, AuthManager, APIKeyManager, s.logger)
 	upgradedRouter := middleware.Panic(accessRouter)
 
 	return http.ListenAndServe(s.config.PortToStart, upgradedRouter)
 }
+
+// createAttemptRepo shares login counters through Postgres unless the memory store is configured.
+func (s *Server) createAttemptRepo() attemptRepo.AttemptRepo {
+	if s.config.LoginThrottle.Store == attemptRepo.MemoryStore {
+		return attemptRepo.NewMemoryAttemptRepo()
+	}
+	return &attemptRepo.PgAttemptRepo{Conn: utils.NewPgSQLConnection(s.config.ConnParams)}
+}
+
+// createAuthProviders returns the external providers enabled in the config.
+func (s *Server) createAuthProviders() []authManager.AuthProvider {
+	var providers []authManager.AuthProvider
+	if s.config.AuthProviders.LDAP.URL != "" {
+		providers = append(providers, authManager.NewLDAPAuthProvider(s.config.AuthProviders.LDAP))
+	}
+	if s.config.AuthProviders.OIDC.Issuer != "" {
+		providers = append(providers, authManager.NewOIDCAuthProvider(s.config.AuthProviders.OIDC))
+	}
+	return providers
+}
+
+// checkPermissions makes sure that every registered route has an entry in the permission table.
+func checkPermissions(router *mux.Router) error {
+	return router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			methods = []string{http.MethodGet}
+		}
+		for _, method := range methods {
+			if !access.IsRouteDeclared(template, method) {
+				return fmt.Errorf("route %s %s is missing in the permission table", method, template)
+			}
+		}
+		return nil
+	})
+}
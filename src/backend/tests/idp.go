@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/golang-jwt/jwt/v4"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+const (
+	StubIdPClientID = "hostel-backend"
+	StubIdPCode     = "valid-code"
+	stubIdPKeyID    = "stub-key"
+)
+
+// NewStubIdP starts an OIDC provider with discovery, JWKS and token endpoints. The token endpoint
+// accepts only StubIdPCode and returns an ID token with claims added to iss, aud, exp and iat.
+func NewStubIdP(claims map[string]interface{}) *httptest.Server {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"issuer":                                server.URL,
+			"authorization_endpoint":                server.URL + "/authorize",
+			"token_endpoint":                        server.URL + "/token",
+			"jwks_uri":                              server.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": stubIdPKeyID,
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.ParseForm() != nil || r.PostForm.Get("code") != StubIdPCode {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		tokenClaims := jwt.MapClaims{
+			"iss": server.URL,
+			"aud": StubIdPClientID,
+			"exp": time.Now().Add(time.Minute).Unix(),
+			"iat": time.Now().Unix(),
+		}
+		for name, value := range claims {
+			tokenClaims[name] = value
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, tokenClaims)
+		token.Header["kid"] = stubIdPKeyID
+		idToken, _ := token.SignedString(key)
+		writeJSON(w, map[string]interface{}{
+			"access_token": "stub-access-token",
+			"token_type":   "Bearer",
+			"expires_in":   60,
+			"id_token":     idToken,
+		})
+	})
+	return server
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
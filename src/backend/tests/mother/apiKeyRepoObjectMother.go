@@ -0,0 +1,44 @@
+package mother
+
+import (
+	"database/sql"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/objects"
+	"src/utils/hashUtils"
+	"time"
+)
+
+type APIKeyRepoObjectMother struct{}
+
+var (
+	DefaultAPIKey     = "hk_api-key"
+	DefaultAPIKeyName = "inventory-script"
+)
+
+func (m APIKeyRepoObjectMother) CreateRepo() (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New()
+	return db, mock
+}
+
+func (m APIKeyRepoObjectMother) CreateAPIKey(role objects.Levels, readOnly bool, lastUsedAt time.Time,
+	revoked bool) objects.APIKey {
+	key := objects.NewAPIKeyWithParams(int(InsertID), DefaultAPIKeyName, hashUtils.HashToken(DefaultAPIKey),
+		role, readOnly, 1, time.Now().Add(-time.Hour))
+	key.SetLastUsedAt(lastUsedAt)
+	key.SetRevoked(revoked)
+	return key
+}
+
+func (m APIKeyRepoObjectMother) CreateRows(keys []objects.APIKey) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "name", "keyhash", "role", "readonly", "createdby", "createdat",
+		"lastusedat", "revoked"})
+	for _, key := range keys {
+		var lastUsedAt sql.NullTime
+		if !key.GetLastUsedAt().IsZero() {
+			lastUsedAt = sql.NullTime{Time: key.GetLastUsedAt(), Valid: true}
+		}
+		rows.AddRow(key.GetID(), key.GetName(), key.GetKeyHash(), key.GetRole(), key.IsReadOnly(),
+			key.GetCreatedBy(), key.GetCreatedAt(), lastUsedAt, key.IsRevoked())
+	}
+	return rows
+}
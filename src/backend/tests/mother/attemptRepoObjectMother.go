@@ -0,0 +1,33 @@
+package mother
+
+import (
+	"database/sql"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/objects"
+	"time"
+)
+
+type AttemptRepoObjectMother struct{}
+
+var (
+	DefaultClientIP   = "10.0.0.1"
+	DefaultLoginKey   = "login:ivan"
+	DefaultIPKey      = "ip:10.0.0.1"
+	DefaultResetAfter = time.Hour
+
+	DefaultAttemptLimits = objects.AttemptLimits{FreeAttempts: 2, BaseDelay: time.Minute, MaxDelay: time.Hour,
+		ResetAfter: DefaultResetAfter}
+)
+
+func (m AttemptRepoObjectMother) CreateRepo() (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New()
+	return db, mock
+}
+
+func (m AttemptRepoObjectMother) CreateRows(attempts []objects.LoginAttempts) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"key", "failures", "lastfailure"})
+	for _, attempt := range attempts {
+		rows.AddRow(attempt.GetKey(), attempt.GetFailures(), attempt.GetLastFailure())
+	}
+	return rows
+}
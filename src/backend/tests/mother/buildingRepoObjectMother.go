@@ -0,0 +1,48 @@
+package mother
+
+import (
+	"database/sql"
+	"fmt"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/objects"
+)
+
+const (
+	DefaultBuildingName    = "Общежитие №"
+	DefaultBuildingAddress = "ул. Бауманская"
+	DefaultFloors          = 9
+)
+
+type BuildingRepoObjectMother struct{}
+
+func (m BuildingRepoObjectMother) CreateRepo() (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New()
+	return db, mock
+}
+
+func (m BuildingRepoObjectMother) CreateDefaultBuildings(amount int) []objects.Building {
+	resultBuildings := make([]objects.Building, objects.Empty)
+	for i := 1; i <= amount; i++ {
+		resultBuildings = append(resultBuildings, objects.NewBuildingWithParams(i,
+			DefaultBuildingName+fmt.Sprintf("%d", i), DefaultBuildingAddress, DefaultFloors))
+	}
+	return resultBuildings
+}
+
+func (m BuildingRepoObjectMother) CreateRows(buildings []objects.Building) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"buildingid", "name", "address", "floors"})
+	for _, building := range buildings {
+		rows.AddRow(building.GetID(), building.GetName(), building.GetAddress(), building.GetFloors())
+	}
+	return rows
+}
+
+func (m BuildingRepoObjectMother) CreateExistsRows(exist bool) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"exists"})
+	rows.AddRow(exist)
+	return rows
+}
+
+func (m BuildingRepoObjectMother) CreateBuildingDTO() objects.BuildingDTO {
+	return objects.NewBuildingDTO(DefaultBuildingName+"1", DefaultBuildingAddress, DefaultFloors)
+}
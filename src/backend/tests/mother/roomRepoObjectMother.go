@@ -7,7 +7,10 @@ import (
 )
 
 const (
-	Type = "Комната"
+	Type              = "Комната"
+	DefaultCapacity   = 3
+	DefaultBuildingID = 1
+	DefaultFloor      = 1
 )
 
 type RoomRepoObjectMother struct{}
@@ -21,19 +24,66 @@ func (m RoomRepoObjectMother) CreateDefaultRooms(amount int) []objects.Room {
 	resultRooms := make([]objects.Room, objects.Empty)
 	roomType := Type
 	for i := 1; i <= amount; i++ {
-		resultRooms = append(resultRooms, objects.NewRoomWithParams(i, roomType, i))
+		room := objects.NewRoomWithParams(i, roomType, i, DefaultCapacity, objects.GenderAny)
+		room.SetLocation(DefaultBuildingID, DefaultFloor, objects.EmptyString)
+		resultRooms = append(resultRooms, room)
 	}
 	return resultRooms
 }
 
 func (m RoomRepoObjectMother) CreateRows(rooms []objects.Room) *sqlmock.Rows {
-	rows := sqlmock.NewRows([]string{"roomid", "roomtype", "roomnumber"})
+	rows := sqlmock.NewRows([]string{"roomid", "roomtype", "roomnumber", "capacity", "gender", "buildingid", "floor",
+		"block", "occupied"})
 	for _, room := range rooms {
-		rows.AddRow(room.GetID(), room.GetRoomType(), room.GetRoomNumber())
+		rows.AddRow(room.GetID(), room.GetRoomType(), room.GetRoomNumber(), room.GetCapacity(), room.GetGender(),
+			room.GetBuildingID(), room.GetFloor(), room.GetBlock(), room.GetOccupied())
 	}
 	return rows
 }
 
+func (m RoomRepoObjectMother) CreateRoom(id, occupied int, gender objects.Gender) objects.Room {
+	room := objects.NewRoomWithParams(id, Type, id, DefaultCapacity, gender)
+	room.SetLocation(DefaultBuildingID, DefaultFloor, objects.EmptyString)
+	room.SetOccupied(occupied)
+	return room
+}
+
+// CreateLockRows returns the room row locked before a settle act, residents are counted by another query.
+func (m RoomRepoObjectMother) CreateLockRows(room objects.Room) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"roomid", "roomtype", "roomnumber", "capacity", "gender"})
+	rows.AddRow(room.GetID(), room.GetRoomType(), room.GetRoomNumber(), room.GetCapacity(), room.GetGender())
+	return rows
+}
+
+func (m RoomRepoObjectMother) CreateCountRows(count int) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"count"})
+	rows.AddRow(count)
+	return rows
+}
+
+func (m RoomRepoObjectMother) CreateExistsRows(exist bool) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"exists"})
+	rows.AddRow(exist)
+	return rows
+}
+
+// ExpectLocks adds the queries which lock the student and the room in a settle transaction.
+// The student is not found if students is empty.
+func (m RoomRepoObjectMother) ExpectLocks(mock sqlmock.Sqlmock, studentID int, students []objects.Student,
+	room objects.Room) {
+	StudentRepoObjectMother{}.ExpectLock(mock, studentID, students)
+	m.ExpectRoomLock(mock, room)
+}
+
+// ExpectRoomLock adds the queries which lock the room and count its residents in a transaction.
+func (m RoomRepoObjectMother) ExpectRoomLock(mock sqlmock.Sqlmock, room objects.Room) {
+	mock.ExpectQuery("SELECT").WithArgs(room.GetID()).WillReturnError(nil).WillReturnRows(m.CreateLockRows(room))
+	mock.ExpectQuery("SELECT").WithArgs(room.GetID()).WillReturnError(nil).
+		WillReturnRows(m.CreateCountRows(room.GetOccupied()))
+}
+
 func (m RoomRepoObjectMother) CreateDTORoom() objects.RoomDTO {
-	return objects.NewRoomDTO(Type, int(InsertID))
+	roomDTO := objects.NewRoomDTO(Type, int(InsertID), DefaultCapacity, objects.GenderAny)
+	roomDTO.SetLocation(DefaultBuildingID, DefaultFloor, objects.EmptyString)
+	return roomDTO
 }
@@ -0,0 +1,66 @@
+package mother
+
+import (
+	"database/sql"
+	"fmt"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/objects"
+	"time"
+)
+
+const (
+	DefaultSettlementComment = "Хочу жить с одногруппниками"
+	DefaultPriority          = 0
+)
+
+type SettlementRepoObjectMother struct{}
+
+func (m SettlementRepoObjectMother) CreateRepo() (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New()
+	return db, mock
+}
+
+func (m SettlementRepoObjectMother) CreatePreferences() objects.SettlementPreferences {
+	return objects.NewSettlementPreferences(DefaultBuildingID, DefaultFloor, objects.None, DefaultSettlementComment)
+}
+
+func (m SettlementRepoObjectMother) CreateDefaultRequests(amount int,
+	status objects.SettlementStatus) []objects.SettlementRequest {
+	resultRequests := make([]objects.SettlementRequest, objects.Empty)
+	date := time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= amount; i++ {
+		resultRequests = append(resultRequests, objects.NewSettlementRequestWithParams(i, i,
+			DefaultStudentNumber+fmt.Sprintf("%d", i), m.CreatePreferences(), DefaultPriority, status,
+			date.AddDate(0, 0, i)))
+	}
+	return resultRequests
+}
+
+func (m SettlementRepoObjectMother) CreateRows(requests []objects.SettlementRequest) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "studentid", "studentnumber", "buildingid", "floor", "roomid",
+		"comment", "priority", "status", "createdat"})
+	for _, request := range requests {
+		preferences := request.GetPreferences()
+		rows.AddRow(request.GetID(), request.GetStudentID(), request.GetStudentNumber(),
+			preferences.GetBuildingID(), preferences.GetFloor(), preferences.GetRoomID(), preferences.GetComment(),
+			request.GetPriority(), request.GetStatus(), request.GetCreatedAt())
+	}
+	return rows
+}
+
+func (m SettlementRepoObjectMother) CreateHistory(status objects.SettlementStatus) []objects.SettlementStatusChange {
+	date := time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC)
+	return []objects.SettlementStatusChange{
+		objects.NewSettlementStatusChange(objects.SettlementPending, date, DefaultStaffLogin,
+			DefaultSettlementComment),
+		objects.NewSettlementStatusChange(status, date.AddDate(0, 0, 1), DefaultStaffLogin, objects.EmptyString),
+	}
+}
+
+func (m SettlementRepoObjectMother) CreateHistoryRows(changes []objects.SettlementStatusChange) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"status", "changedat", "userlogin", "comment"})
+	for _, change := range changes {
+		rows.AddRow(change.GetStatus(), change.GetChangedAt(), change.GetChangedBy(), change.GetComment())
+	}
+	return rows
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
 	"src/objects"
+	"time"
 )
 
 var (
@@ -12,6 +13,9 @@ var (
 	DefaultStudentSurname = "Ivanov"
 	DefaultGroup          = "IU7-65B"
 	DefaultStudentNumber  = "19u609"
+	DefaultGender         = objects.GenderMale
+	DefaultStaffID        = 1
+	DefaultStaffLogin     = "comendant"
 )
 
 type StudentRepoObjectMother struct{}
@@ -25,17 +29,62 @@ func (m StudentRepoObjectMother) CreateDefaultStudents(amount int) []objects.Stu
 	resultStudents := make([]objects.Student, objects.Empty)
 	for i := 1; i <= amount; i++ {
 		resultStudents = append(resultStudents, objects.NewStudentWithParams(i, i, DefaultStudentName,
-			DefaultStudentSurname, DefaultGroup, DefaultStudentNumber+fmt.Sprintf("%d", i), i))
+			DefaultStudentSurname, DefaultGroup, DefaultStudentNumber+fmt.Sprintf("%d", i), i, DefaultGender))
 	}
 	return resultStudents
 }
 
 func (m StudentRepoObjectMother) CreateRows(students []objects.Student) *sqlmock.Rows {
 	rows := sqlmock.NewRows([]string{"studentid", "webaccid", "studentname", "studentsurname",
-		"studentgroup", "studentnumber", "roomid"})
+		"studentgroup", "studentnumber", "roomid", "gender", "patronymic", "faculty", "course", "phone", "email",
+		"dateofbirth", "status"})
 	for _, student := range students {
+		profile := student.GetProfile()
+		var dateOfBirth any
+		if !profile.GetDateOfBirth().IsZero() {
+			dateOfBirth = profile.GetDateOfBirth()
+		}
 		rows.AddRow(student.GetID(), student.GetAccID(), student.GetName(), student.GetSurname(),
-			student.GetStudentGroup(), student.GetStudentNumber(), student.GetRoomID())
+			student.GetStudentGroup(), student.GetStudentNumber(), student.GetRoomID(), student.GetGender(),
+			profile.GetPatronymic(), profile.GetFaculty(), profile.GetCourse(), profile.GetPhone(), profile.GetEmail(),
+			dateOfBirth, student.GetStatus())
+	}
+	return rows
+}
+
+func (m StudentRepoObjectMother) CreateLivingRecords(studentID, amount int) []objects.LivingRecord {
+	resultRecords := make([]objects.LivingRecord, objects.Empty)
+	date := time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= amount; i++ {
+		resultRecords = append(resultRecords, objects.NewLivingRecordWithParams(i, studentID, i,
+			objects.TransferDirection(i%2), date.AddDate(0, i, 0), DefaultStaffLogin))
+	}
+	return resultRecords
+}
+
+func (m StudentRepoObjectMother) CreateLivingRecordRows(records []objects.LivingRecord) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "studentid", "roomid", "direction", "transferdate", "userlogin"})
+	for _, record := range records {
+		rows.AddRow(record.GetID(), record.GetStudentID(), record.GetRoomID(), record.GetDirection(),
+			record.GetTransferDate(), record.GetPerformedBy())
+	}
+	return rows
+}
+
+func (m StudentRepoObjectMother) CreateDefaultFees(studentID, amount int) []objects.Fee {
+	resultFees := make([]objects.Fee, objects.Empty)
+	date := time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= amount; i++ {
+		resultFees = append(resultFees, objects.NewFeeWithParams(i, studentID, i*100000,
+			fmt.Sprintf("Проживание, месяц %d", i), date.AddDate(0, i, 0)))
+	}
+	return resultFees
+}
+
+func (m StudentRepoObjectMother) CreateFeeRows(fees []objects.Fee) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "studentid", "amount", "description", "duedate"})
+	for _, fee := range fees {
+		rows.AddRow(fee.GetID(), fee.GetStudentID(), fee.GetAmount(), fee.GetDescription(), fee.GetDueDate())
 	}
 	return rows
 }
@@ -46,6 +95,26 @@ func (m StudentRepoObjectMother) CreateRowForID(id int) *sqlmock.Rows {
 	return rows
 }
 
+// ExpectLock adds the queries which lock the student and read it in a transaction.
+// The student is not found if students is empty.
+func (m StudentRepoObjectMother) ExpectLock(mock sqlmock.Sqlmock, studentID int, students []objects.Student) {
+	if len(students) == objects.Empty {
+		mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnError(sql.ErrNoRows)
+	} else {
+		mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnError(nil).WillReturnRows(m.CreateRowForID(studentID))
+		mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnError(nil).WillReturnRows(m.CreateRows(students))
+	}
+}
+
+// ExpectDebts adds the queries which count unreturned things and unpaid fees of the locked student.
+func (m StudentRepoObjectMother) ExpectDebts(mock sqlmock.Sqlmock, studentID, things, fees int) {
+	mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnError(nil).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(things))
+	mock.ExpectQuery("SELECT").WithArgs(studentID).WillReturnError(nil).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(fees))
+}
+
 func (m StudentRepoObjectMother) CreateStudentDTO() objects.StudentDTO {
-	return objects.NewStudentDTO(DefaultStudentName, DefaultStudentSurname, DefaultGroup, DefaultGroup+"0")
+	return objects.NewStudentDTO(DefaultStudentName, DefaultStudentSurname, DefaultGroup, DefaultGroup+"0",
+		DefaultGender)
 }
@@ -0,0 +1,50 @@
+package mother
+
+import (
+	"database/sql"
+	"fmt"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/objects"
+	"time"
+)
+
+type SwapRepoObjectMother struct{}
+
+func (m SwapRepoObjectMother) CreateRepo() (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New()
+	return db, mock
+}
+
+// CreateParty returns the default student with the id, default students live in the room with the same id.
+func (m SwapRepoObjectMother) CreateParty(studentID int) objects.SwapParty {
+	return objects.NewSwapParty(studentID, DefaultStudentNumber+fmt.Sprintf("%d", studentID), studentID)
+}
+
+func (m SwapRepoObjectMother) CreateSwap(id, initiatorID, partnerID int, status objects.SwapStatus) objects.RoomSwap {
+	date := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+	return objects.NewRoomSwapWithParams(id, m.CreateParty(initiatorID), m.CreateParty(partnerID), status, date,
+		date.AddDate(0, 0, 1))
+}
+
+func (m SwapRepoObjectMother) CreateRows(swaps []objects.RoomSwap) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "initiatorid", "initiatornumber", "initiatorroomid", "partnerid",
+		"partnernumber", "partnerroomid", "status", "createdat", "updatedat"})
+	for _, swap := range swaps {
+		initiator, partner := swap.GetInitiator(), swap.GetPartner()
+		rows.AddRow(swap.GetID(), initiator.GetStudentID(), initiator.GetStudentNumber(), initiator.GetRoomID(),
+			partner.GetStudentID(), partner.GetStudentNumber(), partner.GetRoomID(), swap.GetStatus(),
+			swap.GetCreatedAt(), swap.GetUpdatedAt())
+	}
+	return rows
+}
+
+// ExpectLocks adds the queries which lock both students and then both rooms of the swap, each pair in id order.
+func (m SwapRepoObjectMother) ExpectLocks(mock sqlmock.Sqlmock, students []objects.Student, rooms []objects.Room) {
+	studentObjectMother, roomObjectMother := StudentRepoObjectMother{}, RoomRepoObjectMother{}
+	for _, student := range students {
+		studentObjectMother.ExpectLock(mock, student.GetID(), []objects.Student{student})
+	}
+	for _, room := range rooms {
+		roomObjectMother.ExpectRoomLock(mock, room)
+	}
+}
@@ -0,0 +1,88 @@
+package mother
+
+import (
+	"archive/zip"
+	"bytes"
+	"hash/crc32"
+	"src/objects"
+)
+
+const (
+	DefaultSheetName = "xl/worksheets/sheet1.xml"
+
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+ xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Студенты" sheetId="1" r:id="rId1"/><sheet name="Лист2" sheetId="2" r:id="rId2"/></sheets>
+</workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId2" Target="worksheets/sheet2.xml"/>
+<Relationship Id="rId1" Target="worksheets/sheet1.xml"/>
+</Relationships>`
+	xlsxOtherSheet = `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<sheetData><row r="1"><c r="A1"><v>not the first sheet</v></c></row></sheetData>
+</worksheet>`
+)
+
+type TableObjectMother struct{}
+
+// CreateSheet wraps rows of sheetData into a worksheet.
+func (m TableObjectMother) CreateSheet(rows string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + rows +
+		`</sheetData></worksheet>`
+}
+
+// CreateSharedStrings returns the shared strings part with the items in order.
+func (m TableObjectMother) CreateSharedStrings(items ...string) string {
+	result := `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`
+	for _, item := range items {
+		result += "<si><t>" + item + "</t></si>"
+	}
+	return result + "</sst>"
+}
+
+// CreateXLSX returns an xlsx file whose first sheet is sheet1.xml, though it is listed second in the relationships.
+// The shared strings part is left out if sharedStrings is empty.
+func (m TableObjectMother) CreateXLSX(sheet, sharedStrings string) []byte {
+	parts := map[string]string{
+		"xl/workbook.xml":            xlsxWorkbook,
+		"xl/_rels/workbook.xml.rels": xlsxWorkbookRels,
+		DefaultSheetName:             sheet,
+		"xl/worksheets/sheet2.xml":   xlsxOtherSheet,
+	}
+	if sharedStrings != objects.EmptyString {
+		parts["xl/sharedStrings.xml"] = sharedStrings
+	}
+	return m.createArchive(parts, objects.EmptyString, 0)
+}
+
+// CreateOversizedXLSX returns an xlsx file where the zip header of the first sheet declares size bytes unpacked.
+func (m TableObjectMother) CreateOversizedXLSX(size uint64) []byte {
+	return m.createArchive(map[string]string{
+		"xl/workbook.xml":            xlsxWorkbook,
+		"xl/_rels/workbook.xml.rels": xlsxWorkbookRels,
+		DefaultSheetName:             m.CreateSheet(objects.EmptyString),
+	}, DefaultSheetName, size)
+}
+
+func (m TableObjectMother) createArchive(parts map[string]string, oversizedPart string, size uint64) []byte {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for name, content := range parts {
+		if name == oversizedPart {
+			header := &zip.FileHeader{Name: name, Method: zip.Store, CRC32: crc32.ChecksumIEEE([]byte(content)),
+				CompressedSize64: uint64(len(content)), UncompressedSize64: size}
+			file, _ := writer.CreateRaw(header)
+			_, _ = file.Write([]byte(content))
+		} else {
+			file, _ := writer.Create(name)
+			_, _ = file.Write([]byte(content))
+		}
+	}
+	_ = writer.Close()
+	return buffer.Bytes()
+}
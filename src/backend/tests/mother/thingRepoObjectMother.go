@@ -2,8 +2,10 @@ package mother
 
 import (
 	"database/sql"
+	"fmt"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
 	"src/objects"
+	"time"
 )
 
 type ThingRepoObjectMother struct{}
@@ -44,6 +46,39 @@ func (m ThingRepoObjectMother) CreateRowForID(id int) *sqlmock.Rows {
 	return rows
 }
 
+// CreateOwnerEvents gives the thing to a student and returns it a month later, one student after another.
+func (m ThingRepoObjectMother) CreateOwnerEvents(students int) []objects.ThingEvent {
+	resultEvents := make([]objects.ThingEvent, objects.Empty)
+	date := time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= students; i++ {
+		studentNumber := DefaultStudentNumber + fmt.Sprintf("%d", i)
+		resultEvents = append(resultEvents, objects.NewOwnerEvent(objects.Get, date, studentNumber))
+		date = date.AddDate(0, 1, 0)
+		resultEvents = append(resultEvents, objects.NewOwnerEvent(objects.Ret, date, studentNumber))
+	}
+	return resultEvents
+}
+
+func (m ThingRepoObjectMother) CreateOwnerEventRows(events []objects.ThingEvent) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"direction", "transferdate", "studentnumber"})
+	for _, event := range events {
+		direction := objects.Ret
+		if event.GetKind() == objects.ThingGiven {
+			direction = objects.Get
+		}
+		rows.AddRow(direction, event.GetDate(), event.GetStudentNumber())
+	}
+	return rows
+}
+
+func (m ThingRepoObjectMother) CreateMoveEventRows(events []objects.ThingEvent) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"srcroomid", "dstroomid", "transferdate"})
+	for _, event := range events {
+		rows.AddRow(event.GetSrcRoomID(), event.GetDstRoomID(), event.GetDate())
+	}
+	return rows
+}
+
 func (m ThingRepoObjectMother) CreateThingDTO() objects.ThingDTO {
 	return objects.NewThingDTO(DefaultMarkNumber, DefaultThingType)
 }
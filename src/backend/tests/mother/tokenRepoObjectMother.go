@@ -0,0 +1,73 @@
+package mother
+
+import (
+	"database/sql"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"src/configs/backend"
+	"src/objects"
+	"src/utils/hashUtils"
+	"src/utils/jwtUtils"
+	"strings"
+	"time"
+)
+
+type TokenRepoObjectMother struct{}
+
+var (
+	DefaultRefreshToken = "refresh-token"
+	DefaultTokenID      = "token-id"
+	DefaultKeyID        = "test-key"
+	DefaultResetToken   = "reset-token"
+)
+
+func (m TokenRepoObjectMother) CreateRepo() (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, _ := sqlmock.New()
+	return db, mock
+}
+
+func (m TokenRepoObjectMother) CreateRefreshToken(userID int, expiresAt time.Time, revoked bool) objects.RefreshToken {
+	return objects.NewRefreshTokenWithParams(int(InsertID), userID, hashUtils.HashToken(DefaultRefreshToken),
+		expiresAt, revoked)
+}
+
+func (m TokenRepoObjectMother) CreateRows(tokens []objects.RefreshToken) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "userid", "tokenhash", "expiresat", "revoked"})
+	for _, token := range tokens {
+		rows.AddRow(token.GetID(), token.GetUserID(), token.GetTokenHash(), token.GetExpiresAt(), token.IsRevoked())
+	}
+	return rows
+}
+
+func (m TokenRepoObjectMother) CreatePasswordResetToken(userID int, expiresAt time.Time,
+	used bool) objects.PasswordResetToken {
+	return objects.NewPasswordResetTokenWithParams(int(InsertID), userID, hashUtils.HashToken(DefaultResetToken),
+		expiresAt, used)
+}
+
+func (m TokenRepoObjectMother) CreatePasswordResetRows(tokens []objects.PasswordResetToken) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "userid", "tokenhash", "expiresat", "used"})
+	for _, token := range tokens {
+		rows.AddRow(token.GetID(), token.GetUserID(), token.GetTokenHash(), token.GetExpiresAt(), token.IsUsed())
+	}
+	return rows
+}
+
+func (m TokenRepoObjectMother) CreateCountRows(count int) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"count"})
+	rows.AddRow(count)
+	return rows
+}
+
+// CreateKeySet returns HS256 keys, the first id is the active one.
+func (m TokenRepoObjectMother) CreateKeySet(keyIDs ...string) *jwtUtils.KeySet {
+	params := configs.JWTParams{ActiveKey: keyIDs[objects.Null]}
+	for _, keyID := range keyIDs {
+		params.Keys = append(params.Keys, configs.JWTKeyParams{
+			ID:        keyID,
+			Algorithm: jwtUtils.HS256,
+			Secret:    strings.Repeat(keyID, 32),
+		})
+	}
+	keySet, _ := jwtUtils.NewKeySet(params)
+	return keySet
+}
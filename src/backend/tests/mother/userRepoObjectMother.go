@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
 	"src/objects"
+	"src/utils/hashUtils"
 )
 
 type UserRepoObjectMother struct{}
@@ -12,6 +13,8 @@ var (
 	DefaultLogin                   = "Ivan"
 	DefaultPassword                = "12345678"
 	DefaultRole     objects.Levels = objects.StudentRole
+	DefaultProvider                = "sso"
+	DefaultSubject                 = "external-subject"
 )
 
 func (m UserRepoObjectMother) CreateRepo() (*sql.DB, sqlmock.Sqlmock) {
@@ -32,10 +35,24 @@ func (m UserRepoObjectMother) CreateDefaultUsers(amount int) []objects.User {
 	return resultUsers
 }
 
+func (m UserRepoObjectMother) CreateDefaultUsersWithHash(amount int) []objects.User {
+	resultUsers := make([]objects.User, objects.Empty)
+	passwordHash, _ := hashUtils.DefaultHasher.Hash(DefaultPassword)
+	for i := 1; i <= amount; i++ {
+		resultUsers = append(resultUsers, objects.NewUserWithParams(i, DefaultLogin,
+			passwordHash, DefaultRole))
+	}
+	return resultUsers
+}
+
+func (m UserRepoObjectMother) CreateUserWithRole(id int, role objects.Levels) objects.User {
+	return objects.NewUserWithParams(id, DefaultLogin, DefaultPassword, role)
+}
+
 func (m UserRepoObjectMother) CreateRows(users []objects.User) *sqlmock.Rows {
-	rows := sqlmock.NewRows([]string{"id", "userlogin", "userpassword", "userrole"})
+	rows := sqlmock.NewRows([]string{"id", "userlogin", "userpassword", "userrole", "disabled"})
 	for _, user := range users {
-		rows.AddRow(user.GetID(), user.GetLogin(), user.GetPassword(), user.GetPrivelegeLevel())
+		rows.AddRow(user.GetID(), user.GetLogin(), user.GetPassword(), user.GetPrivelegeLevel(), user.IsDisabled())
 	}
 	return rows
 }
@@ -1,11 +1,13 @@
 package tests
 
 import (
+	"database/sql/driver"
 	"errors"
 	"github.com/bloomberg/go-testgroup"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
 	"log"
 	"reflect"
+	"src/utils/hashUtils"
 	"time"
 )
 
@@ -33,3 +35,17 @@ func TimeTrack(start time.Time) {
 	elapsed := time.Since(start)
 	log.Printf("took %s", elapsed)
 }
+
+// HashedPasswordArg matches a query argument holding a hash of Password instead of the password itself.
+type HashedPasswordArg struct {
+	Password string
+}
+
+func (a HashedPasswordArg) Match(value driver.Value) bool {
+	storedPassword, ok := value.(string)
+	if !ok || !hashUtils.IsHashed(storedPassword) {
+		return false
+	}
+	isEqual, _ := hashUtils.DefaultHasher.Verify(storedPassword, a.Password)
+	return isEqual
+}
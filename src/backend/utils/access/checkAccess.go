@@ -2,12 +2,20 @@ package access
 
 import (
 	"src/objects"
-	"strings"
 )
 
-func CheckRoleAccess(requestURI string, method string, role objects.Levels) (result bool) {
-	if role > objects.NonAuth || requestURI == objects.AuthURI || strings.Contains(requestURI, "swagger") {
-		result = true
+// CheckRoleAccess looks the route up by its mux path template, so path variables do not matter.
+func CheckRoleAccess(template string, method string, role objects.Levels) (result bool) {
+	for _, allowedRole := range permissions[Route{Template: template, Method: method}] {
+		if allowedRole == role {
+			result = true
+			break
+		}
 	}
 	return result
 }
+
+func IsRouteDeclared(template string, method string) bool {
+	_, exist := permissions[Route{Template: template, Method: method}]
+	return exist
+}
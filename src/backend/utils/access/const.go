@@ -1 +1,87 @@
 package access
+
+import (
+	"net/http"
+	"src/objects"
+)
+
+type Route struct {
+	Template string
+	Method   string
+}
+
+var (
+	anyone   = []objects.Levels{objects.NonAuth, objects.StudentRole, objects.SupplyRole, objects.ComendRole}
+	everyone = []objects.Levels{objects.StudentRole, objects.SupplyRole, objects.ComendRole}
+	staff    = []objects.Levels{objects.SupplyRole, objects.ComendRole}
+	comend   = []objects.Levels{objects.ComendRole}
+)
+
+// permissions maps every route registered in server.Start to the roles allowed to call it.
+// Routes missing here are denied for everybody, the server refuses to start with such routes.
+var permissions = map[Route][]objects.Levels{
+	{objects.AuthURI, http.MethodPost}:                         anyone,
+	{objects.AuthURI + "/{provider}", http.MethodGet}:          anyone,
+	{objects.AuthURI + "/{provider}/callback", http.MethodGet}: anyone,
+	{objects.AuthURI + "/{provider}/link", http.MethodPost}:    everyone,
+	{objects.RefreshURI, http.MethodPost}:                      anyone,
+	{objects.LogoutURI, http.MethodPost}:                       anyone,
+	{objects.JWKSURI, http.MethodGet}:                          anyone,
+	{"/api/v1/swagger/", http.MethodGet}:                       anyone,
+
+	{objects.PasswordResetURI, http.MethodPost}: anyone,
+	{"/api/v1/password", http.MethodPut}:        everyone,
+
+	{"/api/v1/students", http.MethodGet}:                           staff,
+	{"/api/v1/students", http.MethodPost}:                          comend,
+	{"/api/v1/students/import", http.MethodPost}:                   comend,
+	{"/api/v1/students/{stud-number}", http.MethodPut}:             comend,
+	{"/api/v1/students/{stud-number}", http.MethodPatch}:           comend,
+	{"/api/v1/students/{stud-number}/status", http.MethodPut}:      comend,
+	{"/api/v1/students/{stud-number}/clearance", http.MethodGet}:   comend,
+	{"/api/v1/students/{stud-number}", http.MethodGet}:             everyone,
+	{"/api/v1/student-live-acts/{stud-number}", http.MethodPost}:   comend,
+	{"/api/v1/student-live-acts/{stud-number}", http.MethodGet}:    everyone,
+	{"/api/v1/student-things-acts/{mark-number}", http.MethodPost}: staff,
+	{"/api/v1/student-things-acts/{mark-number}", http.MethodGet}:  staff,
+
+	{"/api/v1/things", http.MethodGet}:                 everyone,
+	{"/api/v1/things", http.MethodPost}:                staff,
+	{"/api/v1/things/{mark-number}", http.MethodGet}:   staff,
+	{"/api/v1/things/{mark-number}", http.MethodPatch}: staff,
+
+	{"/api/v1/rooms", http.MethodGet}:              staff,
+	{"/api/v1/rooms", http.MethodPost}:             comend,
+	{"/api/v1/rooms/{room-id}", http.MethodGet}:    staff,
+	{"/api/v1/rooms/{room-id}", http.MethodPatch}:  comend,
+	{"/api/v1/rooms/{room-id}", http.MethodDelete}: comend,
+
+	{"/api/v1/rooms/{room-id}/details", http.MethodGet}: staff,
+
+	{"/api/v1/buildings", http.MethodGet}:               staff,
+	{"/api/v1/buildings", http.MethodPost}:              comend,
+	{"/api/v1/buildings/{building-id}", http.MethodGet}: staff,
+
+	{"/api/v1/settlement-requests", http.MethodGet}:                 everyone,
+	{"/api/v1/settlement-requests", http.MethodPost}:                everyone,
+	{"/api/v1/settlement-requests/{request-id}", http.MethodGet}:    everyone,
+	{"/api/v1/settlement-requests/{request-id}", http.MethodPatch}:  comend,
+	{"/api/v1/settlement-requests/{request-id}", http.MethodDelete}: everyone,
+
+	{"/api/v1/room-swaps", http.MethodGet}:             everyone,
+	{"/api/v1/room-swaps", http.MethodPost}:            everyone,
+	{"/api/v1/room-swaps/{swap-id}", http.MethodGet}:   everyone,
+	{"/api/v1/room-swaps/{swap-id}", http.MethodPatch}: everyone,
+
+	{"/api/v1/users", http.MethodGet}:             comend,
+	{"/api/v1/users", http.MethodPost}:            comend,
+	{"/api/v1/users/{user-id}", http.MethodPatch}: comend,
+
+	{"/api/v1/users/{user-id}/password-reset", http.MethodPost}: comend,
+
+	{"/api/v1/api-keys", http.MethodGet}:             comend,
+	{"/api/v1/api-keys", http.MethodPost}:            comend,
+	{"/api/v1/api-keys/{key-id}", http.MethodDelete}: comend,
+
+	{"/api/v1/debug/vars", http.MethodGet}: comend,
+}
@@ -25,4 +25,51 @@ var (
 	WrongRequestParamsErr   = errors.New("bad request")
 	NotImplementedErr       = errors.New("not implemented")
 	ThingHasNotOwnerErr     = errors.New("thing hasn't owner")
+	BadRefreshTokenErr      = errors.New("refresh token is invalid")
+	RefreshTokenExpiredErr  = errors.New("refresh token is expired")
+	RefreshTokenReusedErr   = errors.New("refresh token was already used")
+	BadAccessTokenErr       = errors.New("access token is invalid")
+	AccessDeniedErr         = errors.New("access denied")
+	BadRoleErr              = errors.New("bad role")
+	UserDisabledErr         = errors.New("user is disabled")
+	NotStaffUserErr         = errors.New("user is not a staff member")
+	SelfActionErr           = errors.New("action on own account is not allowed")
+	BadResetTokenErr        = errors.New("password reset token is invalid")
+	ResetTokenExpiredErr    = errors.New("password reset token is expired")
+	TooManyAttemptsErr      = errors.New("too many failed login attempts")
+	UnknownProviderErr      = errors.New("authentication provider is not configured")
+	ExternalAuthErr         = errors.New("authentication provider rejected the login")
+	ExternalNotLinkedErr    = errors.New("local user with this login exists, its owner must link the account")
+	ExternalLinkedErr       = errors.New("account of the provider is already linked")
+	APIKeyNotFoundErr       = errors.New("api key not found")
+	BadAPIKeyErr            = errors.New("api key is invalid or revoked")
+	ReadOnlyAPIKeyErr       = errors.New("api key is read-only")
+	RoomIsFullErr           = errors.New("room has no free beds")
+	RoomNotForLivingErr     = errors.New("room is not for living")
+	RoomGenderErr           = errors.New("room is reserved for another gender")
+	RoomAlreadyExistErr     = errors.New("room with this number already exists in the building")
+	BuildingNotFoundErr     = errors.New("building not found")
+	BuildingAlreadyExistErr = errors.New("building with this name already exists")
+	BadBuildingParamsErr    = errors.New("bad building params")
+	FloorNotFoundErr        = errors.New("building has no such floor")
+	RoomHasStudentsErr      = errors.New("students live in the room")
+	RoomHasThingsErr        = errors.New("things are located in the room")
+	RoomHasHistoryErr       = errors.New("room is referenced by transfer history")
+	RoomCapacityErr         = errors.New("room capacity is less than number of residents")
+	SettlementNotFoundErr   = errors.New("settlement request not found")
+	SettlementExistsErr     = errors.New("student already has a pending settlement request")
+	SettlementClosedErr     = errors.New("settlement request is already closed")
+	BadSettlementParamsErr  = errors.New("bad settlement request params")
+	SwapNotFoundErr         = errors.New("room swap not found")
+	SwapExistsErr           = errors.New("student already takes part in an open room swap")
+	SwapStatusErr           = errors.New("room swap can't be moved to this status")
+	SwapOutdatedErr         = errors.New("students moved since the room swap was proposed")
+	BadSwapParamsErr        = errors.New("bad room swap params")
+	BadStudentFieldsErr     = errors.New("some student fields are invalid")
+	StudentNotActiveErr     = errors.New("student is not active")
+	StudentStatusErr        = errors.New("student can't be moved to this status")
+	StudentIsLivingErr      = errors.New("student still lives in the dormitory")
+	StudentHasThingsErr     = errors.New("student has unreturned things")
+	StudentClearanceErr     = errors.New("student has clearance blockers")
+	BadImportFileErr        = errors.New("import file has no header or required columns")
 )
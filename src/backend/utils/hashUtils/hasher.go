@@ -0,0 +1,62 @@
+package hashUtils
+
+import (
+	"crypto/subtle"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
+
+const (
+	BcryptPrefix = "$2"
+	DefaultCost  = bcrypt.DefaultCost
+)
+
+// PasswordHasher hides the way user passwords are stored in the users table.
+// Verify reports whether the stored value must be rehashed, so legacy
+// plaintext rows and hashes with an outdated cost are upgraded on login.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(storedPassword, password string) (ok bool, needRehash bool)
+}
+
+// BcryptHasher stores passwords in the bcrypt modular format "$2a$<cost>$<salt+hash>",
+// which keeps the algorithm version and cost next to the hash itself.
+type BcryptHasher struct {
+	Cost int
+}
+
+var DefaultHasher PasswordHasher = NewBcryptHasher(DefaultCost)
+
+// DummyHash is checked for unknown logins, so the response time doesn't tell whether the login exists.
+const DummyHash = "$2a$10$xJvp/aqwQi6yNUGt1nKQXOFRqZaWP9fXnBf4Yrlff5ZmiBs02A05S"
+
+func NewBcryptHasher(cost int) *BcryptHasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = DefaultCost
+	}
+	return &BcryptHasher{Cost: cost}
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	return string(hash), err
+}
+
+func (h *BcryptHasher) Verify(storedPassword, password string) (ok bool, needRehash bool) {
+	if !IsHashed(storedPassword) {
+		ok = subtle.ConstantTimeCompare([]byte(storedPassword), []byte(password)) == 1
+		return ok, ok
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(storedPassword), []byte(password)) == nil {
+		ok = true
+		cost, costErr := bcrypt.Cost([]byte(storedPassword))
+		needRehash = costErr != nil || cost < h.Cost
+	}
+	return ok, needRehash
+}
+
+// IsHashed distinguishes bcrypt hashes from passwords saved before hashing was introduced.
+func IsHashed(storedPassword string) bool {
+	return strings.HasPrefix(storedPassword, BcryptPrefix)
+}
@@ -0,0 +1,26 @@
+package hashUtils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const DefaultTokenBytes = 32
+
+// GenerateToken returns a random url-safe string, used for refresh tokens and token IDs.
+func GenerateToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashToken is used for random server-issued secrets: they have enough entropy,
+// so a fast digest is enough to keep them unusable if the table leaks.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
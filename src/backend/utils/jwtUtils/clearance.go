@@ -0,0 +1,38 @@
+package jwtUtils
+
+import (
+	"github.com/golang-jwt/jwt/v4"
+	"src/objects"
+	"src/utils/hashUtils"
+	"time"
+)
+
+const clearanceType = "clearance"
+
+type clearanceClaims struct {
+	Type          string `json:"typ"`
+	StudentNumber string `json:"student-number"`
+	jwt.RegisteredClaims
+}
+
+// SignClearance signs the clearance of the student with the active key, it can be checked with the public JWKS.
+// The document has no expiration time, so ParseJWT never accepts it as an access token.
+func (ks *KeySet) SignClearance(studentNumber string, issuedAt time.Time) (string, error) {
+	documentID, err := hashUtils.GenerateToken(tokenIDBytes)
+	if err != nil {
+		return objects.EmptyString, err
+	}
+
+	activeKey := ks.keys[ks.activeKeyID]
+	token := jwt.NewWithClaims(activeKey.method, clearanceClaims{
+		Type:          clearanceType,
+		StudentNumber: studentNumber,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:       documentID,
+			Subject:  studentNumber,
+			IssuedAt: jwt.NewNumericDate(issuedAt),
+		},
+	})
+	token.Header[keyIDHeader] = activeKey.id
+	return token.SignedString(activeKey.signKey)
+}
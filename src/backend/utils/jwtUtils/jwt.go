@@ -2,41 +2,116 @@ package jwtUtils
 
 import (
 	"fmt"
-	"github.com/dgrijalva/jwt-go"
+	"github.com/golang-jwt/jwt/v4"
 	"src/objects"
+	"src/utils/hashUtils"
+	"time"
 )
 
-var (
-	PrivateKey = []byte("secret key")
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 30 * 24 * time.Hour
+	tokenIDBytes    = 16
+	keyIDHeader     = "kid"
 )
 
-func CreateJWTToken(login string, role objects.Levels) string {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"login": login,
-		"role":  role,
+type Claims struct {
+	UserID    int
+	Login     string
+	Role      objects.Levels
+	TokenID   string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+type tokenClaims struct {
+	UserID int            `json:"uid"`
+	Login  string         `json:"login"`
+	Role   objects.Levels `json:"role"`
+	jwt.RegisteredClaims
+}
+
+func (ks *KeySet) CreateJWTToken(userID int, login string, role objects.Levels) (string, Claims, error) {
+	var claims Claims
+	tokenID, err := hashUtils.GenerateToken(tokenIDBytes)
+	if err != nil {
+		return objects.EmptyString, claims, err
+	}
+
+	now := time.Now()
+	claims = Claims{
+		UserID:    userID,
+		Login:     login,
+		Role:      role,
+		TokenID:   tokenID,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(ks.accessTokenTTL),
+	}
+
+	activeKey := ks.keys[ks.activeKeyID]
+	token := jwt.NewWithClaims(activeKey.method, tokenClaims{
+		UserID: userID,
+		Login:  login,
+		Role:   role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			IssuedAt:  jwt.NewNumericDate(claims.IssuedAt),
+			ExpiresAt: jwt.NewNumericDate(claims.ExpiresAt),
+		},
 	})
+	token.Header[keyIDHeader] = activeKey.id
 
-	tokenString, _ := token.SignedString(PrivateKey)
-	return tokenString
+	tokenString, err := token.SignedString(activeKey.signKey)
+	return tokenString, claims, err
 }
 
-func GetRoleFromJWT(inToken string) (result objects.Levels) {
-	result = objects.NonAuth
-	hashSecretGetter := func(token *jwt.Token) (interface{}, error) {
-		method, ok := token.Method.(*jwt.SigningMethodHMAC)
-		if !ok || method.Alg() != "HS256" {
+// ParseJWT checks the signature and the expiration time of the token.
+// The key is chosen by kid, tokens without kid, exp or jti are rejected.
+func (ks *KeySet) ParseJWT(inToken string) (Claims, error) {
+	var result Claims
+	keyGetter := func(token *jwt.Token) (interface{}, error) {
+		keyID, _ := token.Header[keyIDHeader].(string)
+		key, exist := ks.keys[keyID]
+		if !exist {
+			return nil, fmt.Errorf("unknown key id %q", keyID)
+		}
+		if token.Method.Alg() != key.method.Alg() {
 			return nil, fmt.Errorf("bad sign method")
 		}
-		return PrivateKey, nil
+		return key.verifyKey, nil
 	}
 
-	token, err := jwt.Parse(inToken, hashSecretGetter)
-	if err == nil && token.Valid {
-		payload, ok := token.Claims.(jwt.MapClaims)
-		if ok {
-			floatRole := payload["role"].(float64)
-			result = objects.Levels(floatRole)
-		}
+	payload := tokenClaims{}
+	token, err := jwt.ParseWithClaims(inToken, &payload, keyGetter)
+	if err != nil {
+		return result, err
+	}
+	if !token.Valid || payload.ExpiresAt == nil || payload.ID == objects.EmptyString {
+		return result, fmt.Errorf("token without expiration")
+	}
+
+	result = Claims{
+		UserID:    payload.UserID,
+		Login:     payload.Login,
+		Role:      payload.Role,
+		TokenID:   payload.ID,
+		ExpiresAt: payload.ExpiresAt.Time,
+	}
+	if payload.IssuedAt != nil {
+		result.IssuedAt = payload.IssuedAt.Time
+	}
+	return result, nil
+}
+
+func (c Claims) GetIdentity() objects.Identity {
+	return objects.NewIdentityWithParams(c.UserID, c.Login, c.Role, c.TokenID)
+}
+
+func (ks *KeySet) GetRoleFromJWT(inToken string) (result objects.Levels) {
+	result = objects.NonAuth
+	claims, err := ks.ParseJWT(inToken)
+	if err == nil {
+		result = claims.Role
 	}
 	return result
 }
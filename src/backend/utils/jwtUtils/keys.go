@@ -0,0 +1,211 @@
+package jwtUtils
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"github.com/golang-jwt/jwt/v4"
+	"math/big"
+	"os"
+	"sort"
+	"src/configs/backend"
+	"src/objects"
+	"time"
+)
+
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+	EdDSA = "EdDSA"
+
+	minSecretLength = 32
+)
+
+type signingKey struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// KeySet keeps all keys accepted for verification. Tokens are signed only by the active key,
+// its id is written into the kid header, so old keys can stay in the set until their tokens expire.
+type KeySet struct {
+	activeKeyID     string
+	keys            map[string]signingKey
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+}
+
+func NewKeySet(params configs.JWTParams) (*KeySet, error) {
+	keySet := &KeySet{
+		activeKeyID:     params.ActiveKey,
+		keys:            make(map[string]signingKey),
+		accessTokenTTL:  AccessTokenTTL,
+		refreshTokenTTL: RefreshTokenTTL,
+	}
+	if params.AccessTokenTTL > objects.Null {
+		keySet.accessTokenTTL = params.AccessTokenTTL
+	}
+	if params.RefreshTokenTTL > objects.Null {
+		keySet.refreshTokenTTL = params.RefreshTokenTTL
+	}
+
+	for _, keyParams := range params.Keys {
+		if _, exist := keySet.keys[keyParams.ID]; exist || keyParams.ID == objects.EmptyString {
+			return nil, fmt.Errorf("jwt key %q: empty or duplicate id", keyParams.ID)
+		}
+		key, err := loadKey(keyParams)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %q: %w", keyParams.ID, err)
+		}
+		keySet.keys[key.id] = key
+	}
+
+	activeKey, exist := keySet.keys[params.ActiveKey]
+	if !exist {
+		return nil, fmt.Errorf("active jwt key %q is not configured", params.ActiveKey)
+	}
+	if activeKey.signKey == nil {
+		return nil, fmt.Errorf("active jwt key %q has no private key", params.ActiveKey)
+	}
+	return keySet, nil
+}
+
+func (ks *KeySet) AccessTokenTTL() time.Duration {
+	return ks.accessTokenTTL
+}
+
+func (ks *KeySet) RefreshTokenTTL() time.Duration {
+	return ks.refreshTokenTTL
+}
+
+func loadKey(params configs.JWTKeyParams) (key signingKey, err error) {
+	key.id = params.ID
+	switch params.Algorithm {
+	case HS256:
+		if len(params.Secret) < minSecretLength {
+			return key, fmt.Errorf("secret must be at least %d bytes long", minSecretLength)
+		}
+		key.method = jwt.SigningMethodHS256
+		key.signKey = []byte(params.Secret)
+		key.verifyKey = key.signKey
+	case RS256:
+		key.method = jwt.SigningMethodRS256
+		if hasPrivateKey(params) {
+			var privateKey *rsa.PrivateKey
+			privateKey, err = readPrivatePEM(params, jwt.ParseRSAPrivateKeyFromPEM)
+			if err == nil {
+				key.signKey, key.verifyKey = privateKey, &privateKey.PublicKey
+			}
+		} else if params.PublicKeyFile != objects.EmptyString {
+			key.verifyKey, err = readPEM(params.PublicKeyFile, jwt.ParseRSAPublicKeyFromPEM)
+		}
+	case EdDSA:
+		key.method = jwt.SigningMethodEdDSA
+		if hasPrivateKey(params) {
+			var privateKey ed25519.PrivateKey
+			privateKey, err = readPrivatePEM(params, parseEdPrivateKey)
+			if err == nil {
+				key.signKey, key.verifyKey = privateKey, privateKey.Public()
+			}
+		} else if params.PublicKeyFile != objects.EmptyString {
+			key.verifyKey, err = readPEM(params.PublicKeyFile, parseEdPublicKey)
+		}
+	default:
+		return key, fmt.Errorf("unsupported algorithm %q", params.Algorithm)
+	}
+
+	if err == nil && key.verifyKey == nil {
+		err = fmt.Errorf("neither private nor public key is set")
+	}
+	return key, err
+}
+
+func hasPrivateKey(params configs.JWTKeyParams) bool {
+	return params.PrivateKeyEnv != objects.EmptyString || params.PrivateKeyFile != objects.EmptyString
+}
+
+func readPrivatePEM[T any](params configs.JWTKeyParams, parse func([]byte) (T, error)) (result T, err error) {
+	if params.PrivateKeyEnv == objects.EmptyString {
+		return readPEM(params.PrivateKeyFile, parse)
+	}
+	data, exist := os.LookupEnv(params.PrivateKeyEnv)
+	if !exist || data == objects.EmptyString {
+		return result, fmt.Errorf("environment variable %s is not set", params.PrivateKeyEnv)
+	}
+	return parse([]byte(data))
+}
+
+func readPEM[T any](path string, parse func([]byte) (T, error)) (result T, err error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		result, err = parse(data)
+	}
+	return result, err
+}
+
+func parseEdPrivateKey(data []byte) (ed25519.PrivateKey, error) {
+	key, err := jwt.ParseEdPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("not an ed25519 private key")
+	}
+	return privateKey, nil
+}
+
+func parseEdPublicKey(data []byte) (ed25519.PublicKey, error) {
+	key, err := jwt.ParseEdPublicKeyFromPEM(data)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("not an ed25519 public key")
+	}
+	return publicKey, nil
+}
+
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// GetJWKS returns public parts of asymmetric keys. HS256 secrets are never published.
+func (ks *KeySet) GetJWKS() JWKS {
+	result := JWKS{Keys: make([]JWK, objects.Null)}
+	for _, key := range ks.keys {
+		jwk := JWK{KeyID: key.id, Use: "sig", Algorithm: key.method.Alg()}
+		switch publicKey := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		default:
+			continue
+		}
+		result.Keys = append(result.Keys, jwk)
+	}
+	sort.Slice(result.Keys, func(i, j int) bool {
+		return result.Keys[i].KeyID < result.Keys[j].KeyID
+	})
+	return result
+}
@@ -3,9 +3,20 @@ package logger
 import (
 	"github.com/sirupsen/logrus"
 	"net/http"
+	"src/objects"
 )
 
 func WriteInfoInLog(log *logrus.Entry, r *http.Request, statusCode int, handleMessage string, err error) {
-	log.Infof("Request: method - %s,  url - %s, Result: status_code = %d, text = %s, err = %v",
-		r.Method, r.URL.Path, statusCode, handleMessage, err)
+	identity := objects.IdentityFromContext(r.Context())
+	log.Infof("Request: method - %s,  url - %s, user - %s (id = %d), Result: status_code = %d, text = %s, err = %v",
+		r.Method, r.URL.Path, identity.GetLogin(), identity.GetUserID(), statusCode, handleMessage, err)
+}
+
+func WriteAccessDeniedInLog(log *logrus.Entry, r *http.Request, route string, login string, role objects.Levels) {
+	log.WithFields(logrus.Fields{
+		"audit": "access_denied",
+		"login": login,
+		"role":  role,
+		"route": route,
+	}).Warnf("Access denied: method - %s,  url - %s, remote - %s", r.Method, r.URL.Path, r.RemoteAddr)
 }
@@ -2,10 +2,13 @@ package utils
 
 import (
 	"github.com/gorilla/mux"
+	"net"
 	"net/http"
 	"src/objects"
 	appErrors "src/utils/error"
 	"strconv"
+	"strings"
+	"time"
 )
 
 func GetIntParamByKey(r *http.Request, key string) (int, error) {
@@ -14,6 +17,37 @@ func GetIntParamByKey(r *http.Request, key string) (int, error) {
 	return strconv.Atoi(paramByString)
 }
 
+// GetOptionalIntParamByKey returns None when the parameter is not set.
+func GetOptionalIntParamByKey(r *http.Request, key string) (int, error) {
+	paramByString := r.URL.Query().Get(key)
+	if paramByString == objects.EmptyString {
+		return objects.None, nil
+	}
+	return strconv.Atoi(paramByString)
+}
+
+// GetOptionalBoolParamByKey returns nil when the parameter is not set.
+func GetOptionalBoolParamByKey(r *http.Request, key string) (*bool, error) {
+	paramByString := r.URL.Query().Get(key)
+	if paramByString == objects.EmptyString {
+		return nil, nil
+	}
+	value, err := strconv.ParseBool(paramByString)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// GetDateParamByKey returns zero time when the parameter is not set.
+func GetDateParamByKey(r *http.Request, key string) (time.Time, error) {
+	paramByString := r.URL.Query().Get(key)
+	if paramByString == objects.EmptyString {
+		return time.Time{}, nil
+	}
+	return time.Parse(objects.DateFormat, paramByString)
+}
+
 func GetPageAndSizeFromQuery(r *http.Request) (page int, size int) {
 	pageFromQuery, pageErr := GetIntParamByKey(r, "page")
 	if pageErr != nil {
@@ -42,6 +76,51 @@ func GetMarkNumberFromPath(r *http.Request) (markNumber int, err error) {
 	return markNumber, err
 }
 
+// TrustedProxies are the addresses of reverse proxies allowed to set X-Real-IP.
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies accepts single addresses and CIDR networks.
+func ParseTrustedProxies(proxies []string) (TrustedProxies, error) {
+	result := make(TrustedProxies, objects.Empty)
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, network)
+	}
+	return result, nil
+}
+
+func (tp TrustedProxies) contains(ip net.IP) bool {
+	for _, network := range tp {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetClientIP trusts X-Real-IP only from the trusted proxies, the app ports can be reached directly too.
+func GetClientIP(r *http.Request, proxies TrustedProxies) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	realIP := r.Header.Get("X-Real-IP")
+	if realIP != objects.EmptyString && net.ParseIP(realIP) != nil && proxies.contains(net.ParseIP(host)) {
+		return realIP
+	}
+	return host
+}
+
 func CheckPageAndSize(page, size int) error {
 	if page < objects.Null || size < objects.Null {
 		return appErrors.WrongRequestParamsErr
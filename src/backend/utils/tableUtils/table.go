@@ -0,0 +1,266 @@
+package tableUtils
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"src/objects"
+	"strconv"
+	"strings"
+)
+
+const (
+	CSVFormat  = "csv"
+	XLSXFormat = "xlsx"
+
+	utf8BOM         = "\uFEFF"
+	sharedStrings   = "xl/sharedStrings.xml"
+	workbook        = "xl/workbook.xml"
+	workbookRels    = "xl/_rels/workbook.xml.rels"
+	sharedStringRef = "s"
+	inlineStringRef = "inlineStr"
+
+	// An xlsx file is a zip archive, the limits keep a small upload from unpacking into gigabytes.
+	// The sizes in the zip headers come from the client, so the parts are read through a limit as well.
+	maxPartSize = 64 << 20
+	maxCells    = 1 << 20
+)
+
+// FormatFromFileName returns the table format by the file extension, empty if it is not supported.
+func FormatFromFileName(fileName string) string {
+	format := strings.ToLower(strings.TrimPrefix(path.Ext(fileName), "."))
+	if format != CSVFormat && format != XLSXFormat {
+		return objects.EmptyString
+	}
+	return format
+}
+
+// Row is a table row with its line in the file: the line where a CSV record starts or the number of a sheet row.
+// A sheet row without the number follows the previous one.
+type Row struct {
+	Line  int
+	Cells []string
+}
+
+// ReadTable returns the rows of a CSV file or of the first XLSX sheet as strings, cells are trimmed.
+// Blank rows are skipped in both formats, the other rows keep their line numbers.
+func ReadTable(data []byte, format string) ([]Row, error) {
+	var (
+		rows []Row
+		err  error
+	)
+	switch format {
+	case CSVFormat:
+		rows, err = readCSV(data)
+	case XLSXFormat:
+		rows, err = readXLSX(data)
+	default:
+		err = fmt.Errorf("unsupported table format %q", format)
+	}
+
+	table := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		for i := range row.Cells {
+			row.Cells[i] = strings.TrimSpace(row.Cells[i])
+		}
+		if strings.Join(row.Cells, objects.EmptyString) != objects.EmptyString {
+			table = append(table, row)
+		}
+	}
+	return table, err
+}
+
+// readCSV accepts both comma and semicolon separated files, Excel saves CSV with semicolons in ru locale.
+func readCSV(data []byte) ([]Row, error) {
+	data = bytes.TrimPrefix(data, []byte(utf8BOM))
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+
+	rows := make([]Row, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		} else if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, Row{Line: line, Cells: record})
+	}
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxStringItem `xml:"si"`
+}
+
+type xlsxStringItem struct {
+	Text string   `xml:"t"`
+	Runs []string `xml:"r>t"`
+}
+
+func (si xlsxStringItem) String() string {
+	if len(si.Runs) == 0 {
+		return si.Text
+	}
+	return strings.Join(si.Runs, "")
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Items []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Ref   int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string         `xml:"r,attr"`
+			Type   string         `xml:"t,attr"`
+			Value  string         `xml:"v"`
+			Inline xlsxStringItem `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readXLSX(data []byte) ([]Row, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*zip.File)
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	var strs xlsxSharedStrings
+	if _, exist := files[sharedStrings]; exist {
+		if err = decodeXML(files, sharedStrings, &strs); err != nil {
+			return nil, err
+		}
+	}
+	sheetName, err := firstSheetName(files)
+	if err != nil {
+		return nil, err
+	}
+	var sheet xlsxSheet
+	if err = decodeXML(files, sheetName, &sheet); err != nil {
+		return nil, err
+	}
+
+	cells, line := 0, 0
+	rows := make([]Row, 0, len(sheet.Rows))
+	for _, sheetRow := range sheet.Rows {
+		line++
+		if sheetRow.Ref > objects.Null {
+			line = sheetRow.Ref
+		}
+		row := make([]string, 0)
+		for i, cell := range sheetRow.Cells {
+			column := i
+			if cell.Ref != objects.EmptyString {
+				column = columnIndex(cell.Ref)
+			}
+			if column >= len(row) {
+				if cells += column + 1 - len(row); cells > maxCells {
+					return nil, fmt.Errorf("sheet has more than %d cells", maxCells)
+				}
+			}
+			for len(row) <= column {
+				row = append(row, objects.EmptyString)
+			}
+			switch cell.Type {
+			case sharedStringRef:
+				index, convErr := strconv.Atoi(cell.Value)
+				if convErr != nil || index < 0 || index >= len(strs.Items) {
+					return nil, fmt.Errorf("cell %s: bad shared string %q", cell.Ref, cell.Value)
+				}
+				row[column] = strs.Items[index].String()
+			case inlineStringRef:
+				row[column] = cell.Inline.String()
+			default:
+				row[column] = cell.Value
+			}
+		}
+		rows = append(rows, Row{Line: line, Cells: row})
+	}
+	return rows, nil
+}
+
+// firstSheetName finds the file of the first sheet in the workbook, sheet1.xml is not always the first one.
+func firstSheetName(files map[string]*zip.File) (string, error) {
+	var (
+		book xlsxWorkbook
+		rels xlsxRelationships
+	)
+	if err := decodeXML(files, workbook, &book); err != nil {
+		return objects.EmptyString, err
+	}
+	if err := decodeXML(files, workbookRels, &rels); err != nil {
+		return objects.EmptyString, err
+	}
+	if len(book.Sheets) == 0 {
+		return objects.EmptyString, fmt.Errorf("workbook has no sheets")
+	}
+	for _, rel := range rels.Items {
+		if rel.ID == book.Sheets[0].RelID {
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/"), nil
+			}
+			return path.Join("xl", rel.Target), nil
+		}
+	}
+	return objects.EmptyString, fmt.Errorf("first sheet is not found in workbook relationships")
+}
+
+func decodeXML(files map[string]*zip.File, name string, result any) error {
+	file, exist := files[name]
+	if !exist {
+		return fmt.Errorf("%s is not found in xlsx file", name)
+	}
+	if file.UncompressedSize64 > maxPartSize {
+		return fmt.Errorf("%s is larger than %d bytes", name, maxPartSize)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return xml.NewDecoder(io.LimitReader(reader, maxPartSize)).Decode(result)
+}
+
+// columnIndex converts the column letters of a cell reference to a zero-based index: "C7" is 2.
+func columnIndex(ref string) int {
+	index := 0
+	for _, letter := range ref {
+		if letter < 'A' || letter > 'Z' {
+			break
+		}
+		index = index*26 + int(letter-'A'+1)
+	}
+	return index - 1
+}
+
+// WriteCSV writes rows as a UTF-8 CSV with BOM, so Excel opens Cyrillic text correctly.
+func WriteCSV(w io.Writer, rows [][]string) error {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return err
+	}
+	return csv.NewWriter(w).WriteAll(rows)
+}
@@ -0,0 +1,233 @@
+package tableUtils
+
+import (
+	"bytes"
+	"github.com/bloomberg/go-testgroup"
+	"src/objects"
+	"src/tests"
+	"src/tests/mother"
+	"testing"
+	"time"
+)
+
+type TestTable struct{}
+
+func Test_Table(t *testing.T) {
+	testgroup.RunSerially(t, &TestTable{})
+}
+
+// TestTable_ReadCSV проверяет, что BOM и пустые строки пропускаются, ячейки обрезаются,
+// а строки сохраняют свои номера в файле, в том числе после записи в несколько строк.
+func (*TestTable) TestTable_ReadCSV(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	data := []byte(utf8BOM + "number,surname\n\n ИУ7-1 , Иванов\n\"ИУ7-2\",\"Петров\nмл.\"\n,\nИУ7-3,Сидоров\n")
+	realRows := []Row{
+		{Line: 1, Cells: []string{"number", "surname"}},
+		{Line: 3, Cells: []string{"ИУ7-1", "Иванов"}},
+		{Line: 4, Cells: []string{"ИУ7-2", "Петров\nмл."}},
+		{Line: 7, Cells: []string{"ИУ7-3", "Сидоров"}},
+	}
+
+	// Act
+	rows, execErr := ReadTable(data, CSVFormat)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertResult(t, rows, realRows)
+}
+
+// TestTable_ReadCSVSemicolon проверяет, что файл из Excel с точками с запятой читается по своему разделителю.
+func (*TestTable) TestTable_ReadCSVSemicolon(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	data := []byte("number;surname;address\r\nИУ7-1;Иванов;ул. Бауманская, 5\r\n")
+	realRows := []Row{
+		{Line: 1, Cells: []string{"number", "surname", "address"}},
+		{Line: 2, Cells: []string{"ИУ7-1", "Иванов", "ул. Бауманская, 5"}},
+	}
+
+	// Act
+	rows, execErr := ReadTable(data, CSVFormat)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertResult(t, rows, realRows)
+}
+
+// TestTable_ReadCSVBroken проверяет, что ошибка разбора CSV возвращается.
+func (*TestTable) TestTable_ReadCSVBroken(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	data := []byte("number,surname\nИУ7-1,\"Иванов\n")
+
+	// Act
+	rows, execErr := ReadTable(data, CSVFormat)
+
+	// Assert
+	tests.AssertResult(t, execErr != nil, true)
+	tests.AssertResult(t, len(rows), objects.Empty)
+}
+
+// TestTable_ReadUnknownFormat проверяет, что неизвестный формат не читается.
+func (*TestTable) TestTable_ReadUnknownFormat(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	data := []byte("number,surname\n")
+
+	// Act
+	_, execErr := ReadTable(data, "ods")
+
+	// Assert
+	tests.AssertResult(t, execErr != nil, true)
+}
+
+// TestTable_ReadXLSX проверяет общие и встроенные строки, пропуски между ячейками, номера строк листа
+// и то, что читается первый лист книги, а не sheet2.xml из первой связи.
+func (*TestTable) TestTable_ReadXLSX(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TableObjectMother{}
+	sheet := objectMother.CreateSheet(
+		`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>` +
+			`<row r="3"><c r="A3" t="inlineStr"><is><r><t>ИУ7</t></r><r><t>-1</t></r></is></c>` +
+			`<c r="C3"><v>5</v></c></row>` +
+			`<row r="4"><c r="A4" t="s"><v>2</v></c></row>` +
+			`<row><c t="inlineStr"><is><t>ИУ7-2</t></is></c><c><v>7</v></c></row>`)
+	data := objectMother.CreateXLSX(sheet, objectMother.CreateSharedStrings("number", "room", " "))
+	realRows := []Row{
+		{Line: 1, Cells: []string{"number", "room"}},
+		{Line: 3, Cells: []string{"ИУ7-1", objects.EmptyString, "5"}},
+		{Line: 5, Cells: []string{"ИУ7-2", "7"}},
+	}
+
+	// Act
+	rows, execErr := ReadTable(data, XLSXFormat)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertResult(t, rows, realRows)
+}
+
+// TestTable_ReadXLSXBadSharedString проверяет, что ссылка на несуществующую общую строку не читается.
+func (*TestTable) TestTable_ReadXLSXBadSharedString(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TableObjectMother{}
+	sheet := objectMother.CreateSheet(`<row r="1"><c r="A1" t="s"><v>1</v></c></row>`)
+	data := objectMother.CreateXLSX(sheet, objectMother.CreateSharedStrings("number"))
+
+	// Act
+	_, execErr := ReadTable(data, XLSXFormat)
+
+	// Assert
+	tests.AssertResult(t, execErr != nil, true)
+}
+
+// TestTable_ReadXLSXOversizedPart проверяет, что часть архива больше maxPartSize не распаковывается.
+func (*TestTable) TestTable_ReadXLSXOversizedPart(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	data := mother.TableObjectMother{}.CreateOversizedXLSX(maxPartSize + 1)
+
+	// Act
+	rows, execErr := ReadTable(data, XLSXFormat)
+
+	// Assert
+	tests.AssertResult(t, execErr != nil, true)
+	tests.AssertResult(t, len(rows), objects.Empty)
+}
+
+// TestTable_ReadXLSXTooManyCells проверяет, что лист больше maxCells не читается,
+// даже если ячеек мало, но ссылка уводит далеко вправо.
+func (*TestTable) TestTable_ReadXLSXTooManyCells(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	objectMother := mother.TableObjectMother{}
+	sheet := objectMother.CreateSheet(`<row r="1"><c r="A1"><v>1</v></c><c r="ZZZZZ1"><v>2</v></c></row>`)
+	data := objectMother.CreateXLSX(sheet, objects.EmptyString)
+
+	// Act
+	rows, execErr := ReadTable(data, XLSXFormat)
+
+	// Assert
+	tests.AssertResult(t, execErr != nil, true)
+	tests.AssertResult(t, len(rows), objects.Empty)
+}
+
+// TestTable_ReadXLSXNotZip проверяет, что файл не в формате zip не читается.
+func (*TestTable) TestTable_ReadXLSXNotZip(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	data := []byte("number,surname\n")
+
+	// Act
+	_, execErr := ReadTable(data, XLSXFormat)
+
+	// Assert
+	tests.AssertResult(t, execErr != nil, true)
+}
+
+// TestTable_ColumnIndex проверяет перевод букв столбца в индекс с нуля.
+func (*TestTable) TestTable_ColumnIndex(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	refs := []string{"A1", "C7", "Z10", "AA1", "AZ3", "XFD1048576"}
+	realIndexes := []int{0, 2, 25, 26, 51, 16383}
+
+	// Act
+	indexes := make([]int, objects.Empty)
+	for _, ref := range refs {
+		indexes = append(indexes, columnIndex(ref))
+	}
+
+	// Assert
+	tests.AssertResult(t, indexes, realIndexes)
+}
+
+// TestTable_FormatFromFileName проверяет, что формат определяется по расширению без учёта регистра.
+func (*TestTable) TestTable_FormatFromFileName(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	names := []string{"students.csv", "Students.XLSX", "students.xls", "students"}
+	realFormats := []string{CSVFormat, XLSXFormat, objects.EmptyString, objects.EmptyString}
+
+	// Act
+	formats := make([]string, objects.Empty)
+	for _, name := range names {
+		formats = append(formats, FormatFromFileName(name))
+	}
+
+	// Assert
+	tests.AssertResult(t, formats, realFormats)
+}
+
+// TestTable_WriteCSV проверяет, что записанный файл начинается с BOM и читается обратно.
+func (*TestTable) TestTable_WriteCSV(t *testgroup.T) {
+	defer tests.TimeTrack(time.Now())
+	// Arrange
+
+	var buffer bytes.Buffer
+	table := [][]string{{"number", "password"}, {"ИУ7-1", "a,b"}}
+
+	// Act
+	execErr := WriteCSV(&buffer, table)
+	rows, readErr := ReadTable(buffer.Bytes(), CSVFormat)
+
+	// Assert
+	tests.AssertErrors(t, execErr, nil)
+	tests.AssertErrors(t, readErr, nil)
+	tests.AssertResult(t, bytes.HasPrefix(buffer.Bytes(), []byte(utf8BOM)), true)
+	tests.AssertResult(t, rows, []Row{{Line: 1, Cells: table[0]}, {Line: 2, Cells: table[1]}})
+}